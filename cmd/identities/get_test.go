@@ -2,9 +2,10 @@ package identities
 
 import (
 	"context"
-	"encoding/json"
 	"testing"
 
+	"github.com/ory/kratos-client-go"
+
 	"kratos/x"
 
 	"github.com/stretchr/testify/assert"
@@ -23,10 +24,7 @@ func TestGetCmd(t *testing.T) {
 
 		stdOut := execNoErr(t, GetCmd, i.ID.String())
 
-		ij, err := json.Marshal(i)
-		require.NoError(t, err)
-
-		assert.Equal(t, string(ij)+"\n", stdOut)
+		assert.Equal(t, sdkJSON(t, i, new(kratos.Identity))+"\n", stdOut)
 	})
 
 	t.Run("case=gets three identities", func(t *testing.T) {
@@ -34,10 +32,7 @@ func TestGetCmd(t *testing.T) {
 
 		stdOut := execNoErr(t, GetCmd, ids...)
 
-		isj, err := json.Marshal(is)
-		require.NoError(t, err)
-
-		assert.Equal(t, string(isj)+"\n", stdOut)
+		assert.Equal(t, sdkJSON(t, is, new([]kratos.Identity))+"\n", stdOut)
 	})
 
 	t.Run("case=fails with unknown ID", func(t *testing.T) {
@@ -3,6 +3,7 @@ package identities
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -112,3 +113,14 @@ func makeIdentities(t *testing.T, reg driver.Registry, n int) (is []*identity.Id
 	}
 	return
 }
+
+// sdkJSON returns the JSON encoding of v as the CLI prints it. Fields which the SDK model does not know are dropped.
+func sdkJSON(t *testing.T, v interface{}, model interface{}) string {
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, model))
+
+	raw, err = json.Marshal(model)
+	require.NoError(t, err)
+	return string(raw)
+}
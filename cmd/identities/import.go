@@ -24,7 +24,21 @@ var ImportCmd = &cobra.Command{
     "schema_id": "default",
     "traits": {
         "email": "foo@example.com"
-    }
+    },
+    "credentials": {
+        "password": {
+            "config": {
+                "hashed_password": "$2a$10$ZsCsoVQ3xfBG/K2z2XpBf.tm90GZmtOqtqWcB5.pYd5Eq8y7RlDyq"
+            }
+        }
+    },
+    "verifiable_addresses": [
+        {
+            "value": "foo@example.com",
+            "via": "email",
+            "verified": true
+        }
+    ]
 }
 EOF
 
@@ -35,7 +49,8 @@ $ cat file.json | kratos identities import`,
 
 Files can contain only a single or an array of identities. The validity of files can be tested beforehand using "... identities validate".
 
-WARNING: Importing credentials is not yet supported.`,
+Password credentials can be imported in clear text ("password") or as a hash ("hashed_password", for example bcrypt or Argon2id).
+OpenID Connect credentials are imported as provider and subject pairs. The verification status of addresses is kept.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		c := cliclient.NewClient(cmd)
 
@@ -16,6 +16,7 @@ import (
 	"github.com/ory/kratos-client-go"
 	"github.com/ory/x/cmdx"
 	"kratos/driver/config"
+	"kratos/identity"
 )
 
 func TestImportCmd(t *testing.T) {
@@ -119,6 +120,29 @@ func TestImportCmd(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("case=imports an identity with credentials", func(t *testing.T) {
+		stdOut, stdErr, err := exec(ImportCmd, bytes.NewBufferString(`{
+  "schema_id": "`+config.DefaultIdentityTraitsSchemaID+`",
+  "traits": {},
+  "credentials": {
+    "password": {"config": {"password": "b6f2c9cb-4c7e-4b4b-8a8e-9c3f1b0f7d2e"}},
+    "oidc": {"config": {"providers": [{"provider": "github", "subject": "import-cmd-subject"}]}}
+  }
+}`))
+		require.NoError(t, err, "%s %s", stdOut, stdErr)
+
+		id, err := uuid.FromString(gjson.Get(stdOut, "id").String())
+		require.NoError(t, err)
+		i, err := reg.Persister().GetIdentityConfidential(context.Background(), id)
+		require.NoError(t, err)
+
+		_, ok := i.GetCredentials(identity.CredentialsTypePassword)
+		assert.True(t, ok)
+		c, ok := i.GetCredentials(identity.CredentialsTypeOIDC)
+		require.True(t, ok)
+		assert.Equal(t, []string{"github:import-cmd-subject"}, c.Identifiers)
+	})
+
 	t.Run("case=fails to import invalid identity", func(t *testing.T) {
 		// validation is further tested with the validate command
 		stdOut, stdErr, err := exec(ImportCmd, bytes.NewBufferString("{}"))
@@ -84,7 +84,7 @@ func RegisterFakes() {
 
 	if err := faker.AddProvider("ui_node_attributes", func(v reflect.Value) (interface{}, error) {
 		var a node.Attributes
-		switch rand.Intn(4) {
+		switch rand.Intn(5) {
 		case 0:
 			a = new(node.InputAttributes)
 		case 1:
@@ -93,6 +93,8 @@ func RegisterFakes() {
 			a = new(node.AnchorAttributes)
 		case 3:
 			a = new(node.TextAttributes)
+		case 4:
+			a = new(node.ScriptAttributes)
 		}
 
 		if err := faker.FakeData(a); err != nil {
@@ -138,65 +138,71 @@ func (m *Courier) watchMessages(ctx context.Context, errChan chan error) {
 func (m *Courier) DispatchMessage(ctx context.Context, msg Message) error {
 	switch msg.Type {
 	case MessageTypeEmail:
-		from := m.d.Config(ctx).CourierSMTPFrom()
-		fromName := m.d.Config(ctx).CourierSMTPFromName()
-		gm := gomail.NewMessage()
-		if fromName == "" {
-			gm.SetHeader("From", from)
-		} else {
-			gm.SetAddressHeader("From", from, fromName)
-		}
+		return m.dispatchEmail(ctx, msg)
+	case MessageTypeSMS:
+		return m.dispatchSMS(ctx, msg)
+	}
+	return errors.Errorf("received unexpected message type: %d", msg.Type)
+}
+
+func (m *Courier) dispatchEmail(ctx context.Context, msg Message) error {
+	from := m.d.Config(ctx).CourierSMTPFrom()
+	fromName := m.d.Config(ctx).CourierSMTPFromName()
+	gm := gomail.NewMessage()
+	if fromName == "" {
+		gm.SetHeader("From", from)
+	} else {
+		gm.SetAddressHeader("From", from, fromName)
+	}
 
-		gm.SetHeader("To", msg.Recipient)
-		gm.SetHeader("Subject", msg.Subject)
-		gm.SetBody("text/plain", msg.Body)
+	gm.SetHeader("To", msg.Recipient)
+	gm.SetHeader("Subject", msg.Subject)
+	gm.SetBody("text/plain", msg.Body)
 
-		tmpl, err := NewEmailTemplateFromMessage(m.d.Config(ctx), msg)
+	tmpl, err := NewEmailTemplateFromMessage(m.d.Config(ctx), msg)
+	if err != nil {
+		m.d.Logger().
+			WithError(err).
+			WithField("message_id", msg.ID).
+			Error(`Unable to get email template from message.`)
+	} else {
+		htmlBody, err := tmpl.EmailBody()
 		if err != nil {
 			m.d.Logger().
 				WithError(err).
 				WithField("message_id", msg.ID).
-				Error(`Unable to get email template from message.`)
+				Error(`Unable to get email body from template.`)
 		} else {
-			htmlBody, err := tmpl.EmailBody()
-			if err != nil {
-				m.d.Logger().
-					WithError(err).
-					WithField("message_id", msg.ID).
-					Error(`Unable to get email body from template.`)
-			} else {
-				gm.AddAlternative("text/html", htmlBody)
-			}
-		}
-
-		if err := m.Dialer.DialAndSend(ctx, gm); err != nil {
-			m.d.Logger().
-				WithError(err).
-				WithField("smtp_server", fmt.Sprintf("%s:%d", m.Dialer.Host, m.Dialer.Port)).
-				WithField("smtp_ssl_enabled", m.Dialer.SSL).
-				// WithField("email_to", msg.Recipient).
-				WithField("message_from", from).
-				Error("Unable to send email using SMTP connection.")
-			return errors.WithStack(err)
+			gm.AddAlternative("text/html", htmlBody)
 		}
+	}
 
-		if err := m.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
-			m.d.Logger().
-				WithError(err).
-				WithField("message_id", msg.ID).
-				Error(`Unable to set the message status to "sent".`)
-			return err
-		}
+	if err := m.Dialer.DialAndSend(ctx, gm); err != nil {
+		m.d.Logger().
+			WithError(err).
+			WithField("smtp_server", fmt.Sprintf("%s:%d", m.Dialer.Host, m.Dialer.Port)).
+			WithField("smtp_ssl_enabled", m.Dialer.SSL).
+			// WithField("email_to", msg.Recipient).
+			WithField("message_from", from).
+			Error("Unable to send email using SMTP connection.")
+		return errors.WithStack(err)
+	}
 
+	if err := m.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
 		m.d.Logger().
+			WithError(err).
 			WithField("message_id", msg.ID).
-			WithField("message_type", msg.Type).
-			WithField("message_template_type", msg.TemplateType).
-			WithField("message_subject", msg.Subject).
-			Debug("Courier sent out message.")
-		return nil
+			Error(`Unable to set the message status to "sent".`)
+		return err
 	}
-	return errors.Errorf("received unexpected message type: %d", msg.Type)
+
+	m.d.Logger().
+		WithField("message_id", msg.ID).
+		WithField("message_type", msg.Type).
+		WithField("message_template_type", msg.TemplateType).
+		WithField("message_subject", msg.Subject).
+		Debug("Courier sent out message.")
+	return nil
 }
 
 func (m *Courier) DispatchQueue(ctx context.Context) error {
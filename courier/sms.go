@@ -0,0 +1,212 @@
+package courier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/google/go-jsonnet"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"kratos/request"
+)
+
+const smsRequestTimeout = 10 * time.Second
+
+type (
+	smsRequestConfig struct {
+		URL     string            `json:"url"`
+		Method  string            `json:"method"`
+		Headers map[string]string `json:"headers"`
+		Body    string            `json:"body"`
+		Auth    request.Auth      `json:"auth"`
+	}
+
+	// smsTemplateContext is available as `std.extVar('ctx')` in the Jsonnet body template.
+	smsTemplateContext struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Body string `json:"body"`
+	}
+)
+
+func (m *Courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error) {
+	if !m.d.Config(ctx).CourierSMSEnabled() {
+		return uuid.Nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to send a text message because courier.sms.enabled is not set."))
+	}
+
+	recipient, err := t.PhoneNumber()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	body, err := t.SMSBody()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	templateType, err := GetSMSTemplateType(t)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	templateData, err := json.Marshal(t)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	message := &Message{
+		Status:       MessageStatusQueued,
+		Type:         MessageTypeSMS,
+		Recipient:    recipient,
+		Body:         body,
+		TemplateType: templateType,
+		TemplateData: templateData,
+	}
+	if err := m.d.CourierPersister().AddMessage(ctx, message); err != nil {
+		return uuid.Nil, err
+	}
+	return message.ID, nil
+}
+
+func (m *Courier) dispatchSMS(ctx context.Context, msg Message) error {
+	var c smsRequestConfig
+	if err := json.Unmarshal(m.d.Config(ctx).CourierSMSRequestConfig(), &c); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the SMS request configuration: %s", err))
+	}
+	if c.Method == "" {
+		c.Method = "POST"
+	}
+
+	// The timeout also limits the retries of the resilient client.
+	ctx, cancel := context.WithTimeout(ctx, smsRequestTimeout)
+	defer cancel()
+
+	req, err := newSMSRequest(ctx, &c, &smsTemplateContext{
+		From: m.d.Config(ctx).CourierSMSFrom(),
+		To:   msg.Recipient,
+		Body: msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := request.NewClient(smsRequestTimeout).Do(req)
+	if err != nil {
+		m.d.Logger().
+			WithError(err).
+			WithField("sms_provider_url", c.URL).
+			WithField("message_id", msg.ID).
+			Error("Unable to send text message using the SMS provider.")
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		m.d.Logger().
+			WithField("sms_provider_url", c.URL).
+			WithField("sms_provider_status_code", res.StatusCode).
+			WithField("message_id", msg.ID).
+			Error("The SMS provider responded with an unexpected status code.")
+		return errors.Errorf("the SMS provider responded with unexpected status code %d", res.StatusCode)
+	}
+
+	if err := m.d.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusSent); err != nil {
+		m.d.Logger().
+			WithError(err).
+			WithField("message_id", msg.ID).
+			Error(`Unable to set the message status to "sent".`)
+		return err
+	}
+
+	m.d.Logger().
+		WithField("message_id", msg.ID).
+		WithField("message_type", msg.Type).
+		WithField("message_template_type", msg.TemplateType).
+		Debug("Courier sent out message.")
+	return nil
+}
+
+func newSMSRequest(ctx context.Context, c *smsRequestConfig, tc *smsTemplateContext) (*retryablehttp.Request, error) {
+	rendered, err := renderSMSBody(c, tc)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := "application/json"
+	for k, v := range c.Headers {
+		if http.CanonicalHeaderKey(k) == "Content-Type" {
+			contentType = v
+		}
+	}
+
+	body := rendered
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if !gjson.Valid(rendered) || !gjson.Parse(rendered).IsObject() {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The SMS body template must render an object to be sent as form values."))
+		}
+
+		values := url.Values{}
+		gjson.Parse(rendered).ForEach(func(key, value gjson.Result) bool {
+			values.Set(key.String(), value.String())
+			return true
+		})
+		body = values.Encode()
+	}
+
+	req, err := retryablehttp.NewRequest(c.Method, c.URL, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to create the SMS request: %s", err))
+	}
+
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if err := c.Auth.Apply(req.Request); err != nil {
+		return nil, err
+	}
+
+	return req.WithContext(ctx), nil
+}
+
+// renderSMSBody evaluates the Jsonnet body template. If no template is configured, the template context
+// itself is sent.
+func renderSMSBody(c *smsRequestConfig, tc *smsTemplateContext) (string, error) {
+	ctx, err := json.Marshal(tc)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if c.Body == "" {
+		return string(ctx), nil
+	}
+
+	template, err := fetcher.NewFetcher().Fetch(c.Body)
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to fetch the SMS body template: %s", err))
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.ExtCode("ctx", string(ctx))
+	evaluated, err := vm.EvaluateSnippet(c.Body, template.String())
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to render the SMS body template: %s", strings.TrimSpace(err.Error())))
+	}
+
+	return evaluated, nil
+}
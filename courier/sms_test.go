@@ -0,0 +1,107 @@
+package courier_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kratos/courier"
+	templates "kratos/courier/template"
+	"kratos/driver/config"
+	"kratos/internal"
+)
+
+func TestQueueSMS(t *testing.T) {
+	ctx := context.Background()
+
+	type sentSMS struct {
+		Header http.Header
+		Body   []byte
+	}
+
+	var sent []sentSMS
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		sent = append(sent, sentSMS{Header: r.Header, Body: body})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	newRegistry := func(t *testing.T, requestConfig string) (*config.Config, *courier.Courier) {
+		sent = nil
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+		conf.MustSet(config.ViperKeyCourierSMSFrom, "+12065550199")
+
+		var rc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(requestConfig), &rc))
+		conf.MustSet(config.ViperKeyCourierSMSRequestConfig, rc)
+		return conf, reg.Courier(ctx)
+	}
+
+	t.Run("case=fails if SMS is disabled", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		_, err := reg.Courier(ctx).QueueSMS(ctx, templates.NewSMSTestStub(conf, &templates.SMSTestStubModel{To: "+12065550100", Body: "hi"}))
+		require.Error(t, err)
+	})
+
+	t.Run("case=sends the template context as JSON if no body is configured", func(t *testing.T) {
+		conf, c := newRegistry(t, `{"url": "`+srv.URL+`", "auth": {"type": "api_key", "config": {"name": "X-Api-Key", "value": "secret"}}}`)
+
+		id, err := c.QueueSMS(ctx, templates.NewSMSTestStub(conf, &templates.SMSTestStubModel{To: "+12065550100", Body: "test-body-1"}))
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, id)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		require.Len(t, sent, 1)
+		assert.Equal(t, "secret", sent[0].Header.Get("X-Api-Key"))
+		assert.Equal(t, "application/json", sent[0].Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"from":"+12065550199","to":"+12065550100","body":"stub sms body test-body-1"}`, string(sent[0].Body))
+	})
+
+	t.Run("case=renders the body template as form values", func(t *testing.T) {
+		body := base64.StdEncoding.EncodeToString([]byte(`local ctx = std.extVar('ctx'); { To: ctx.to, From: ctx.from, Body: ctx.body }`))
+		conf, c := newRegistry(t, `{
+  "url": "`+srv.URL+`",
+  "body": "base64://`+body+`",
+  "headers": {"Content-Type": "application/x-www-form-urlencoded"},
+  "auth": {"type": "basic_auth", "config": {"user": "me", "password": "12345"}}
+}`)
+
+		_, err := c.QueueSMS(ctx, templates.NewOTPMessage(conf, &templates.OTPMessageModel{To: "+12065550100", Code: "424242"}))
+		require.NoError(t, err)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+		require.Len(t, sent, 1)
+		assert.Equal(t, "application/x-www-form-urlencoded", sent[0].Header.Get("Content-Type"))
+		assert.Contains(t, sent[0].Header.Get("Authorization"), "Basic ")
+
+		values, err := url.ParseQuery(string(sent[0].Body))
+		require.NoError(t, err)
+		assert.Equal(t, "+12065550100", values.Get("To"))
+		assert.Equal(t, "+12065550199", values.Get("From"))
+		assert.Contains(t, values.Get("Body"), "424242")
+	})
+
+	t.Run("case=keeps the message queued if the provider fails", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(failing.Close)
+
+		conf, c := newRegistry(t, `{"url": "`+failing.URL+`"}`)
+		_, err := c.QueueSMS(ctx, templates.NewSMSTestStub(conf, &templates.SMSTestStubModel{To: "+12065550100", Body: "test-body-2"}))
+		require.NoError(t, err)
+		require.Error(t, c.DispatchQueue(ctx))
+	})
+}
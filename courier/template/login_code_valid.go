@@ -0,0 +1,43 @@
+package template
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"kratos/driver/config"
+)
+
+type (
+	LoginCodeValid struct {
+		c *config.Config
+		m *LoginCodeValidModel
+	}
+	LoginCodeValidModel struct {
+		To        string
+		LoginCode string
+	}
+)
+
+func NewLoginCodeValid(c *config.Config, m *LoginCodeValidModel) *LoginCodeValid {
+	return &LoginCodeValid{c: c, m: m}
+}
+
+func (t *LoginCodeValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *LoginCodeValid) EmailSubject() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "login_code/valid/email.subject.gotmpl"), t.m)
+}
+
+func (t *LoginCodeValid) EmailBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "login_code/valid/email.body.gotmpl"), t.m)
+}
+
+func (t *LoginCodeValid) EmailBodyPlaintext() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "login_code/valid/email.body.plaintext.gotmpl"), t.m)
+}
+
+func (t *LoginCodeValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
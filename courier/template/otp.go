@@ -0,0 +1,35 @@
+package template
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"kratos/driver/config"
+)
+
+type (
+	OTPMessage struct {
+		c *config.Config
+		m *OTPMessageModel
+	}
+	OTPMessageModel struct {
+		To   string
+		Code string
+	}
+)
+
+func NewOTPMessage(c *config.Config, m *OTPMessageModel) *OTPMessage {
+	return &OTPMessage{c: c, m: m}
+}
+
+func (t *OTPMessage) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *OTPMessage) SMSBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "otp/sms.body.gotmpl"), t.m)
+}
+
+func (t *OTPMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,24 @@
+package template_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kratos/courier/template"
+	"kratos/internal"
+)
+
+func TestOTPMessage(t *testing.T) {
+	conf, _ := internal.NewFastRegistryWithMocks(t)
+	tpl := template.NewOTPMessage(conf, &template.OTPMessageModel{To: "+12065550100", Code: "123456"})
+
+	rendered, err := tpl.SMSBody()
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "123456")
+
+	to, err := tpl.PhoneNumber()
+	require.NoError(t, err)
+	assert.Equal(t, "+12065550100", to)
+}
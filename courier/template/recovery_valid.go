@@ -38,6 +38,14 @@ func (t *RecoveryValid) EmailBodyPlaintext() (string, error) {
 	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "recovery/valid/email.body.plaintext.gotmpl"), t.m)
 }
 
+func (t *RecoveryValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RecoveryValid) SMSBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "recovery/valid/sms.body.gotmpl"), t.m)
+}
+
 func (t *RecoveryValid) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.m)
 }
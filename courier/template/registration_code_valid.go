@@ -0,0 +1,43 @@
+package template
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"kratos/driver/config"
+)
+
+type (
+	RegistrationCodeValid struct {
+		c *config.Config
+		m *RegistrationCodeValidModel
+	}
+	RegistrationCodeValidModel struct {
+		To               string
+		RegistrationCode string
+	}
+)
+
+func NewRegistrationCodeValid(c *config.Config, m *RegistrationCodeValidModel) *RegistrationCodeValid {
+	return &RegistrationCodeValid{c: c, m: m}
+}
+
+func (t *RegistrationCodeValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *RegistrationCodeValid) EmailSubject() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "registration_code/valid/email.subject.gotmpl"), t.m)
+}
+
+func (t *RegistrationCodeValid) EmailBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "registration_code/valid/email.body.gotmpl"), t.m)
+}
+
+func (t *RegistrationCodeValid) EmailBodyPlaintext() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "registration_code/valid/email.body.plaintext.gotmpl"), t.m)
+}
+
+func (t *RegistrationCodeValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -0,0 +1,34 @@
+package template
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"kratos/driver/config"
+)
+
+type SMSTestStub struct {
+	c *config.Config
+	m *SMSTestStubModel
+}
+
+type SMSTestStubModel struct {
+	To   string
+	Body string
+}
+
+func NewSMSTestStub(c *config.Config, m *SMSTestStubModel) *SMSTestStub {
+	return &SMSTestStub{c: c, m: m}
+}
+
+func (t *SMSTestStub) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *SMSTestStub) SMSBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "test_stub/sms.body.gotmpl"), t.m)
+}
+
+func (t *SMSTestStub) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
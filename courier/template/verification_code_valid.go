@@ -0,0 +1,51 @@
+package template
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"kratos/driver/config"
+)
+
+type (
+	VerificationCodeValid struct {
+		c *config.Config
+		m *VerificationCodeValidModel
+	}
+	VerificationCodeValidModel struct {
+		To               string
+		VerificationCode string
+	}
+)
+
+func NewVerificationCodeValid(c *config.Config, m *VerificationCodeValidModel) *VerificationCodeValid {
+	return &VerificationCodeValid{c: c, m: m}
+}
+
+func (t *VerificationCodeValid) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationCodeValid) EmailSubject() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "verification_code/valid/email.subject.gotmpl"), t.m)
+}
+
+func (t *VerificationCodeValid) EmailBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "verification_code/valid/email.body.gotmpl"), t.m)
+}
+
+func (t *VerificationCodeValid) EmailBodyPlaintext() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "verification_code/valid/email.body.plaintext.gotmpl"), t.m)
+}
+
+func (t *VerificationCodeValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationCodeValid) SMSBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "verification_code/valid/sms.body.gotmpl"), t.m)
+}
+
+func (t *VerificationCodeValid) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
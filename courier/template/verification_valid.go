@@ -38,6 +38,14 @@ func (t *VerificationValid) EmailBodyPlaintext() (string, error) {
 	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "verification/valid/email.body.plaintext.gotmpl"), t.m)
 }
 
+func (t *VerificationValid) PhoneNumber() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *VerificationValid) SMSBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "verification/valid/sms.body.gotmpl"), t.m)
+}
+
 func (t *VerificationValid) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.m)
 }
@@ -21,4 +21,8 @@ func TestVerifyValid(t *testing.T) {
 	rendered, err = tpl.EmailSubject()
 	require.NoError(t, err)
 	assert.NotEmpty(t, rendered)
+
+	rendered, err = tpl.SMSBody()
+	require.NoError(t, err)
+	assert.NotEmpty(t, rendered)
 }
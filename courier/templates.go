@@ -12,11 +12,17 @@ import (
 type TemplateType string
 
 const (
-	TypeRecoveryInvalid     TemplateType = "recovery_invalid"
-	TypeRecoveryValid       TemplateType = "recovery_valid"
-	TypeVerificationInvalid TemplateType = "verification_invalid"
-	TypeVerificationValid   TemplateType = "verification_valid"
-	TypeTestStub            TemplateType = "stub"
+	TypeRecoveryInvalid       TemplateType = "recovery_invalid"
+	TypeRecoveryValid         TemplateType = "recovery_valid"
+	TypeVerificationInvalid   TemplateType = "verification_invalid"
+	TypeVerificationValid     TemplateType = "verification_valid"
+	TypeRecoveryCodeValid     TemplateType = "recovery_code_valid"
+	TypeVerificationCodeValid TemplateType = "verification_code_valid"
+	TypeLoginCodeValid        TemplateType = "login_code_valid"
+	TypeRegistrationCodeValid TemplateType = "registration_code_valid"
+	TypeTestStub              TemplateType = "stub"
+	TypeOTP                   TemplateType = "otp"
+	TypeSMSTestStub           TemplateType = "sms_stub"
 )
 
 type EmailTemplate interface {
@@ -27,6 +33,12 @@ type EmailTemplate interface {
 	EmailRecipient() (string, error)
 }
 
+type SMSTemplate interface {
+	json.Marshaler
+	SMSBody() (string, error)
+	PhoneNumber() (string, error)
+}
+
 func GetTemplateType(t EmailTemplate) (TemplateType, error) {
 	switch t.(type) {
 	case *template.RecoveryInvalid:
@@ -37,6 +49,14 @@ func GetTemplateType(t EmailTemplate) (TemplateType, error) {
 		return TypeVerificationInvalid, nil
 	case *template.VerificationValid:
 		return TypeVerificationValid, nil
+	case *template.RecoveryCodeValid:
+		return TypeRecoveryCodeValid, nil
+	case *template.VerificationCodeValid:
+		return TypeVerificationCodeValid, nil
+	case *template.LoginCodeValid:
+		return TypeLoginCodeValid, nil
+	case *template.RegistrationCodeValid:
+		return TypeRegistrationCodeValid, nil
 	case *template.TestStub:
 		return TypeTestStub, nil
 	default:
@@ -70,6 +90,30 @@ func NewEmailTemplateFromMessage(c *config.Config, m Message) (EmailTemplate, er
 			return nil, err
 		}
 		return template.NewVerificationValid(c, &t), nil
+	case TypeRecoveryCodeValid:
+		var t template.RecoveryCodeValidModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewRecoveryCodeValid(c, &t), nil
+	case TypeVerificationCodeValid:
+		var t template.VerificationCodeValidModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewVerificationCodeValid(c, &t), nil
+	case TypeLoginCodeValid:
+		var t template.LoginCodeValidModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewLoginCodeValid(c, &t), nil
+	case TypeRegistrationCodeValid:
+		var t template.RegistrationCodeValidModel
+		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return template.NewRegistrationCodeValid(c, &t), nil
 	case TypeTestStub:
 		var t template.TestStubModel
 		if err := json.Unmarshal(m.TemplateData, &t); err != nil {
@@ -80,3 +124,22 @@ func NewEmailTemplateFromMessage(c *config.Config, m Message) (EmailTemplate, er
 		return nil, errors.Errorf("received unexpected message template type: %s", m.TemplateType)
 	}
 }
+
+func GetSMSTemplateType(t SMSTemplate) (TemplateType, error) {
+	switch t.(type) {
+	case *template.RecoveryValid:
+		return TypeRecoveryValid, nil
+	case *template.VerificationValid:
+		return TypeVerificationValid, nil
+	case *template.RecoveryCodeValid:
+		return TypeRecoveryCodeValid, nil
+	case *template.VerificationCodeValid:
+		return TypeVerificationCodeValid, nil
+	case *template.OTPMessage:
+		return TypeOTP, nil
+	case *template.SMSTestStub:
+		return TypeSMSTestStub, nil
+	default:
+		return "", errors.Errorf("unexpected template type")
+	}
+}
@@ -14,11 +14,15 @@ import (
 
 func TestGetTemplateType(t *testing.T) {
 	for expectedType, tmpl := range map[courier.TemplateType]courier.EmailTemplate{
-		courier.TypeRecoveryInvalid:     &template.RecoveryInvalid{},
-		courier.TypeRecoveryValid:       &template.RecoveryValid{},
-		courier.TypeVerificationInvalid: &template.VerificationInvalid{},
-		courier.TypeVerificationValid:   &template.VerificationValid{},
-		courier.TypeTestStub:            &template.TestStub{},
+		courier.TypeRecoveryInvalid:       &template.RecoveryInvalid{},
+		courier.TypeRecoveryValid:         &template.RecoveryValid{},
+		courier.TypeVerificationInvalid:   &template.VerificationInvalid{},
+		courier.TypeVerificationValid:     &template.VerificationValid{},
+		courier.TypeRecoveryCodeValid:     &template.RecoveryCodeValid{},
+		courier.TypeVerificationCodeValid: &template.VerificationCodeValid{},
+		courier.TypeLoginCodeValid:        &template.LoginCodeValid{},
+		courier.TypeRegistrationCodeValid: &template.RegistrationCodeValid{},
+		courier.TypeTestStub:              &template.TestStub{},
 	} {
 		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
 			actualType, err := courier.GetTemplateType(tmpl)
@@ -30,14 +34,35 @@ func TestGetTemplateType(t *testing.T) {
 	}
 }
 
+func TestGetSMSTemplateType(t *testing.T) {
+	for expectedType, tmpl := range map[courier.TemplateType]courier.SMSTemplate{
+		courier.TypeRecoveryValid:         &template.RecoveryValid{},
+		courier.TypeVerificationValid:     &template.VerificationValid{},
+		courier.TypeRecoveryCodeValid:     &template.RecoveryCodeValid{},
+		courier.TypeVerificationCodeValid: &template.VerificationCodeValid{},
+		courier.TypeOTP:                   &template.OTPMessage{},
+		courier.TypeSMSTestStub:           &template.SMSTestStub{},
+	} {
+		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
+			actualType, err := courier.GetSMSTemplateType(tmpl)
+			require.NoError(t, err)
+			require.Equal(t, expectedType, actualType)
+		})
+	}
+}
+
 func TestNewEmailTemplateFromMessage(t *testing.T) {
 	conf := internal.NewConfigurationWithDefaults(t)
 	for tmplType, expectedTmpl := range map[courier.TemplateType]courier.EmailTemplate{
-		courier.TypeRecoveryInvalid:     template.NewRecoveryInvalid(conf, &template.RecoveryInvalidModel{To: "foo"}),
-		courier.TypeRecoveryValid:       template.NewRecoveryValid(conf, &template.RecoveryValidModel{To: "bar", RecoveryURL: "http://foo.bar"}),
-		courier.TypeVerificationInvalid: template.NewVerificationInvalid(conf, &template.VerificationInvalidModel{To: "baz"}),
-		courier.TypeVerificationValid:   template.NewVerificationValid(conf, &template.VerificationValidModel{To: "faz", VerificationURL: "http://bar.foo"}),
-		courier.TypeTestStub:            template.NewTestStub(conf, &template.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
+		courier.TypeRecoveryInvalid:       template.NewRecoveryInvalid(conf, &template.RecoveryInvalidModel{To: "foo"}),
+		courier.TypeRecoveryValid:         template.NewRecoveryValid(conf, &template.RecoveryValidModel{To: "bar", RecoveryURL: "http://foo.bar"}),
+		courier.TypeVerificationInvalid:   template.NewVerificationInvalid(conf, &template.VerificationInvalidModel{To: "baz"}),
+		courier.TypeVerificationValid:     template.NewVerificationValid(conf, &template.VerificationValidModel{To: "faz", VerificationURL: "http://bar.foo"}),
+		courier.TypeRecoveryCodeValid:     template.NewRecoveryCodeValid(conf, &template.RecoveryCodeValidModel{To: "bar", RecoveryCode: "123456"}),
+		courier.TypeVerificationCodeValid: template.NewVerificationCodeValid(conf, &template.VerificationCodeValidModel{To: "faz", VerificationCode: "654321"}),
+		courier.TypeLoginCodeValid:        template.NewLoginCodeValid(conf, &template.LoginCodeValidModel{To: "far", LoginCode: "123456"}),
+		courier.TypeRegistrationCodeValid: template.NewRegistrationCodeValid(conf, &template.RegistrationCodeValidModel{To: "far", RegistrationCode: "654321"}),
+		courier.TypeTestStub:              template.NewTestStub(conf, &template.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
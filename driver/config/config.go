@@ -14,10 +14,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
 	"github.com/google/uuid"
 
 	"github.com/ory/x/dbal"
 
+	"github.com/ory/x/stringslice"
 	"github.com/ory/x/stringsx"
 
 	"github.com/stretchr/testify/require"
@@ -45,8 +48,12 @@ const (
 	ViperKeyCourierTemplatesPath                                    = "courier.template_override_path"
 	ViperKeyCourierSMTPFrom                                         = "courier.smtp.from_address"
 	ViperKeyCourierSMTPFromName                                     = "courier.smtp.from_name"
+	ViperKeyCourierSMSEnabled                                       = "courier.sms.enabled"
+	ViperKeyCourierSMSFrom                                          = "courier.sms.from"
+	ViperKeyCourierSMSRequestConfig                                 = "courier.sms.request_config"
 	ViperKeySecretsDefault                                          = "secrets.default"
 	ViperKeySecretsCookie                                           = "secrets.cookie"
+	ViperKeySecretsPepper                                           = "secrets.pepper"
 	ViperKeyPublicBaseURL                                           = "serve.public.base_url"
 	ViperKeyPublicDomainAliases                                     = "serve.public.domain_aliases"
 	ViperKeyPublicPort                                              = "serve.public.port"
@@ -66,6 +73,17 @@ const (
 	ViperKeySessionName                                             = "session.cookie.name"
 	ViperKeySessionPath                                             = "session.cookie.path"
 	ViperKeySessionPersistentCookie                                 = "session.cookie.persistent"
+	ViperKeySessionWhoAmIAAL                                        = "session.whoami.required_aal"
+	ViperKeySessionTokenizerJWKSURL                                 = "session.tokenizer.jwks_url"
+	ViperKeySessionTokenizerClaimsMapperURL                         = "session.tokenizer.claims_mapper_url"
+	ViperKeySessionTokenizerTTL                                     = "session.tokenizer.ttl"
+	ViperKeySessionTokenizerJWKSCacheTTL                            = "session.tokenizer.jwks_cache_ttl"
+	ViperKeySessionSlidingExpiryEnabled                             = "session.sliding_expiry.enabled"
+	ViperKeySessionSlidingExpiryThreshold                           = "session.sliding_expiry.threshold"
+	ViperKeySessionDeviceLocationHeader                             = "session.devices.location_header"
+	ViperKeySessionDeviceUpdateInterval                             = "session.devices.update_interval"
+	ViperKeySessionDeviceHistoryLimit                               = "session.devices.history_limit"
+	ViperKeySessionDeviceTrustedProxies                             = "session.devices.trusted_proxies"
 	ViperKeySelfServiceStrategyConfig                               = "selfservice.methods"
 	ViperKeySelfServiceBrowserDefaultReturnTo                       = "selfservice." + DefaultBrowserReturnURL
 	ViperKeyURLsWhitelistedReturnToDomains                          = "selfservice.whitelisted_return_urls"
@@ -83,14 +101,19 @@ const (
 	ViperKeySelfServiceSettingsAfter                                = "selfservice.flows.settings.after"
 	ViperKeySelfServiceSettingsRequestLifespan                      = "selfservice.flows.settings.lifespan"
 	ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter        = "selfservice.flows.settings.privileged_session_max_age"
+	ViperKeySelfServiceSettingsBeforeHooks                          = "selfservice.flows.settings.before.hooks"
 	ViperKeySelfServiceRecoveryEnabled                              = "selfservice.flows.recovery.enabled"
 	ViperKeySelfServiceRecoveryUI                                   = "selfservice.flows.recovery.ui_url"
 	ViperKeySelfServiceRecoveryRequestLifespan                      = "selfservice.flows.recovery.lifespan"
 	ViperKeySelfServiceRecoveryBrowserDefaultReturnTo               = "selfservice.flows.recovery.after." + DefaultBrowserReturnURL
+	ViperKeySelfServiceRecoveryBeforeHooks                          = "selfservice.flows.recovery.before.hooks"
+	ViperKeySelfServiceRecoveryAfterHooks                           = "selfservice.flows.recovery.after.hooks"
 	ViperKeySelfServiceVerificationEnabled                          = "selfservice.flows.verification.enabled"
 	ViperKeySelfServiceVerificationUI                               = "selfservice.flows.verification.ui_url"
 	ViperKeySelfServiceVerificationRequestLifespan                  = "selfservice.flows.verification.lifespan"
 	ViperKeySelfServiceVerificationBrowserDefaultReturnTo           = "selfservice.flows.verification.after." + DefaultBrowserReturnURL
+	ViperKeySelfServiceVerificationBeforeHooks                      = "selfservice.flows.verification.before.hooks"
+	ViperKeySelfServiceVerificationAfterHooks                       = "selfservice.flows.verification.after.hooks"
 	ViperKeyDefaultIdentitySchemaURL                                = "identity.default_schema_url"
 	ViperKeyIdentitySchemas                                         = "identity.schemas"
 	ViperKeyHasherAlgorithm                                         = "hashers.algorithm"
@@ -103,8 +126,28 @@ const (
 	ViperKeyHasherArgon2ConfigExpectedDeviation                     = "hashers.argon2.expected_deviation"
 	ViperKeyHasherArgon2ConfigDedicatedMemory                       = "hashers.argon2.dedicated_memory"
 	ViperKeyHasherBcryptCost                                        = "hashers.bcrypt.cost"
+	ViperKeyHasherMaxConcurrency                                    = "hashers.max_concurrency"
+	ViperKeyHasherQueueTimeout                                      = "hashers.queue_timeout"
 	ViperKeyPasswordMaxBreaches                                     = "selfservice.methods.password.config.max_breaches"
 	ViperKeyIgnoreNetworkErrors                                     = "selfservice.methods.password.config.ignore_network_errors"
+	ViperKeyPasswordMinLength                                       = "selfservice.methods.password.config.min_password_length"
+	ViperKeyPasswordMaxLength                                       = "selfservice.methods.password.config.max_password_length"
+	ViperKeyPasswordRequiredCharacterClasses                        = "selfservice.methods.password.config.required_character_classes"
+	ViperKeyPasswordDenylistFile                                    = "selfservice.methods.password.config.denylist_file"
+	ViperKeyPasswordMinIdentifierDistance                           = "selfservice.methods.password.config.min_identifier_distance"
+	ViperKeyPasswordMaxIdentifierSubstringRatio                     = "selfservice.methods.password.config.max_identifier_substring_ratio"
+	ViperKeyPasswordSchemaOverrides                                 = "selfservice.methods.password.config.schema_overrides"
+	ViperKeyTOTPIssuer                                              = "selfservice.methods.totp.config.issuer"
+	ViperKeyTOTPMaxAttempts                                         = "selfservice.methods.totp.config.max_attempts"
+	ViperKeyCodeLifespan                                            = "selfservice.methods.code.config.lifespan"
+	ViperKeyCodeMaxAttempts                                         = "selfservice.methods.code.config.max_attempts"
+	ViperKeyCodeMaxSent                                             = "selfservice.methods.code.config.max_sent"
+	ViperKeyCodePasswordless                                        = "selfservice.methods.code.config.passwordless"
+	ViperKeyWebAuthnRPDisplayName                                   = "selfservice.methods.webauthn.config.rp.display_name"
+	ViperKeyWebAuthnRPID                                            = "selfservice.methods.webauthn.config.rp.id"
+	ViperKeyWebAuthnRPOrigin                                        = "selfservice.methods.webauthn.config.rp.origin"
+	ViperKeyWebAuthnRPIcon                                          = "selfservice.methods.webauthn.config.rp.icon"
+	ViperKeyWebAuthnPasswordless                                    = "selfservice.methods.webauthn.config.passwordless"
 	ViperKeyVersion                                                 = "version"
 	Argon2DefaultMemory                                             = 128 * bytesize.MB
 	Argon2DefaultIterations                                  uint32 = 1
@@ -114,11 +157,21 @@ const (
 	Argon2DefaultDeviation                                          = 500 * time.Millisecond
 	Argon2DefaultDedicatedMemory                                    = 1 * bytesize.GB
 	BcryptDefaultCost                                        uint32 = 12
+	HasherDefaultQueueTimeout                                       = 5 * time.Second
+	PasswordDefaultMinLength                                        = 6
+	PasswordDefaultMinIdentifierDistance                            = 5
+	PasswordDefaultMaxIdentifierSubstringRatio                      = 0.5
 )
 
+// PasswordCharacterClasses are the character classes a password policy can require.
+var PasswordCharacterClasses = []string{"lowercase", "uppercase", "digit", "symbol"}
+
 // DefaultSessionCookieName returns the default cookie name for the kratos session.
 const DefaultSessionCookieName = "ory_kratos_session"
 
+// HighestAvailableAAL requires sessions to have completed all authentication factors the identity has set up.
+const HighestAvailableAAL = "highest_available"
+
 type (
 	Argon2 struct {
 		Memory            bytesize.ByteSize `json:"memory"`
@@ -146,8 +199,14 @@ type (
 		URL string `json:"url"`
 	}
 	PasswordPolicy struct {
-		MaxBreaches         uint `json:"max_breaches"`
-		IgnoreNetworkErrors bool `json:"ignore_network_errors"`
+		MaxBreaches                 uint     `json:"max_breaches"`
+		IgnoreNetworkErrors         bool     `json:"ignore_network_errors"`
+		MinPasswordLength           int      `json:"min_password_length"`
+		MaxPasswordLength           int      `json:"max_password_length"`
+		RequiredCharacterClasses    []string `json:"required_character_classes"`
+		DenylistFile                string   `json:"denylist_file"`
+		MinIdentifierDistance       int      `json:"min_identifier_distance"`
+		MaxIdentifierSubstringRatio float64  `json:"max_identifier_substring_ratio"`
 	}
 	Schemas []Schema
 	Config  struct {
@@ -297,6 +356,18 @@ func (p *Config) HasherBcrypt() *Bcrypt {
 	return &Bcrypt{Cost: cost}
 }
 
+// HasherMaxConcurrency returns the maximum number of concurrent hash operations or 0 if it is not limited.
+func (p *Config) HasherMaxConcurrency() int {
+	if max := p.p.Int(ViperKeyHasherMaxConcurrency); max > 0 {
+		return max
+	}
+	return 0
+}
+
+func (p *Config) HasherQueueTimeout() time.Duration {
+	return p.p.DurationF(ViperKeyHasherQueueTimeout, HasherDefaultQueueTimeout)
+}
+
 func (p *Config) listenOn(key string) string {
 	fb := 4433
 	if key == "admin" {
@@ -408,6 +479,18 @@ func (p *Config) SelfServiceFlowRegistrationBeforeHooks() []SelfServiceHook {
 	return p.selfServiceHooks(ViperKeySelfServiceRegistrationBeforeHooks)
 }
 
+func (p *Config) SelfServiceFlowSettingsBeforeHooks() []SelfServiceHook {
+	return p.selfServiceHooks(ViperKeySelfServiceSettingsBeforeHooks)
+}
+
+func (p *Config) SelfServiceFlowRecoveryBeforeHooks() []SelfServiceHook {
+	return p.selfServiceHooks(ViperKeySelfServiceRecoveryBeforeHooks)
+}
+
+func (p *Config) SelfServiceFlowVerificationBeforeHooks() []SelfServiceHook {
+	return p.selfServiceHooks(ViperKeySelfServiceVerificationBeforeHooks)
+}
+
 func (p *Config) selfServiceHooks(key string) []SelfServiceHook {
 	var hooks []SelfServiceHook
 	if !p.p.Exists(key) {
@@ -449,6 +532,14 @@ func (p *Config) SelfServiceFlowRegistrationAfterHooks(strategy string) []SelfSe
 	return p.selfServiceHooks(HookStrategyKey(ViperKeySelfServiceRegistrationAfter, strategy))
 }
 
+func (p *Config) SelfServiceFlowRecoveryAfterHooks() []SelfServiceHook {
+	return p.selfServiceHooks(ViperKeySelfServiceRecoveryAfterHooks)
+}
+
+func (p *Config) SelfServiceFlowVerificationAfterHooks() []SelfServiceHook {
+	return p.selfServiceHooks(ViperKeySelfServiceVerificationAfterHooks)
+}
+
 func (p *Config) SelfServiceStrategy(strategy string) *SelfServiceStrategy {
 	config := "{}"
 	out, err := p.p.Marshal(kjson.Parser())
@@ -515,6 +606,19 @@ func (p *Config) SecretsSession() [][]byte {
 	return result
 }
 
+// SecretsPepper returns the secrets used to pepper passwords before hashing them. Unlike other secrets,
+// peppers do not fall back to the default secrets as changing those would invalidate all password hashes.
+func (p *Config) SecretsPepper() [][]byte {
+	secrets := p.p.Strings(ViperKeySecretsPepper)
+
+	result := make([][]byte, len(secrets))
+	for k, v := range secrets {
+		result[k] = []byte(v)
+	}
+
+	return result
+}
+
 func (p *Config) SelfServiceBrowserDefaultReturnTo() *url.URL {
 	return p.ParseURIOrFail(ViperKeySelfServiceBrowserDefaultReturnTo)
 }
@@ -648,6 +752,69 @@ func (p *Config) SessionPersistentCookie() bool {
 	return p.p.Bool(ViperKeySessionPersistentCookie)
 }
 
+func (p *Config) SessionWhoAmIAAL() string {
+	return p.p.StringF(ViperKeySessionWhoAmIAAL, "aal1")
+}
+
+func (p *Config) SessionTokenizerJWKSURL() string {
+	return p.p.String(ViperKeySessionTokenizerJWKSURL)
+}
+
+func (p *Config) SessionTokenizerClaimsMapperURL() string {
+	return p.p.String(ViperKeySessionTokenizerClaimsMapperURL)
+}
+
+func (p *Config) SessionTokenizerTTL() time.Duration {
+	return p.p.DurationF(ViperKeySessionTokenizerTTL, time.Minute)
+}
+
+func (p *Config) SessionTokenizerJWKSCacheTTL() time.Duration {
+	return p.p.DurationF(ViperKeySessionTokenizerJWKSCacheTTL, time.Minute*5)
+}
+
+func (p *Config) SessionSlidingExpiryEnabled() bool {
+	return p.p.Bool(ViperKeySessionSlidingExpiryEnabled)
+}
+
+func (p *Config) SessionSlidingExpiryThreshold() time.Duration {
+	return p.p.DurationF(ViperKeySessionSlidingExpiryThreshold, time.Hour)
+}
+
+func (p *Config) SessionDeviceLocationHeader() string {
+	return p.p.String(ViperKeySessionDeviceLocationHeader)
+}
+
+func (p *Config) SessionDeviceUpdateInterval() time.Duration {
+	return p.p.DurationF(ViperKeySessionDeviceUpdateInterval, time.Minute*5)
+}
+
+func (p *Config) SessionDeviceHistoryLimit() int {
+	return p.p.IntF(ViperKeySessionDeviceHistoryLimit, 10)
+}
+
+// SessionDeviceTrustedProxies returns the networks of the proxies whose X-Forwarded-For header is used to
+// determine the IP address of a device. Single IP addresses are treated as networks of one address.
+func (p *Config) SessionDeviceTrustedProxies() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range p.p.Strings(ViperKeySessionDeviceTrustedProxies) {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			p.l.WithError(err).Warnf("Ignoring invalid trusted proxy \"%s\" in configuration key %s.", cidr, ViperKeySessionDeviceTrustedProxies)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
 func (p *Config) SelfServiceBrowserWhitelistedReturnToDomains() (us []url.URL) {
 	src := p.p.Strings(ViperKeyURLsWhitelistedReturnToDomains)
 	for k, u := range src {
@@ -691,6 +858,29 @@ func (p *Config) CourierSMTPFromName() string {
 	return p.p.StringF(ViperKeyCourierSMTPFromName, "")
 }
 
+func (p *Config) CourierSMSEnabled() bool {
+	return p.p.Bool(ViperKeyCourierSMSEnabled)
+}
+
+func (p *Config) CourierSMSFrom() string {
+	return p.p.StringF(ViperKeyCourierSMSFrom, "Ory Kratos")
+}
+
+// CourierSMSRequestConfig returns the configuration of the HTTP request which delivers text messages.
+func (p *Config) CourierSMSRequestConfig() json.RawMessage {
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Warn("Unable to marshal SMS request configuration.")
+		return json.RawMessage("{}")
+	}
+
+	config := gjson.GetBytes(out, ViperKeyCourierSMSRequestConfig).Raw
+	if len(config) == 0 {
+		return json.RawMessage("{}")
+	}
+	return json.RawMessage(config)
+}
+
 func (p *Config) CourierTemplatesRoot() string {
 	return p.p.StringF(ViperKeyCourierTemplatesPath, "courier/builtin/templates")
 }
@@ -809,8 +999,82 @@ func (p *Config) ConfigVersion() string {
 
 func (p *Config) PasswordPolicyConfig() *PasswordPolicy {
 	return &PasswordPolicy{
-		MaxBreaches:         uint(p.p.Int(ViperKeyPasswordMaxBreaches)),
-		IgnoreNetworkErrors: p.p.BoolF(ViperKeyIgnoreNetworkErrors, true),
+		MaxBreaches:                 uint(p.p.Int(ViperKeyPasswordMaxBreaches)),
+		IgnoreNetworkErrors:         p.p.BoolF(ViperKeyIgnoreNetworkErrors, true),
+		MinPasswordLength:           p.p.IntF(ViperKeyPasswordMinLength, PasswordDefaultMinLength),
+		MaxPasswordLength:           p.p.Int(ViperKeyPasswordMaxLength),
+		RequiredCharacterClasses:    p.p.Strings(ViperKeyPasswordRequiredCharacterClasses),
+		DenylistFile:                p.p.String(ViperKeyPasswordDenylistFile),
+		MinIdentifierDistance:       p.p.IntF(ViperKeyPasswordMinIdentifierDistance, PasswordDefaultMinIdentifierDistance),
+		MaxIdentifierSubstringRatio: p.p.Float64F(ViperKeyPasswordMaxIdentifierSubstringRatio, PasswordDefaultMaxIdentifierSubstringRatio),
+	}
+}
+
+// PasswordPolicyConfigForSchema returns the password policy with the overrides of the given identity schema applied.
+func (p *Config) PasswordPolicyConfigForSchema(schemaID string) (*PasswordPolicy, error) {
+	policy := p.PasswordPolicyConfig()
+
+	// The schema ID is not appended to the key as it may contain the key delimiter.
+	if overrides, ok := p.p.Get(ViperKeyPasswordSchemaOverrides).(map[string]interface{}); ok {
+		if override, ok := overrides[schemaID]; ok {
+			raw, err := json.Marshal(override)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			if err := json.Unmarshal(raw, policy); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+	}
+
+	for _, class := range policy.RequiredCharacterClasses {
+		if !stringslice.Has(PasswordCharacterClasses, class) {
+			return nil, errors.Errorf("unknown required character class %q, expected one of: %s", class, strings.Join(PasswordCharacterClasses, ", "))
+		}
+	}
+
+	return policy, nil
+}
+
+func (p *Config) TOTPIssuer() string {
+	return p.p.StringF(ViperKeyTOTPIssuer, p.SelfPublicURL(nil).Hostname())
+}
+
+func (p *Config) TOTPMaxAttempts() int {
+	return p.p.IntF(ViperKeyTOTPMaxAttempts, 5)
+}
+
+func (p *Config) SelfServiceCodeLifespan() time.Duration {
+	return p.p.DurationF(ViperKeyCodeLifespan, time.Minute*15)
+}
+
+func (p *Config) SelfServiceCodeMaxAttempts() int {
+	return p.p.IntF(ViperKeyCodeMaxAttempts, 5)
+}
+
+func (p *Config) SelfServiceCodeMaxSent() int {
+	return p.p.IntF(ViperKeyCodeMaxSent, 5)
+}
+
+func (p *Config) SelfServiceCodePasswordless() bool {
+	return p.p.BoolF(ViperKeyCodePasswordless, false)
+}
+
+func (p *Config) WebAuthnForPasswordless() bool {
+	return p.p.BoolF(ViperKeyWebAuthnPasswordless, false)
+}
+
+func (p *Config) WebAuthnConfig() *webauthn.Config {
+	publicURL := p.SelfPublicURL(nil)
+	return &webauthn.Config{
+		RPDisplayName: p.p.String(ViperKeyWebAuthnRPDisplayName),
+		RPID:          p.p.String(ViperKeyWebAuthnRPID),
+		RPOrigin:      p.p.StringF(ViperKeyWebAuthnRPOrigin, publicURL.Scheme+"://"+publicURL.Host),
+		RPIcon:        p.p.String(ViperKeyWebAuthnRPIcon),
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			UserVerification: protocol.VerificationDiscouraged,
+		},
 	}
 }
 
@@ -144,7 +144,7 @@ func TestViperProvider(t *testing.T) {
 				config  string
 				enabled bool
 			}{
-				{id: "password", enabled: true, config: `{"ignore_network_errors":true,"max_breaches":0}`},
+				{id: "password", enabled: true, config: `{"ignore_network_errors":true,"max_breaches":0,"max_identifier_substring_ratio":0.5,"max_password_length":0,"min_identifier_distance":5,"min_password_length":6}`},
 				{id: "oidc", enabled: true, config: `{"providers":[{"client_id":"a","client_secret":"b","id":"github","provider":"github","mapper_url":"http://test.kratos.ory.sh/default-identity.schema.json"}]}`},
 			} {
 				strategy := p.SelfServiceStrategy(tc.id)
@@ -261,6 +261,23 @@ func TestViperProvider(t *testing.T) {
 		t.Run("method=recovery", func(t *testing.T) {
 			assert.Equal(t, time.Minute*98, p.SelfServiceFlowRecoveryRequestLifespan())
 			assert.Equal(t, "http://test.kratos.ory.sh/recovery", p.SelfServiceFlowRecoveryUI().String())
+
+			hooks := p.SelfServiceFlowRecoveryBeforeHooks()
+			require.Len(t, hooks, 1)
+			assert.Equal(t, "web_hook", hooks[0].Name)
+			assert.JSONEq(t, `{"url":"https://test.kratos.ory.sh/before_recovery_hook"}`, string(hooks[0].Config))
+
+			hooks = p.SelfServiceFlowRecoveryAfterHooks()
+			require.Len(t, hooks, 1)
+			assert.Equal(t, "web_hook", hooks[0].Name)
+			assert.JSONEq(t, `{
+				"url": "https://test.kratos.ory.sh/after_recovery_hook",
+				"method": "PUT",
+				"body": "file:///path/to/template.jsonnet",
+				"timeout": "1s",
+				"can_interrupt": false,
+				"auth": {"type": "api_key", "config": {"name": "X-Api-Key", "value": "secret"}}
+			}`, string(hooks[0].Config))
 		})
 
 		t.Run("method=verification", func(t *testing.T) {
@@ -392,6 +409,56 @@ func TestViperProvider_Secrets(t *testing.T) {
 	assert.NotEmpty(t, def)
 	assert.Equal(t, def, p.SecretsSession())
 	assert.Equal(t, def, p.SecretsDefault())
+	assert.Empty(t, p.SecretsPepper())
+
+	p.MustSet(config.ViperKeySecretsPepper, []string{"new-pepper-secret", "old-pepper-secret"})
+	assert.Equal(t, [][]byte{[]byte("new-pepper-secret"), []byte("old-pepper-secret")}, p.SecretsPepper())
+}
+
+func TestPasswordPolicyConfigForSchema(t *testing.T) {
+	p := config.MustNew(t, logrusx.New("", ""), configx.SkipValidation())
+
+	policy, err := p.PasswordPolicyConfigForSchema("default")
+	require.NoError(t, err)
+	assert.Equal(t, config.PasswordDefaultMinLength, policy.MinPasswordLength)
+	assert.Equal(t, 0, policy.MaxPasswordLength)
+	assert.Empty(t, policy.RequiredCharacterClasses)
+	assert.Equal(t, config.PasswordDefaultMinIdentifierDistance, policy.MinIdentifierDistance)
+	assert.Equal(t, config.PasswordDefaultMaxIdentifierSubstringRatio, policy.MaxIdentifierSubstringRatio)
+
+	p.MustSet(config.ViperKeyPasswordMinLength, 10)
+	p.MustSet(config.ViperKeyPasswordRequiredCharacterClasses, []string{"digit"})
+	p.MustSet(config.ViperKeyPasswordSchemaOverrides, map[string]interface{}{
+		"employee": map[string]interface{}{
+			"min_password_length":        16,
+			"required_character_classes": []string{"digit", "symbol"},
+		},
+	})
+
+	policy, err = p.PasswordPolicyConfigForSchema("default")
+	require.NoError(t, err)
+	assert.Equal(t, 10, policy.MinPasswordLength)
+	assert.Equal(t, []string{"digit"}, policy.RequiredCharacterClasses)
+
+	policy, err = p.PasswordPolicyConfigForSchema("employee")
+	require.NoError(t, err)
+	assert.Equal(t, 16, policy.MinPasswordLength)
+	assert.Equal(t, []string{"digit", "symbol"}, policy.RequiredCharacterClasses)
+	assert.Equal(t, config.PasswordDefaultMinIdentifierDistance, policy.MinIdentifierDistance)
+
+	p.MustSet(config.ViperKeyPasswordSchemaOverrides, map[string]interface{}{
+		"employee": map[string]interface{}{
+			"required_character_classes": []string{"emoji"},
+		},
+	})
+
+	_, err = p.PasswordPolicyConfigForSchema("employee")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "emoji")
+
+	policy, err = p.PasswordPolicyConfigForSchema("default")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"digit"}, policy.RequiredCharacterClasses)
 }
 
 func TestViperProvider_Defaults(t *testing.T) {
@@ -18,6 +18,7 @@ import (
 	"kratos/selfservice/flow/recovery"
 	"kratos/selfservice/flow/settings"
 	"kratos/selfservice/flow/verification"
+	"kratos/selfservice/strategy/code"
 	"kratos/selfservice/strategy/link"
 
 	"github.com/ory/x/healthx"
@@ -93,6 +94,7 @@ type Registry interface {
 	session.HandlerProvider
 	session.ManagementProvider
 	session.PersistenceProvider
+	session.TokenizerProvider
 
 	settings.HandlerProvider
 	settings.ErrorHandlerProvider
@@ -124,6 +126,8 @@ type Registry interface {
 	link.VerificationTokenPersistenceProvider
 	link.RecoveryTokenPersistenceProvider
 
+	code.CodePersistenceProvider
+
 	recovery.FlowPersistenceProvider
 	recovery.ErrorHandlerProvider
 	recovery.HandlerProvider
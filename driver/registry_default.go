@@ -23,11 +23,14 @@ import (
 	"kratos/selfservice/flow/settings"
 	"kratos/selfservice/flow/verification"
 	"kratos/selfservice/hook"
+	"kratos/selfservice/strategy/code"
 	"kratos/selfservice/strategy/link"
+	"kratos/selfservice/strategy/lookup"
 	"kratos/selfservice/strategy/profile"
 	"kratos/x"
 
 	"github.com/cenkalti/backoff"
+	"github.com/gofrs/uuid"
 	"github.com/gorilla/sessions"
 	"github.com/pkg/errors"
 
@@ -53,6 +56,8 @@ import (
 	"kratos/identity"
 	"kratos/selfservice/errorx"
 	password2 "kratos/selfservice/strategy/password"
+	"kratos/selfservice/strategy/totp"
+	"kratos/selfservice/strategy/webauthn"
 	"kratos/session"
 )
 
@@ -84,8 +89,9 @@ type RegistryDefault struct {
 
 	schemaHandler *schema.Handler
 
-	sessionHandler *session.Handler
-	sessionManager session.Manager
+	sessionHandler   *session.Handler
+	sessionManager   session.Manager
+	sessionTokenizer *session.Tokenizer
 
 	passwordHasher    hash.Hasher
 	passwordValidator password2.Validator
@@ -109,11 +115,13 @@ type RegistryDefault struct {
 	selfserviceVerifyErrorHandler *verification.ErrorHandler
 	selfserviceVerifyManager      *identity.Manager
 	selfserviceVerifyHandler      *verification.Handler
+	selfserviceVerifyExecutor     *verification.HookExecutor
 
 	selfserviceLinkSender *link.Sender
 
 	selfserviceRecoveryErrorHandler *recovery.ErrorHandler
 	selfserviceRecoveryHandler      *recovery.Handler
+	selfserviceRecoveryExecutor     *recovery.HookExecutor
 
 	selfserviceLogoutHandler *logout.Handler
 
@@ -250,6 +258,10 @@ func (m *RegistryDefault) selfServiceStrategies() []interface{} {
 			oidc.NewStrategy(m),
 			profile.NewStrategy(m),
 			link.NewStrategy(m),
+			code.NewStrategy(m),
+			totp.NewStrategy(m),
+			webauthn.NewStrategy(m),
+			lookup.NewStrategy(m),
 		}
 	}
 
@@ -289,6 +301,23 @@ func (m *RegistryDefault) LoginStrategies(ctx context.Context) (loginStrategies
 	return
 }
 
+// HighestAvailableAAL returns the highest authenticator assurance level the identity can reach
+// using the enabled login strategies.
+func (m *RegistryDefault) HighestAvailableAAL(ctx context.Context, id uuid.UUID) (identity.AuthenticatorAssuranceLevel, error) {
+	i, err := m.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return identity.NoAuthenticatorAssuranceLevel, err
+	}
+
+	for _, s := range m.LoginStrategies(ctx) {
+		if sf, ok := s.(login.SecondFactorStrategy); ok && sf.HasSecondFactor(ctx, i) {
+			return identity.AuthenticatorAssuranceLevel2, nil
+		}
+	}
+
+	return identity.AuthenticatorAssuranceLevel1, nil
+}
+
 func (m *RegistryDefault) AllLoginStrategies() login.Strategies {
 	var loginStrategies []login.Strategy
 	for _, strategy := range m.selfServiceStrategies() {
@@ -356,6 +385,13 @@ func (m *RegistryDefault) SessionHandler() *session.Handler {
 	return m.sessionHandler
 }
 
+func (m *RegistryDefault) SessionTokenizer() *session.Tokenizer {
+	if m.sessionTokenizer == nil {
+		m.sessionTokenizer = session.NewTokenizer(m)
+	}
+	return m.sessionTokenizer
+}
+
 func (m *RegistryDefault) Hasher() hash.Hasher {
 	if m.passwordHasher == nil {
 		if m.c.HasherPasswordHashingAlgorithm() == "bcrypt" {
@@ -363,6 +399,10 @@ func (m *RegistryDefault) Hasher() hash.Hasher {
 		} else {
 			m.passwordHasher = hash.NewHasherArgon2(m)
 		}
+
+		if max := m.c.HasherMaxConcurrency(); max > 0 {
+			m.passwordHasher = hash.NewHasherLimited(m.passwordHasher, m, max)
+		}
 	}
 	return m.passwordHasher
 }
@@ -585,6 +625,10 @@ func (m *RegistryDefault) VerificationTokenPersister() link.VerificationTokenPer
 	return m.Persister()
 }
 
+func (m *RegistryDefault) CodePersister() code.CodePersister {
+	return m.Persister()
+}
+
 func (m *RegistryDefault) Persister() persistence.Persister {
 	return m.persister
 }
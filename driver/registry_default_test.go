@@ -13,11 +13,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"kratos/driver/config"
+	"kratos/hash"
 	"kratos/identity"
 	"kratos/internal"
 	"kratos/selfservice/flow/login"
+	"kratos/selfservice/flow/recovery"
 	"kratos/selfservice/flow/registration"
 	"kratos/selfservice/flow/settings"
+	"kratos/selfservice/flow/verification"
 	"kratos/selfservice/hook"
 )
 
@@ -61,6 +64,45 @@ func TestDriverDefault_Hooks(t *testing.T) {
 			assert.Equal(t, []settings.PostHookPostPersistExecutor{hook.NewVerifier(reg)}, h)
 		})
 	})
+
+	t.Run("case=web_hook", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		hooks := []map[string]interface{}{{"hook": "web_hook", "config": map[string]interface{}{"url": "https://www.ory.sh/"}}}
+		expected := hook.NewWebHook(reg, []byte(`{"url":"https://www.ory.sh/"}`))
+
+		t.Run("type=login", func(t *testing.T) {
+			conf.MustSet(config.ViperKeySelfServiceLoginBeforeHooks, hooks)
+			assert.Equal(t, []login.PreHookExecutor{expected}, reg.PreLoginHooks(ctx))
+		})
+
+		t.Run("type=settings", func(t *testing.T) {
+			conf.MustSet(config.ViperKeySelfServiceSettingsBeforeHooks, hooks)
+			assert.Equal(t, []settings.PreHookExecutor{expected}, reg.PreSettingsHooks(ctx))
+
+			conf.MustSet(config.ViperKeySelfServiceSettingsAfter+".profile.hooks", hooks)
+			assert.Equal(t, []settings.PostHookPostPersistExecutor{expected}, reg.PostSettingsPostPersistHooks(ctx, "profile"))
+		})
+
+		t.Run("type=recovery", func(t *testing.T) {
+			assert.Empty(t, reg.PreRecoveryHooks(ctx))
+			assert.Empty(t, reg.PostRecoveryHooks(ctx))
+
+			conf.MustSet(config.ViperKeySelfServiceRecoveryBeforeHooks, hooks)
+			conf.MustSet(config.ViperKeySelfServiceRecoveryAfterHooks, hooks)
+			assert.Equal(t, []recovery.PreHookExecutor{expected}, reg.PreRecoveryHooks(ctx))
+			assert.Equal(t, []recovery.PostHookExecutor{expected}, reg.PostRecoveryHooks(ctx))
+		})
+
+		t.Run("type=verification", func(t *testing.T) {
+			assert.Empty(t, reg.PreVerificationHooks(ctx))
+			assert.Empty(t, reg.PostVerificationHooks(ctx))
+
+			conf.MustSet(config.ViperKeySelfServiceVerificationBeforeHooks, hooks)
+			conf.MustSet(config.ViperKeySelfServiceVerificationAfterHooks, hooks)
+			assert.Equal(t, []verification.PreHookExecutor{expected}, reg.PreVerificationHooks(ctx))
+			assert.Equal(t, []verification.PostHookExecutor{expected}, reg.PostVerificationHooks(ctx))
+		})
+	})
 }
 
 func TestDriverDefault_Strategies(t *testing.T) {
@@ -112,6 +154,14 @@ func TestDriverDefault_Strategies(t *testing.T) {
 			{prep: func(conf *config.Config) {
 				conf.MustSet(config.ViperKeySelfServiceStrategyConfig+".link.enabled", true)
 			}, expect: []string{"link"}},
+			{prep: func(conf *config.Config) {
+				conf.MustSet(config.ViperKeySelfServiceStrategyConfig+".link.enabled", true)
+				conf.MustSet(config.ViperKeySelfServiceStrategyConfig+".code.enabled", true)
+			}, expect: []string{"link", "code"}},
+			{prep: func(conf *config.Config) {
+				conf.MustSet(config.ViperKeySelfServiceStrategyConfig+".link.enabled", false)
+				conf.MustSet(config.ViperKeySelfServiceStrategyConfig+".code.enabled", true)
+			}, expect: []string{"code"}},
 		} {
 			t.Run(fmt.Sprintf("run=%d", k), func(t *testing.T) {
 				conf, reg := internal.NewFastRegistryWithMocks(t)
@@ -196,7 +246,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	_, reg := internal.NewFastRegistryWithMocks(t)
 
 	t.Run("case=all login strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc"}
+		expects := []string{"password", "oidc", "code", "totp", "webauthn", "lookup_secret"}
 		s := reg.AllLoginStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -205,7 +255,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all registration strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc"}
+		expects := []string{"password", "oidc", "code"}
 		s := reg.AllRegistrationStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -214,7 +264,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all settings strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc", "profile"}
+		expects := []string{"password", "oidc", "profile", "totp", "webauthn", "lookup_secret"}
 		s := reg.AllSettingsStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -223,7 +273,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all recovery strategies", func(t *testing.T) {
-		expects := []string{"link"}
+		expects := []string{"link", "code"}
 		s := reg.AllRecoveryStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -231,3 +281,18 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 		}
 	})
 }
+
+func TestDefaultRegistry_Hasher(t *testing.T) {
+	t.Run("case=unlimited by default", func(t *testing.T) {
+		_, reg := internal.NewFastRegistryWithMocks(t)
+		_, ok := reg.Hasher().(*hash.Limited)
+		assert.False(t, ok)
+	})
+
+	t.Run("case=limits concurrent hash operations", func(t *testing.T) {
+		conf, reg := internal.NewRegistryDefaultWithDSN(t, "")
+		conf.MustSet(config.ViperKeyHasherMaxConcurrency, 2)
+		_, ok := reg.Hasher().(*hash.Limited)
+		assert.True(t, ok)
+	})
+}
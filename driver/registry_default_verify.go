@@ -36,6 +36,31 @@ func (m *RegistryDefault) VerificationHandler() *verification.Handler {
 	return m.selfserviceVerifyHandler
 }
 
+func (m *RegistryDefault) VerificationExecutor() *verification.HookExecutor {
+	if m.selfserviceVerifyExecutor == nil {
+		m.selfserviceVerifyExecutor = verification.NewHookExecutor(m)
+	}
+	return m.selfserviceVerifyExecutor
+}
+
+func (m *RegistryDefault) PreVerificationHooks(ctx context.Context) (b []verification.PreHookExecutor) {
+	for _, v := range m.getHooks("", m.Config(ctx).SelfServiceFlowVerificationBeforeHooks()) {
+		if hook, ok := v.(verification.PreHookExecutor); ok {
+			b = append(b, hook)
+		}
+	}
+	return
+}
+
+func (m *RegistryDefault) PostVerificationHooks(ctx context.Context) (b []verification.PostHookExecutor) {
+	for _, v := range m.getHooks("", m.Config(ctx).SelfServiceFlowVerificationAfterHooks()) {
+		if hook, ok := v.(verification.PostHookExecutor); ok {
+			b = append(b, hook)
+		}
+	}
+	return
+}
+
 func (m *RegistryDefault) LinkSender() *link.Sender {
 	if m.selfserviceLinkSender == nil {
 		m.selfserviceLinkSender = link.NewSender(m)
@@ -2,26 +2,75 @@ package hash
 
 import (
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1" // #nosec G505 required to verify legacy hashes
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	"hash"
 	"regexp"
 	"strings"
 
+	"github.com/inhies/go-bytesize"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 
 	"kratos/driver/config"
 )
 
 var ErrUnknownHashAlgorithm = errors.New("unknown hash algorithm")
 
-func Compare(ctx context.Context, password []byte, hash []byte) error {
-	if IsBcryptHash(hash) {
+// Limits for the parameters of hashes. Hashes are usually imported, so their parameters
+// must not allow a single login to exhaust the CPU or memory of the server.
+const (
+	pbkdf2MaxIterations = 10_000_000
+	maxKeyLength        = 1024
+	scryptMaxCost       = 20
+	scryptMaxBlockSize  = 32
+	scryptMaxParallel   = 16
+	scryptMaxMemory     = 1 << 30
+	bcryptMaxCost       = 16
+	argon2MaxIterations = 100
+	// argon2MaxMemory is in KiB like the memory parameter of the hash.
+	argon2MaxMemory = scryptMaxMemory / 1024
+)
+
+// Compare compares the password with the hash. Peppers are the secrets which are tried if the password
+// was peppered before it was hashed.
+func Compare(ctx context.Context, password []byte, hash []byte, peppers ...[]byte) error {
+	if IsPepperedHash(hash) {
+		fingerprint, hash, err := decodePepperedHash(hash)
+		if err != nil {
+			return err
+		}
+
+		secret, err := findPepper(peppers, fingerprint)
+		if err != nil {
+			return err
+		}
+
+		if IsPepperedHash(hash) {
+			return ErrInvalidHash
+		}
+		return Compare(ctx, pepper(secret, password), hash)
+	} else if IsBcryptHash(hash) {
 		return CompareBcrypt(ctx, password, hash)
 	} else if IsArgon2idHash(hash) {
 		return CompareArgon2id(ctx, password, hash)
+	} else if IsPbkdf2Hash(hash) {
+		return ComparePbkdf2(ctx, password, hash)
+	} else if IsScryptHash(hash) {
+		return CompareScrypt(ctx, password, hash)
+	} else if IsFirebaseScryptHash(hash) {
+		return CompareFirebaseScrypt(ctx, password, hash)
+	} else if IsSHAHash(hash) {
+		return CompareSHA(ctx, password, hash)
 	} else {
 		return ErrUnknownHashAlgorithm
 	}
@@ -60,6 +109,121 @@ func CompareArgon2id(_ context.Context, password []byte, hash []byte) error {
 	return ErrMismatchedHashAndPassword
 }
 
+// ComparePbkdf2 compares a password with a PBKDF2 hash encoded as
+// $pbkdf2-<sha1|sha256|sha512>$i=<iterations>,l=<key length>$<salt>$<hash>.
+func ComparePbkdf2(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodePbkdf2Hash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	otherHash := pbkdf2.Key(password, salt, p.iterations, p.keyLength, p.hash)
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return ErrMismatchedHashAndPassword
+}
+
+// CompareScrypt compares a password with a scrypt hash encoded as
+// $scrypt$ln=<log2 of the cost>,r=<block size>,p=<parallelism>$<salt>$<hash>.
+func CompareScrypt(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodeScryptHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	otherHash, err := scrypt.Key(password, salt, 1<<p.cost, p.blockSize, p.parallelism, len(hash))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return ErrMismatchedHashAndPassword
+}
+
+// CompareFirebaseScrypt compares a password with a hash exported from Firebase
+// Authentication encoded as
+// $firescrypt$ln=<memory cost>,r=<rounds>,p=<parallelism>$<salt>$<hash>$<salt separator>$<signer key>.
+func CompareFirebaseScrypt(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodeFirebaseScryptHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	key, err := scrypt.Key(password, append(salt, p.saltSeparator...), 1<<p.cost, p.blockSize, p.parallelism, 32)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	otherHash := make([]byte, len(p.signerKey))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(otherHash, p.signerKey)
+
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return ErrMismatchedHashAndPassword
+}
+
+// CompareSHA compares a password with a salted SHA-1, SHA-256 or SHA-512 hash
+// encoded as $<sha1|sha256|sha512>$[pf=<format>$]<salt>$<hash>. The optional
+// format describes how password and salt are concatenated using the {PASSWORD}
+// and {SALT} placeholders and defaults to {PASSWORD}{SALT}.
+func CompareSHA(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodeSHAHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	// Both placeholders are replaced in one pass so that the salt and the password are not parsed for placeholders.
+	input := strings.NewReplacer("{SALT}", string(salt), "{PASSWORD}", string(password)).Replace(string(p.format))
+
+	h := p.hash()
+	_, _ = h.Write([]byte(input))
+	if subtle.ConstantTimeCompare(hash, h.Sum(nil)) == 1 {
+		return nil
+	}
+	return ErrMismatchedHashAndPassword
+}
+
+// IsKnownHash returns true if the hash uses an algorithm which Compare supports.
+func IsKnownHash(hash []byte) bool {
+	if IsPepperedHash(hash) {
+		_, hash, err := decodePepperedHash(hash)
+		return err == nil && !IsPepperedHash(hash) && IsKnownHash(hash)
+	}
+
+	// Hashes are usually imported, so their parameters are validated as well.
+	var err error
+	switch {
+	case IsBcryptHash(hash):
+		var cost int
+		cost, err = bcrypt.Cost(hash)
+		if err == nil && cost > bcryptMaxCost {
+			err = ErrInvalidHash
+		}
+	case IsArgon2idHash(hash):
+		_, _, _, err = decodeArgon2idHash(string(hash))
+	case IsPbkdf2Hash(hash):
+		_, _, _, err = decodePbkdf2Hash(string(hash))
+	case IsScryptHash(hash):
+		_, _, _, err = decodeScryptHash(string(hash))
+	case IsFirebaseScryptHash(hash):
+		_, _, _, err = decodeFirebaseScryptHash(string(hash))
+	case IsSHAHash(hash):
+		_, _, _, err = decodeSHAHash(string(hash))
+	default:
+		return false
+	}
+	return err == nil
+}
+
 func IsBcryptHash(hash []byte) bool {
 	res, _ := regexp.Match("^\\$2[abzy]?\\$", hash)
 	return res
@@ -70,6 +234,26 @@ func IsArgon2idHash(hash []byte) bool {
 	return res
 }
 
+func IsPbkdf2Hash(hash []byte) bool {
+	res, _ := regexp.Match("^\\$pbkdf2-sha(1|256|512)\\$", hash)
+	return res
+}
+
+func IsScryptHash(hash []byte) bool {
+	res, _ := regexp.Match("^\\$scrypt\\$", hash)
+	return res
+}
+
+func IsFirebaseScryptHash(hash []byte) bool {
+	res, _ := regexp.Match("^\\$firescrypt\\$", hash)
+	return res
+}
+
+func IsSHAHash(hash []byte) bool {
+	res, _ := regexp.Match("^\\$sha(1|256|512)\\$", hash)
+	return res
+}
+
 func decodeArgon2idHash(encodedHash string) (p *config.Argon2, salt, hash []byte, err error) {
 	parts := strings.Split(encodedHash, "$")
 	if len(parts) != 6 {
@@ -103,5 +287,212 @@ func decodeArgon2idHash(encodedHash string) (p *config.Argon2, salt, hash []byte
 	}
 	p.KeyLength = uint32(len(hash))
 
+	// Argon2 requires at least 8 KiB of memory per lane.
+	if p.Iterations < 1 || p.Iterations > argon2MaxIterations || p.Parallelism < 1 ||
+		p.Memory < 8*bytesize.ByteSize(p.Parallelism) || p.Memory > argon2MaxMemory ||
+		p.KeyLength < 1 || p.KeyLength > maxKeyLength {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return p, salt, hash, nil
+}
+
+type pbkdf2Parameters struct {
+	hash       func() hash.Hash
+	iterations int
+	keyLength  int
+}
+
+func decodePbkdf2Hash(encodedHash string) (p *pbkdf2Parameters, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	p = new(pbkdf2Parameters)
+	p.hash, err = shaFunc(strings.TrimPrefix(parts[1], "pbkdf2-"))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	_, err = fmt.Sscanf(parts[2], "i=%d,l=%d", &p.iterations, &p.keyLength)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err = decodeBase64(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if p.iterations < 1 || p.iterations > pbkdf2MaxIterations ||
+		p.keyLength < 1 || p.keyLength > maxKeyLength || p.keyLength != len(hash) {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return p, salt, hash, nil
+}
+
+type scryptParameters struct {
+	cost          uint
+	blockSize     int
+	parallelism   int
+	saltSeparator []byte
+	signerKey     []byte
+}
+
+func decodeScryptParameters(encoded string) (*scryptParameters, error) {
+	p := new(scryptParameters)
+	if _, err := fmt.Sscanf(encoded, "ln=%d,r=%d,p=%d", &p.cost, &p.blockSize, &p.parallelism); err != nil {
+		return nil, err
+	}
+
+	if p.cost < 1 || p.cost > scryptMaxCost ||
+		p.blockSize < 1 || p.blockSize > scryptMaxBlockSize ||
+		p.parallelism < 1 || p.parallelism > scryptMaxParallel {
+		return nil, ErrInvalidHash
+	}
+
+	// scrypt allocates 128 * N * r bytes.
+	if 128*(1<<p.cost)*p.blockSize > scryptMaxMemory {
+		return nil, ErrInvalidHash
+	}
+
+	return p, nil
+}
+
+func decodeScryptHash(encodedHash string) (p *scryptParameters, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	p, err = decodeScryptParameters(parts[2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err = decodeBase64(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(hash) == 0 || len(hash) > maxKeyLength {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return p, salt, hash, nil
+}
+
+func decodeFirebaseScryptHash(encodedHash string) (p *scryptParameters, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 7 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	p, err = decodeScryptParameters(parts[2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err = decodeBase64(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	p.saltSeparator, err = decodeBase64(parts[5])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	p.signerKey, err = decodeBase64(parts[6])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The hash is the signer key encrypted with the derived key.
+	if len(hash) == 0 || len(hash) > maxKeyLength || len(hash) != len(p.signerKey) {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
 	return p, salt, hash, nil
 }
+
+type shaParameters struct {
+	hash   func() hash.Hash
+	format []byte
+}
+
+func decodeSHAHash(encodedHash string) (p *shaParameters, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 4 && len(parts) != 5 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	p = &shaParameters{format: []byte("{PASSWORD}{SALT}")}
+	p.hash, err = shaFunc(parts[1])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(parts) == 5 {
+		if !strings.HasPrefix(parts[2], "pf=") {
+			return nil, nil, nil, ErrInvalidHash
+		}
+
+		p.format, err = decodeBase64(strings.TrimPrefix(parts[2], "pf="))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		parts = append(parts[:2], parts[3:]...)
+	}
+
+	salt, err = decodeBase64(parts[2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(hash) != p.hash().Size() {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return p, salt, hash, nil
+}
+
+func shaFunc(name string) (func() hash.Hash, error) {
+	switch name {
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	}
+	return nil, ErrInvalidHash
+}
+
+// decodeBase64 decodes standard base64 with or without padding as legacy
+// systems are not consistent in this regard.
+func decodeBase64(encoded string) ([]byte, error) {
+	return base64.RawStdEncoding.Strict().DecodeString(strings.TrimRight(encoded, "="))
+}
@@ -6,6 +6,13 @@ import "context"
 type Hasher interface {
 	// Generate returns a hash derived from the password or an error if the hash method failed.
 	Generate(ctx context.Context, password []byte) ([]byte, error)
+
+	// Compare returns nil if the password matches the hash or an error otherwise.
+	Compare(ctx context.Context, password []byte, hash []byte) error
+
+	// NeedsRehash returns true if the hash was not generated by this hasher or with different parameters
+	// than the ones currently configured.
+	NeedsRehash(ctx context.Context, hash []byte) bool
 }
 
 type HashProvider interface {
@@ -45,6 +45,8 @@ func (h *Argon2) Generate(ctx context.Context, password []byte) ([]byte, error)
 		return nil, err
 	}
 
+	password, encode := pepperPassword(h.c.Config(ctx).SecretsPepper(), password)
+
 	// Pass the plaintext password, salt and parameters to the argon2.IDKey
 	// function. This will generate a hash of the password using the Argon2id
 	// variant.
@@ -61,5 +63,29 @@ func (h *Argon2) Generate(ctx context.Context, password []byte) ([]byte, error)
 		return nil, errors.WithStack(err)
 	}
 
-	return b.Bytes(), nil
+	return encode(b.Bytes()), nil
+}
+
+func (h *Argon2) Compare(ctx context.Context, password []byte, hash []byte) error {
+	return Compare(ctx, password, hash, h.c.Config(ctx).SecretsPepper()...)
+}
+
+func (h *Argon2) NeedsRehash(ctx context.Context, hash []byte) bool {
+	hash, ok := unpepperCurrent(h.c.Config(ctx).SecretsPepper(), hash)
+	if !ok || !IsArgon2idHash(hash) {
+		return true
+	}
+
+	current, salt, key, err := decodeArgon2idHash(string(hash))
+	if err != nil {
+		return true
+	}
+
+	// The memory of encoded hashes is stored in KiB and decoded as is.
+	p := h.c.Config(ctx).HasherArgon2()
+	return uint32(current.Memory) != toKB(p.Memory) ||
+		current.Iterations != p.Iterations ||
+		current.Parallelism != p.Parallelism ||
+		uint32(len(salt)) != p.SaltLength ||
+		uint32(len(key)) != p.KeyLength
 }
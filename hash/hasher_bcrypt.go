@@ -27,12 +27,31 @@ func (h *Bcrypt) Generate(ctx context.Context, password []byte) ([]byte, error)
 		return nil, err
 	}
 
+	password, encode := pepperPassword(h.c.Config(ctx).SecretsPepper(), password)
 	hash, err := bcrypt.GenerateFromPassword(password, int(h.c.Config(ctx).HasherBcrypt().Cost))
 	if err != nil {
 		return nil, err
 	}
 
-	return hash, nil
+	return encode(hash), nil
+}
+
+func (h *Bcrypt) Compare(ctx context.Context, password []byte, hash []byte) error {
+	return Compare(ctx, password, hash, h.c.Config(ctx).SecretsPepper()...)
+}
+
+func (h *Bcrypt) NeedsRehash(ctx context.Context, hash []byte) bool {
+	hash, ok := unpepperCurrent(h.c.Config(ctx).SecretsPepper(), hash)
+	if !ok || !IsBcryptHash(hash) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return true
+	}
+
+	return uint32(cost) != h.c.Config(ctx).HasherBcrypt().Cost
 }
 
 func validateBcryptPasswordLength(password []byte) error {
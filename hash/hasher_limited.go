@@ -0,0 +1,72 @@
+package hash
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/ory/herodot"
+
+	"kratos/driver/config"
+)
+
+var ErrTooManyHashOperations = herodot.DefaultError{
+	CodeField:   http.StatusServiceUnavailable,
+	StatusField: http.StatusText(http.StatusServiceUnavailable),
+	ErrorField:  "The server is currently processing too many password hashes. Please try again in a few seconds.",
+}
+
+// Limited bounds the number of concurrent hash operations of the wrapped hasher. Operations which
+// can not be started within the configured queue timeout fail with ErrTooManyHashOperations instead of
+// exhausting the memory of the server.
+type Limited struct {
+	h   Hasher
+	c   LimitedConfiguration
+	sem *semaphore.Weighted
+}
+
+type LimitedConfiguration interface {
+	config.Provider
+}
+
+// NewHasherLimited returns a hasher which runs at most maxConcurrency hash operations at once.
+func NewHasherLimited(h Hasher, c LimitedConfiguration, maxConcurrency int) *Limited {
+	return &Limited{h: h, c: c, sem: semaphore.NewWeighted(int64(maxConcurrency))}
+}
+
+func (h *Limited) Generate(ctx context.Context, password []byte) ([]byte, error) {
+	if err := h.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer h.sem.Release(1)
+
+	return h.h.Generate(ctx, password)
+}
+
+func (h *Limited) Compare(ctx context.Context, password []byte, hash []byte) error {
+	if err := h.acquire(ctx); err != nil {
+		return err
+	}
+	defer h.sem.Release(1)
+
+	return h.h.Compare(ctx, password, hash)
+}
+
+func (h *Limited) NeedsRehash(ctx context.Context, hash []byte) bool {
+	return h.h.NeedsRehash(ctx, hash)
+}
+
+func (h *Limited) acquire(ctx context.Context) error {
+	queueCtx, cancel := context.WithTimeout(ctx, h.c.Config(ctx).HasherQueueTimeout())
+	defer cancel()
+
+	if err := h.sem.Acquire(queueCtx, 1); err != nil {
+		if ctx.Err() != nil {
+			return errors.WithStack(ctx.Err())
+		}
+		return errors.WithStack(ErrTooManyHashOperations)
+	}
+	return nil
+}
@@ -3,12 +3,16 @@ package hash_test
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 
+	"kratos/driver/config"
 	"kratos/hash"
 	"kratos/internal"
 )
@@ -148,4 +152,208 @@ func TestCompare(t *testing.T) {
 	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$argon2id$v=19$m=32,t=5,p=4$cm94YnRVOW5jZzFzcVE4bQ$fBxypOL0nP/zdPE71JtAV71i487LbX3fJI5PoTN6Lp4")))
 	assert.Nil(t, hash.CompareArgon2id(context.Background(), []byte("test"), []byte("$argon2id$v=19$m=32,t=5,p=4$cm94YnRVOW5jZzFzcVE4bQ$fBxypOL0nP/zdPE71JtAV71i487LbX3fJI5PoTN6Lp4")))
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$argon2id$v=19$m=32,t=5,p=4$cm94YnRVOW5jZzFzcVE4bQ$fBxypOL0nP/zdPE71JtAV71i487LbX3fJI5PoTN6Lp5")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha1$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$LIjRgQTRdIdqGN+o1pJuroslHwyhrx72MMfrFegMsp0")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc")))
+	assert.Nil(t, hash.ComparePbkdf2(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha512$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$ipxgq5mFwrbKkLyTtiv167i84I+DhbuPcapbfKGCkfE")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdd")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=1000,l=16$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-md5$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=10,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E")))
+	assert.Nil(t, hash.CompareScrypt(context.Background(), []byte("test"), []byte("$scrypt$ln=10,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=10,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/F")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=11,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("user1password"), []byte("$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==$jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==")))
+	assert.Nil(t, hash.CompareFirebaseScrypt(context.Background(), []byte("user1password"), []byte("$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==$jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("user2password"), []byte("$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==$jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha1$bGVnYWN5c2FsdDEyMzQ1Ng$midTF/pxzDG/mi0PDf9Wo/mxi6U")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha256$bGVnYWN5c2FsdDEyMzQ1Ng$HB2kgXB0FHwO7Xwwm4gyQJZpchFccE/HBtLv8GP8f3g")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha512$bGVnYWN5c2FsdDEyMzQ1Ng$1kiT5TYCK5OK/V+aAq7PdDheiKNha5Nlpi4ri6VOqCZnzy6FgBPw7ZbxOrdYta2OIZqP7wH3nNc9Wyib3cs8ZA")))
+	assert.Nil(t, hash.CompareSHA(context.Background(), []byte("test"), []byte("$sha512$pf=e1NBTFR9e1BBU1NXT1JEfQ$bGVnYWN5c2FsdDEyMzQ1Ng$hFkuaktYYJ4yBYl9yCADUYvR0naMa+2/jUxup3+IlWtEeXji9/QuHiOwj0i12j+riRW333mERMpSQ5uU++z1zQ")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$sha512$bGVnYWN5c2FsdDEyMzQ1Ng$hFkuaktYYJ4yBYl9yCADUYvR0naMa+2/jUxup3+IlWtEeXji9/QuHiOwj0i12j+riRW333mERMpSQ5uU++z1zQ")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("tesT"), []byte("$sha256$bGVnYWN5c2FsdDEyMzQ1Ng$HB2kgXB0FHwO7Xwwm4gyQJZpchFccE/HBtLv8GP8f3g")))
+	// The salt contains the {PASSWORD} placeholder which must not be replaced.
+	assert.Nil(t, hash.CompareSHA(context.Background(), []byte("test"), []byte("$sha256$eHtQQVNTV09SRH15$RPPG/FCfv2N7TlmmndfSDq8PVQfBfqf6o60a8aScRAE")))
+
+	for _, h := range []string{
+		"$pbkdf2-sha256$i=1,l=0$c2FsdA$",
+		"$scrypt$ln=31,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E",
+		"$firescrypt$ln=14,r=8,p=1$c2FsdA$$Bw$",
+		"$sha256$c2FsdA$",
+		"$argon2id$v=19$m=16777216,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$argon2id$v=19$m=32,t=100000,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+	} {
+		assert.ErrorIs(t, hash.Compare(context.Background(), []byte("anything"), []byte(h)), hash.ErrInvalidHash, h)
+	}
+
+	assert.Equal(t, hash.ErrUnknownHashAlgorithm, hash.Compare(context.Background(), []byte("test"), []byte("$md5$bGVnYWN5c2FsdDEyMzQ1Ng$HB2kgXB0FHwO7Xwwm4gyQJZpchFccE/HBtLv8GP8f3g")))
+}
+
+func TestIsKnownHash(t *testing.T) {
+	for _, h := range []string{
+		"$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6",
+		"$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$pbkdf2-sha256$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc",
+		"$scrypt$ln=10,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E",
+		"$firescrypt$ln=14,r=8,p=1$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==$Bw==$jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==",
+		"$sha512$bGVnYWN5c2FsdDEyMzQ1Ng$1kiT5TYCK5OK/V+aAq7PdDheiKNha5Nlpi4ri6VOqCZnzy6FgBPw7ZbxOrdYta2OIZqP7wH3nNc9Wyib3cs8ZA",
+	} {
+		assert.True(t, hash.IsKnownHash([]byte(h)), h)
+	}
+
+	for _, h := range []string{
+		"",
+		"test",
+		"$md5$bGVnYWN5c2FsdDEyMzQ1Ng$HB2kgXB0FHwO7Xwwm4gyQJZpchFccE/HBtLv8GP8f3g",
+		"$pbkdf2-md5$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc",
+		"$pbkdf2-sha256$i=1,l=0$c2FsdA$",
+		"$pbkdf2-sha256$i=100000000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc",
+		"$scrypt$ln=10,r=8,p=1$c2FsdA$",
+		"$scrypt$ln=31,r=8,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E",
+		"$scrypt$ln=10,r=1024,p=1$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E",
+		"$scrypt$ln=10,r=8,p=0$bGVnYWN5c2FsdDEyMzQ1Ng$HCwq1Ymvy3zTr15dE3vIUJLXDWBww6lLF/+1aKo6n/E",
+		"$firescrypt$ln=14,r=8,p=1$c2FsdA$$Bw$",
+		"$sha256$c2FsdA$",
+		"$2a$31$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6",
+		"$2a$12$abc",
+		"$argon2id$v=19$m=16777216,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$argon2id$v=19$m=32,t=100000,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$argon2id$v=19$m=32,t=2,p=0$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$",
+	} {
+		assert.False(t, hash.IsKnownHash([]byte(h)), h)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ctx := context.Background()
+
+	t.Run("hasher=bcrypt", func(t *testing.T) {
+		h := hash.NewHasherBcrypt(reg)
+		current, err := h.Generate(ctx, []byte("test"))
+		require.NoError(t, err)
+		assert.False(t, h.NeedsRehash(ctx, current))
+
+		outdated, err := bcrypt.GenerateFromPassword([]byte("test"), int(conf.HasherBcrypt().Cost)+1)
+		require.NoError(t, err)
+		assert.True(t, h.NeedsRehash(ctx, outdated))
+
+		assert.True(t, h.NeedsRehash(ctx, []byte("$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw")))
+		assert.True(t, h.NeedsRehash(ctx, []byte("$pbkdf2-sha256$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc")))
+	})
+
+	t.Run("hasher=argon2id", func(t *testing.T) {
+		h := hash.NewHasherArgon2(reg)
+		current, err := h.Generate(ctx, []byte("test"))
+		require.NoError(t, err)
+		assert.False(t, h.NeedsRehash(ctx, current))
+
+		conf.MustSet(config.ViperKeyHasherArgon2ConfigIterations, conf.HasherArgon2().Iterations+1)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyHasherArgon2ConfigIterations, conf.HasherArgon2().Iterations-1)
+		})
+		assert.True(t, h.NeedsRehash(ctx, current))
+
+		assert.True(t, h.NeedsRehash(ctx, []byte("$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6")))
+		assert.True(t, h.NeedsRehash(ctx, []byte("$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ")))
+	})
+}
+
+func TestPepper(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		conf.MustSet(config.ViperKeySecretsPepper, []string{})
+	})
+
+	for _, h := range []hash.Hasher{
+		hash.NewHasherBcrypt(reg),
+		hash.NewHasherArgon2(reg),
+	} {
+		t.Run(fmt.Sprintf("hasher=%T", h), func(t *testing.T) {
+			conf.MustSet(config.ViperKeySecretsPepper, []string{})
+			unpeppered, err := h.Generate(ctx, []byte("test"))
+			require.NoError(t, err)
+			assert.False(t, hash.IsPepperedHash(unpeppered))
+
+			conf.MustSet(config.ViperKeySecretsPepper, []string{"old-pepper-secret"})
+			assert.True(t, h.NeedsRehash(ctx, unpeppered))
+
+			old, err := h.Generate(ctx, []byte("test"))
+			require.NoError(t, err)
+			assert.True(t, hash.IsPepperedHash(old))
+			assert.True(t, hash.IsKnownHash(old))
+			assert.False(t, h.NeedsRehash(ctx, old))
+
+			require.NoError(t, hash.Compare(ctx, []byte("test"), old, []byte("old-pepper-secret")))
+			assert.Error(t, hash.Compare(ctx, []byte("tesT"), old, []byte("old-pepper-secret")))
+			assert.ErrorIs(t, hash.Compare(ctx, []byte("test"), old), hash.ErrUnknownPepper)
+			assert.ErrorIs(t, hash.Compare(ctx, []byte("test"), old, []byte("new-pepper-secret")), hash.ErrUnknownPepper)
+
+			conf.MustSet(config.ViperKeySecretsPepper, []string{"new-pepper-secret", "old-pepper-secret"})
+			assert.True(t, h.NeedsRehash(ctx, old))
+
+			current, err := h.Generate(ctx, []byte("test"))
+			require.NoError(t, err)
+			assert.False(t, h.NeedsRehash(ctx, current))
+
+			peppers := conf.SecretsPepper()
+			require.NoError(t, hash.Compare(ctx, []byte("test"), old, peppers...))
+			require.NoError(t, hash.Compare(ctx, []byte("test"), current, peppers...))
+			require.NoError(t, hash.Compare(ctx, []byte("test"), unpeppered, peppers...))
+
+			conf.MustSet(config.ViperKeySecretsPepper, []string{})
+			assert.True(t, h.NeedsRehash(ctx, current))
+		})
+	}
+}
+
+type blockingHasher struct {
+	hash.Hasher
+	release chan struct{}
+}
+
+func (h *blockingHasher) Generate(ctx context.Context, password []byte) ([]byte, error) {
+	<-h.release
+	return h.Hasher.Generate(ctx, password)
+}
+
+func TestLimitedHasher(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyHasherQueueTimeout, "50ms")
+	ctx := context.Background()
+
+	blocking := &blockingHasher{Hasher: hash.NewHasherBcrypt(reg), release: make(chan struct{})}
+	h := hash.NewHasherLimited(blocking, reg, 1)
+
+	done := make(chan error)
+	go func() {
+		_, err := h.Generate(ctx, []byte("test"))
+		done <- err
+	}()
+
+	// Wait until the first operation occupies the only slot.
+	require.Eventually(t, func() bool {
+		_, err := h.Generate(ctx, []byte("test"))
+		return errors.Is(err, hash.ErrTooManyHashOperations)
+	}, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, h.Compare(ctx, []byte("test"), []byte("$2a$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6")), hash.ErrTooManyHashOperations)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := h.Generate(canceled, []byte("test"))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(blocking.release)
+	require.NoError(t, <-done)
+
+	hs, err := h.Generate(ctx, []byte("test"))
+	require.NoError(t, err)
+	assert.NoError(t, h.Compare(ctx, []byte("test"), hs))
+	assert.False(t, h.NeedsRehash(ctx, hs))
 }
@@ -0,0 +1,87 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnknownPepper = errors.New("the hash was peppered with an unknown secret")
+
+	pepperedHashPattern = regexp.MustCompile("^\\$pepper\\$k=([0-9a-f]+)(\\$.+)$")
+)
+
+// IsPepperedHash returns true if the password was peppered using an HMAC secret before it was hashed.
+//
+// Peppered hashes are encoded as $pepper$k=<fingerprint of the secret><hash of the peppered password>.
+func IsPepperedHash(hash []byte) bool {
+	return pepperedHashPattern.Match(hash)
+}
+
+// pepper returns the HMAC-SHA256 of the password keyed with the secret. The result is base64
+// encoded and thus short enough for BCrypt.
+func pepper(secret, password []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(password)
+	return []byte(base64.RawStdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+// pepperFingerprint identifies a secret without revealing it so that secrets can be rotated.
+func pepperFingerprint(secret []byte) string {
+	sum := sha256.Sum256(secret)
+	return hex.EncodeToString(sum[:4])
+}
+
+func encodePepperedHash(secret, hash []byte) []byte {
+	return append([]byte("$pepper$k="+pepperFingerprint(secret)), hash...)
+}
+
+// decodePepperedHash returns the fingerprint of the secret and the hash of the peppered password.
+func decodePepperedHash(hash []byte) (string, []byte, error) {
+	matches := pepperedHashPattern.FindSubmatch(hash)
+	if len(matches) != 3 {
+		return "", nil, ErrInvalidHash
+	}
+	return string(matches[1]), matches[2], nil
+}
+
+// findPepper returns the secret matching the fingerprint.
+func findPepper(peppers [][]byte, fingerprint string) ([]byte, error) {
+	for _, secret := range peppers {
+		if pepperFingerprint(secret) == fingerprint {
+			return secret, nil
+		}
+	}
+	return nil, ErrUnknownPepper
+}
+
+// pepperPassword applies the current (first) secret to the password, if any.
+func pepperPassword(peppers [][]byte, password []byte) ([]byte, func(hash []byte) []byte) {
+	if len(peppers) == 0 {
+		return password, func(hash []byte) []byte { return hash }
+	}
+
+	return pepper(peppers[0], password), func(hash []byte) []byte {
+		return encodePepperedHash(peppers[0], hash)
+	}
+}
+
+// unpepperCurrent returns the hash of the password if it was peppered as currently configured.
+func unpepperCurrent(peppers [][]byte, hash []byte) ([]byte, bool) {
+	if !IsPepperedHash(hash) {
+		return hash, len(peppers) == 0
+	} else if len(peppers) == 0 {
+		return nil, false
+	}
+
+	fingerprint, hash, err := decodePepperedHash(hash)
+	if err != nil || fingerprint != pepperFingerprint(peppers[0]) {
+		return nil, false
+	}
+	return hash, true
+}
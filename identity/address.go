@@ -1,3 +1,16 @@
 package identity
 
-const AddressTypeEmail = "email"
+import "regexp"
+
+const (
+	AddressTypeEmail = "email"
+	AddressTypePhone = "phone"
+)
+
+// phoneNumberPattern matches phone numbers in the E.164 format, e.g. +12065550100.
+var phoneNumberPattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// IsPhoneNumber returns true if the value is a phone number in the E.164 format.
+func IsPhoneNumber(value string) bool {
+	return phoneNumberPattern.MatchString(value)
+}
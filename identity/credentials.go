@@ -24,8 +24,45 @@ const (
 	// make sure to add all of these values to the test that ensures they are created during migration
 	CredentialsTypePassword CredentialsType = "password"
 	CredentialsTypeOIDC     CredentialsType = "oidc"
+	CredentialsTypeTOTP     CredentialsType = "totp"
+	CredentialsTypeWebAuthn CredentialsType = "webauthn"
+	CredentialsTypeLookup   CredentialsType = "lookup_secret"
+	CredentialsTypeCodeAuth CredentialsType = "code"
 )
 
+// AuthenticatorAssuranceLevel represents the Authenticator Assurance Level (AAL) as defined by NIST SP 800-63B.
+//
+// - aal0: The session was not authenticated (for example because it was issued by an administrator).
+// - aal1: The identity completed a single authentication factor (for example a password).
+// - aal2: The identity completed a second authentication factor (for example a TOTP code or a security key).
+//
+// swagger:model authenticatorAssuranceLevel
+type AuthenticatorAssuranceLevel string
+
+const (
+	NoAuthenticatorAssuranceLevel AuthenticatorAssuranceLevel = "aal0"
+	AuthenticatorAssuranceLevel1  AuthenticatorAssuranceLevel = "aal1"
+	AuthenticatorAssuranceLevel2  AuthenticatorAssuranceLevel = "aal2"
+)
+
+func (a AuthenticatorAssuranceLevel) String() string {
+	return string(a)
+}
+
+// Rank orders the assurance levels so that they can be compared. Unknown levels, for example those of
+// sessions stored before levels were recorded, rank below all known levels.
+func (a AuthenticatorAssuranceLevel) Rank() int {
+	switch a {
+	case NoAuthenticatorAssuranceLevel:
+		return 0
+	case AuthenticatorAssuranceLevel1:
+		return 1
+	case AuthenticatorAssuranceLevel2:
+		return 2
+	}
+	return -1
+}
+
 // Credentials represents a specific credential type
 //
 // swagger:model identityCredentials
@@ -61,6 +98,8 @@ type (
 		Identifier string    `db:"identifier"`
 		// IdentityCredentialsID is a helper struct field for gobuffalo.pop.
 		IdentityCredentialsID uuid.UUID `json:"-" db:"identity_credential_id"`
+		// IdentityCredentialsTypeID is a helper struct field for gobuffalo.pop.
+		IdentityCredentialsTypeID uuid.UUID `json:"-" db:"identity_credential_type_id"`
 		// CreatedAt is a helper struct field for gobuffalo.pop.
 		CreatedAt time.Time `json:"-" db:"created_at"`
 		// UpdatedAt is a helper struct field for gobuffalo.pop.
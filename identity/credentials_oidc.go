@@ -0,0 +1,44 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"kratos/x"
+)
+
+// CredentialsOIDC is the configuration of credentials of the type oidc.
+type CredentialsOIDC struct {
+	Providers []CredentialsOIDCProvider `json:"providers"`
+}
+
+// CredentialsOIDCProvider links the identity to a subject of an OpenID Connect provider.
+type CredentialsOIDCProvider struct {
+	Subject  string `json:"subject"`
+	Provider string `json:"provider"`
+}
+
+// NewCredentialsOIDC returns credentials of the type oidc linking the identity to the provider's subject.
+func NewCredentialsOIDC(provider, subject string) (*Credentials, error) {
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(CredentialsOIDC{
+		Providers: []CredentialsOIDCProvider{{Subject: subject, Provider: provider}},
+	}); err != nil {
+		return nil, errors.WithStack(x.PseudoPanic.
+			WithDebugf("Unable to encode OpenID Connect options to JSON: %s", err))
+	}
+
+	return &Credentials{
+		Type:        CredentialsTypeOIDC,
+		Identifiers: []string{OIDCUniqueID(provider, subject)},
+		Config:      b.Bytes(),
+	}, nil
+}
+
+// OIDCUniqueID returns the credentials identifier of the provider's subject.
+func OIDCUniqueID(provider, subject string) string {
+	return fmt.Sprintf("%s:%s", provider, subject)
+}
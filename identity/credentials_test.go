@@ -19,3 +19,9 @@ func TestCredentialsEqual(t *testing.T) {
 	derived["foo"].Identifiers[0] = "baz"
 	assert.NotEqual(t, original, derived)
 }
+
+func TestAuthenticatorAssuranceLevelRank(t *testing.T) {
+	assert.Less(t, AuthenticatorAssuranceLevel("").Rank(), NoAuthenticatorAssuranceLevel.Rank())
+	assert.Less(t, NoAuthenticatorAssuranceLevel.Rank(), AuthenticatorAssuranceLevel1.Rank())
+	assert.Less(t, AuthenticatorAssuranceLevel1.Rank(), AuthenticatorAssuranceLevel2.Rank())
+}
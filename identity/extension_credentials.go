@@ -14,31 +14,44 @@ import (
 
 type SchemaExtensionCredentials struct {
 	i *Identity
-	v []string
+	v map[CredentialsType][]string
 	l sync.Mutex
 }
 
 func NewSchemaExtensionCredentials(i *Identity) *SchemaExtensionCredentials {
-	return &SchemaExtensionCredentials{i: i}
+	return &SchemaExtensionCredentials{i: i, v: map[CredentialsType][]string{}}
+}
+
+func (r *SchemaExtensionCredentials) setIdentifier(ct CredentialsType, value interface{}) {
+	cred, ok := r.i.GetCredentials(ct)
+	if !ok {
+		cred = &Credentials{
+			Type:        ct,
+			Identifiers: []string{},
+			Config:      sqlxx.JSONRawMessage{},
+		}
+	}
+
+	r.v[ct] = stringslice.Unique(append(r.v[ct], strings.ToLower(fmt.Sprintf("%s", value))))
+	cred.Identifiers = r.v[ct]
+	r.i.SetCredentials(ct, *cred)
 }
 
 func (r *SchemaExtensionCredentials) Run(_ jsonschema.ValidationContext, s schema.ExtensionConfig, value interface{}) error {
 	r.l.Lock()
 	defer r.l.Unlock()
 	if s.Credentials.Password.Identifier {
-		cred, ok := r.i.GetCredentials(CredentialsTypePassword)
-		if !ok {
-			cred = &Credentials{
-				Type:        CredentialsTypePassword,
-				Identifiers: []string{},
-				Config:      sqlxx.JSONRawMessage{},
-			}
-		}
+		r.setIdentifier(CredentialsTypePassword, value)
+	}
 
-		r.v = stringslice.Unique(append(r.v, strings.ToLower(fmt.Sprintf("%s", value))))
-		cred.Identifiers = r.v
-		r.i.SetCredentials(CredentialsTypePassword, *cred)
+	if s.Credentials.WebAuthn.Identifier {
+		r.setIdentifier(CredentialsTypeWebAuthn, value)
 	}
+
+	if s.Credentials.Code.Identifier {
+		r.setIdentifier(CredentialsTypeCodeAuth, value)
+	}
+
 	return nil
 }
 
@@ -22,6 +22,7 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 		doc       string
 		expect    []string
 		existing  *identity.Credentials
+		ct        identity.CredentialsType
 	}{
 		{
 			doc:    `{"email":"foo@ory.sh"}`,
@@ -41,8 +42,29 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 				Identifiers: []string{"not-foo@ory.sh"},
 			},
 		},
+		{
+			doc:    `{"email":"FOO@ory.sh", "username": "foobar"}`,
+			schema: "file://./stub/extension/credentials/webauthn.schema.json",
+			expect: []string{"foo@ory.sh", "foobar"},
+		},
+		{
+			doc:    `{"email":"FOO@ory.sh", "username": "foobar"}`,
+			schema: "file://./stub/extension/credentials/webauthn.schema.json",
+			expect: []string{"foo@ory.sh"},
+			ct:     identity.CredentialsTypeWebAuthn,
+		},
+		{
+			doc:    `{"email":"FOO@ory.sh", "username": "foobar"}`,
+			schema: "file://./stub/extension/credentials/code.schema.json",
+			expect: []string{"foo@ory.sh"},
+			ct:     identity.CredentialsTypeCodeAuth,
+		},
 	} {
 		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			if tc.ct == "" {
+				tc.ct = identity.CredentialsTypePassword
+			}
+
 			c := jsonschema.NewCompiler()
 			runner, err := schema.NewExtensionRunner(schema.ExtensionRunnerIdentityMetaSchema)
 			require.NoError(t, err)
@@ -50,7 +72,7 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 			i := new(identity.Identity)
 			e := identity.NewSchemaExtensionCredentials(i)
 			if tc.existing != nil {
-				i.SetCredentials(identity.CredentialsTypePassword, *tc.existing)
+				i.SetCredentials(tc.ct, *tc.existing)
 			}
 
 			runner.AddRunner(e).Register(c)
@@ -60,7 +82,7 @@ func TestSchemaExtensionCredentials(t *testing.T) {
 			}
 			require.NoError(t, e.Finish())
 
-			credentials, ok := i.GetCredentials(identity.CredentialsTypePassword)
+			credentials, ok := i.GetCredentials(tc.ct)
 			require.True(t, ok)
 			assert.ElementsMatch(t, tc.expect, credentials.Identifiers)
 		})
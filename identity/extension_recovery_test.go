@@ -116,6 +116,22 @@ func TestSchemaExtensionRecovery(t *testing.T) {
 			schema:    "file://./stub/extension/recovery/schema.json",
 			expectErr: errors.New("I[#/username] S[#/properties/username/format] \"foobar\" is not valid \"email\""),
 		},
+		{
+			doc:    `{"phone":"+12065550100"}`,
+			schema: "file://./stub/extension/recovery/schema.json",
+			expect: []RecoveryAddress{
+				{
+					Value:      "+12065550100",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+			},
+		},
+		{
+			doc:       `{"phone":"+1 206 555 0100"}`,
+			schema:    "file://./stub/extension/recovery/schema.json",
+			expectErr: errors.New("I[#/phone] S[#/properties/phone/format] \"+1 206 555 0100\" is not a valid phone number in the E.164 format"),
+		},
 		{
 			doc:    `{"emails":["foo@ory.sh","bar@ory.sh","bar@ory.sh"], "username": "foobar@ory.sh"}`,
 			schema: "file://./stub/extension/recovery/schema.json",
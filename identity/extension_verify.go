@@ -2,6 +2,7 @@ package identity
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,42 +26,59 @@ func (r *SchemaExtensionVerification) Run(ctx jsonschema.ValidationContext, s sc
 	r.l.Lock()
 	defer r.l.Unlock()
 
+	var address *VerifiableAddress
 	switch s.Verification.Via {
 	case "email":
 		if !jsonschema.Formats["email"](value) {
 			return ctx.Error("format", "%q is not valid %q", value, "email")
 		}
 
-		address := NewVerifiableEmailAddress(fmt.Sprintf("%s", value), r.i.ID)
-
-		if has := r.has(r.i.VerifiableAddresses, address); has != nil {
-			if r.has(r.v, address) == nil {
-				r.v = append(r.v, *has)
-			}
-			return nil
-		}
-
-		if has := r.has(r.v, address); has == nil {
-			r.v = append(r.v, *address)
+		address = NewVerifiableEmailAddress(fmt.Sprintf("%s", value), r.i.ID)
+	case "sms":
+		if !IsPhoneNumber(fmt.Sprintf("%s", value)) {
+			return ctx.Error("format", "%q is not a valid phone number in the E.164 format", value)
 		}
 
-		return nil
+		address = NewVerifiablePhoneAddress(fmt.Sprintf("%s", value), r.i.ID)
 	case "":
 		return nil
+	default:
+		return ctx.Error("", "verification.via has unknown value %q", s.Verification.Via)
+	}
+
+	if has := r.has(r.i.VerifiableAddresses, address); has != nil {
+		if r.has(r.v, address) == nil {
+			// The verification status is kept, the value is taken from the traits.
+			has.Value = address.Value
+			r.v = append(r.v, *has)
+		}
+		return nil
+	}
+
+	if has := r.has(r.v, address); has == nil {
+		r.v = append(r.v, *address)
 	}
 
-	return ctx.Error("", "verification.via has unknown value %q", s.Verification.Via)
+	return nil
 }
 
 func (r *SchemaExtensionVerification) has(haystack []VerifiableAddress, needle *VerifiableAddress) *VerifiableAddress {
 	for _, has := range haystack {
-		if has.Value == needle.Value && has.Via == needle.Via {
+		if has.Via == needle.Via && sameAddressValue(has.Via, has.Value, needle.Value) {
 			return &has
 		}
 	}
 	return nil
 }
 
+// sameAddressValue compares email addresses case-insensitively and all other addresses exactly.
+func sameAddressValue(via VerifiableAddressType, a, b string) bool {
+	if via == VerifiableAddressTypeEmail {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
 func (r *SchemaExtensionVerification) Finish() error {
 	r.i.VerifiableAddresses = r.v
 	return nil
@@ -139,6 +139,31 @@ func TestSchemaExtensionVerification(t *testing.T) {
 			schema:    "file://./stub/extension/verify/schema.json",
 			expectErr: errors.New("I[#/username] S[#/properties/username/format] \"foobar\" is not valid \"email\""),
 		},
+		{
+			doc:    `{"phone":"+12065550100","username":"foo@ory.sh"}`,
+			schema: "file://./stub/extension/verify/schema.json",
+			expect: []VerifiableAddress{
+				{
+					Value:      "+12065550100",
+					Verified:   false,
+					Status:     VerifiableAddressStatusPending,
+					Via:        VerifiableAddressTypePhone,
+					IdentityID: iid,
+				},
+				{
+					Value:      "foo@ory.sh",
+					Verified:   false,
+					Status:     VerifiableAddressStatusPending,
+					Via:        VerifiableAddressTypeEmail,
+					IdentityID: iid,
+				},
+			},
+		},
+		{
+			doc:       `{"phone":"2065550100"}`,
+			schema:    "file://./stub/extension/verify/schema.json",
+			expectErr: errors.New("I[#/phone] S[#/properties/phone/format] \"2065550100\" is not a valid phone number in the E.164 format"),
+		},
 		{
 			doc:    `{"emails":["foo@ory.sh","bar@ory.sh","bar@ory.sh"], "username": "foobar@ory.sh"}`,
 			schema: "file://./stub/extension/verify/schema.json",
@@ -1,15 +1,26 @@
 package identity
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"kratos/driver/config"
+	"kratos/hash"
 
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 
+	"github.com/ory/herodot"
 	"github.com/ory/x/jsonx"
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
 	"kratos/x"
@@ -22,8 +33,10 @@ type (
 		PoolProvider
 		PrivilegedPoolProvider
 		ManagementProvider
+		ValidationProvider
 		x.WriterProvider
 		config.Provider
+		hash.HashProvider
 	}
 	HandlerProvider interface {
 		IdentityHandler() *Handler
@@ -44,6 +57,8 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 
 	admin.POST(RouteBase, h.create)
 	admin.PUT(RouteBase+"/:id", h.update)
+	admin.PATCH(RouteBase+"/:id", h.patch)
+	admin.PUT(RouteBase+"/:id/state", h.updateState)
 }
 
 // A single identity.
@@ -87,13 +102,92 @@ type listIdentityParameters struct {
 	// default: 0
 	// min: 0
 	Page int `json:"page"`
+
+	// Page Token
+	//
+	// The ID of the last identity of the previous page. If set, the identities following it are returned, which
+	// stays fast for large offsets, and `page` is ignored. The response then only includes a `Link: rel="next"`
+	// header and no `X-Total-Count` header.
+	//
+	// required: false
+	// in: query
+	PageToken string `json:"page_token"`
+
+	// Credentials Identifier
+	//
+	// Only return identities with a credentials identifier (e.g. email or username) equal to this value.
+	//
+	// required: false
+	// in: query
+	CredentialsIdentifier string `json:"credentials_identifier"`
+
+	// Credentials Identifier Prefix
+	//
+	// Only return identities with a credentials identifier starting with this value.
+	//
+	// required: false
+	// in: query
+	CredentialsIdentifierPrefix string `json:"credentials_identifier_prefix"`
+
+	// Identity Schema ID
+	//
+	// Only return identities using this identity schema.
+	//
+	// required: false
+	// in: query
+	SchemaID string `json:"schema_id"`
+
+	// Verified
+	//
+	// If true, only return identities with at least one verified address. If false, only return identities
+	// without a verified address.
+	//
+	// required: false
+	// in: query
+	Verified bool `json:"verified"`
+
+	// Created After
+	//
+	// Only return identities created at or after this RFC 3339 timestamp.
+	//
+	// required: false
+	// in: query
+	// format: date-time
+	CreatedAfter string `json:"created_after"`
+
+	// Created Before
+	//
+	// Only return identities created before this RFC 3339 timestamp.
+	//
+	// required: false
+	// in: query
+	// format: date-time
+	CreatedBefore string `json:"created_before"`
+
+	// Trait Path
+	//
+	// A dot-separated path into the traits, e.g. `address.city`. Only identities where the trait at this path
+	// equals `trait_value` are returned.
+	//
+	// required: false
+	// in: query
+	Trait string `json:"trait"`
+
+	// Trait Value
+	//
+	// The value the trait at `trait` must equal. Values are compared as strings.
+	//
+	// required: false
+	// in: query
+	TraitValue string `json:"trait_value"`
 }
 
 // swagger:route GET /identities admin listIdentities
 //
 // List Identities
 //
-// Lists all identities. Does not support search at the moment.
+// Lists identities. The list can be filtered by credentials identifier, identity schema, verification
+// status, creation date, and trait value.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -104,25 +198,86 @@ type listIdentityParameters struct {
 //
 //     Responses:
 //       200: identityList
+//       400: genericError
 //       500: genericError
 func (h *Handler) list(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	page, itemsPerPage := x.ParsePagination(r)
-	is, err := h.r.IdentityPool().ListIdentities(r.Context(), page, itemsPerPage)
+	params, err := parseListIdentityParameters(r)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	total, err := h.r.IdentityPool().CountIdentities(r.Context())
+	is, err := h.r.IdentityPool().ListIdentities(r.Context(), params)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteBase), total, page, itemsPerPage)
+	// The links keep the filters of the request.
+	query := r.URL.Query()
+	base := urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteBase)
+	if params.PageToken == uuid.Nil {
+		total, err := h.r.IdentityPool().CountIdentities(r.Context(), params)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+
+		w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+		x.PaginationHeader(w, urlx.CopyWithQuery(base, query), total, params.Page, params.PerPage)
+	} else if len(is) == params.PerPage {
+		query.Set("page_token", is[len(is)-1].ID.String())
+		query.Set("per_page", strconv.Itoa(params.PerPage))
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, urlx.CopyWithQuery(base, query).String()))
+	}
+
 	h.r.Writer().Write(w, r, is)
 }
 
+func parseListIdentityParameters(r *http.Request) (params ListIdentityParameters, err error) {
+	query := r.URL.Query()
+	params.Page, params.PerPage = x.ParsePagination(r)
+	params.CredentialsIdentifier = query.Get("credentials_identifier")
+	params.CredentialsIdentifierPrefix = query.Get("credentials_identifier_prefix")
+	params.SchemaID = query.Get("schema_id")
+	params.TraitPath = query.Get("trait")
+	params.TraitValue = query.Get("trait_value")
+
+	if token := query.Get("page_token"); token != "" {
+		params.Page = 0
+		if params.PageToken, err = uuid.FromString(token); err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The page_token query parameter must be a UUID: %s", err))
+		}
+	}
+
+	if verified := query.Get("verified"); verified != "" {
+		v, err := strconv.ParseBool(verified)
+		if err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The verified query parameter must be a boolean: %s", err))
+		}
+		params.Verified = &v
+	}
+
+	for key, target := range map[string]*time.Time{
+		"created_after":  &params.CreatedAfter,
+		"created_before": &params.CreatedBefore,
+	} {
+		if value := query.Get(key); value != "" {
+			if *target, err = time.Parse(time.RFC3339, value); err != nil {
+				return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The %s query parameter must be a RFC 3339 timestamp: %s", key, err))
+			}
+		}
+	}
+
+	if params.TraitPath != "" {
+		if _, err := params.TraitPathKeys(); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
 // swagger:parameters getIdentity
 // nolint:deadcode,unused
 type getIdentityParameters struct {
@@ -153,15 +308,42 @@ type getIdentityParameters struct {
 //       404: genericError
 //       500: genericError
 func (h *Handler) get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	i, err := h.r.IdentityPool().GetIdentity(r.Context(), x.ParseUUID(ps.ByName("id")))
+	h.writeIdentity(w, r, x.ParseUUID(ps.ByName("id")))
+}
+
+// writeIdentity writes the identity as stored together with its ETag.
+func (h *Handler) writeIdentity(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	i, err := h.r.IdentityPool().GetIdentity(r.Context(), id)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", identityETag(i))
 	h.r.Writer().Write(w, r, i)
 }
 
+// identityETag derives the ETag from the time the identity was last updated.
+func identityETag(i *Identity) string {
+	return fmt.Sprintf(`"%x"`, i.UpdatedAt.UnixNano())
+}
+
+// ifMatch returns true if the If-Match header is not set or matches the identity's ETag.
+func ifMatch(r *http.Request, i *Identity) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag == "*" || tag == identityETag(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // swagger:parameters createIdentity
 // nolint:deadcode,unused
 type createIdentityParameters struct {
@@ -183,14 +365,43 @@ type CreateIdentity struct {
 	// required: true
 	// in: body
 	Traits json.RawMessage `json:"traits"`
+
+	// State is the identity's state. Defaults to "active".
+	//
+	// in: body
+	State State `json:"state"`
+
+	// MetadataPublic contains application data which is visible to the identity, for example in the
+	// session, but can only be changed by administrators.
+	//
+	// in: body
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+
+	// MetadataAdmin contains application data which is only visible and editable through the admin API.
+	//
+	// in: body
+	MetadataAdmin json.RawMessage `json:"metadata_admin"`
+
+	// Credentials imports the identity's credentials, for example when migrating users from another system.
+	//
+	// in: body
+	Credentials *CreateIdentityCredentials `json:"credentials"`
+
+	// VerifiableAddresses imports the verification status of the identity's addresses. Addresses which are
+	// not part of the identity's traits are rejected.
+	//
+	// in: body
+	VerifiableAddresses []CreateIdentityVerifiableAddress `json:"verifiable_addresses"`
 }
 
 // swagger:route POST /identities admin createIdentity
 //
 // Create an Identity
 //
-// This endpoint creates an identity. It is NOT possible to set an identity's credentials (password, ...)
-// using this method! A way to achieve that will be introduced in the future.
+// This endpoint creates an identity. Password and OpenID Connect credentials as well as the verification status
+// of addresses can be imported, for example when migrating users from another system. Passwords can be imported
+// in clear text or as a hash (bcrypt, Argon2id, PBKDF2, scrypt, Firebase scrypt, salted SHA, or a peppered
+// variant of these).
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -214,7 +425,31 @@ func (h *Handler) create(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
-	i := &Identity{SchemaID: cr.SchemaID, Traits: []byte(cr.Traits)}
+	if cr.State == "" {
+		cr.State = StateActive
+	} else if !cr.State.IsValid() {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Identity state %q is not supported.", cr.State)))
+		return
+	}
+
+	i := &Identity{
+		SchemaID:       cr.SchemaID,
+		Traits:         []byte(cr.Traits),
+		State:          cr.State,
+		MetadataPublic: sqlxx.NullJSONRawMessage(cr.MetadataPublic),
+		MetadataAdmin:  sqlxx.NullJSONRawMessage(cr.MetadataAdmin),
+	}
+
+	if err := h.importCredentials(r.Context(), i, cr.Credentials); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.importVerifiableAddresses(r.Context(), i, cr.VerifiableAddresses); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
 	if err := h.r.IdentityManager().Create(r.Context(), i); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
@@ -238,6 +473,13 @@ type updateIdentityParameters struct {
 	// required: true
 	// in: path
 	ID string `json:"id"`
+
+	// If set, the identity is only updated if its ETag matches.
+	//
+	// required: false
+	// in: header
+	IfMatch string `json:"If-Match"`
+
 	// in: body
 	Body UpdateIdentity
 }
@@ -253,6 +495,14 @@ type UpdateIdentity struct {
 	//
 	// required: true
 	Traits json.RawMessage `json:"traits"`
+
+	// MetadataPublic contains application data which is visible to the identity. If set, replaces the
+	// identity's public metadata. Set it to null to remove the metadata.
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+
+	// MetadataAdmin contains application data which is only visible through the admin API. If set, replaces
+	// the identity's admin metadata. Set it to null to remove the metadata.
+	MetadataAdmin json.RawMessage `json:"metadata_admin"`
 }
 
 // swagger:route PUT /identities/{id} admin updateIdentity
@@ -262,7 +512,11 @@ type UpdateIdentity struct {
 // This endpoint updates an identity. It is NOT possible to set an identity's credentials (password, ...)
 // using this method! A way to achieve that will be introduced in the future.
 //
-// The full identity payload (except credentials) is expected. This endpoint does not support patching.
+// The full identity payload (except credentials) is expected. Use the `PATCH` method to update parts of the
+// identity instead.
+//
+// If the `If-Match` header is set to the identity's ETag, the identity is only updated if it was not modified
+// in the meantime.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -278,6 +532,7 @@ type UpdateIdentity struct {
 //       200: identityResponse
 //       400: genericError
 //       404: genericError
+//       412: genericError
 //       500: genericError
 func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var ur UpdateIdentity
@@ -293,21 +548,240 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
+	opts := []ManagerOption{ManagerAllowWriteProtectedTraits}
+	if r.Header.Get("If-Match") != "" {
+		if !ifMatch(r, identity) {
+			h.r.Writer().WriteError(w, r, errors.WithStack(ErrIdentityModified))
+			return
+		}
+		opts = append(opts, ManagerIfUnmodified(identity.UpdatedAt))
+	}
+
 	if ur.SchemaID != "" {
 		identity.SchemaID = ur.SchemaID
 	}
 
+	if ur.MetadataPublic != nil {
+		identity.MetadataPublic = sqlxx.NullJSONRawMessage(ur.MetadataPublic)
+	}
+
+	if ur.MetadataAdmin != nil {
+		identity.MetadataAdmin = sqlxx.NullJSONRawMessage(ur.MetadataAdmin)
+	}
+
 	identity.Traits = []byte(ur.Traits)
-	if err := h.r.IdentityManager().Update(
-		r.Context(),
-		identity,
-		ManagerAllowWriteProtectedTraits,
-	); err != nil {
+	if err := h.r.IdentityManager().Update(r.Context(), identity, opts...); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.writeIdentity(w, r, id)
+}
+
+// swagger:parameters patchIdentity
+// nolint:deadcode,unused
+type patchIdentityParameters struct {
+	// ID must be set to the ID of identity you want to update
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// If set, the identity is only updated if its ETag matches.
+	//
+	// required: false
+	// in: header
+	IfMatch string `json:"If-Match"`
+
+	// in: body
+	Body []jsonPatch
+}
+
+// A JSON Patch operation as defined in RFC 6902.
+//
+// swagger:model jsonPatch
+// nolint:deadcode,unused
+type jsonPatch struct {
+	// The operation to perform.
+	//
+	// required: true
+	// enum: add,remove,replace,move,copy,test
+	Op string `json:"op"`
+
+	// The JSON Pointer of the target location, for example "/traits/email".
+	//
+	// required: true
+	Path string `json:"path"`
+
+	// The JSON Pointer of the source location for "move" and "copy" operations.
+	From string `json:"from"`
+
+	// The value for "add", "replace", and "test" operations.
+	Value interface{} `json:"value"`
+}
+
+// patchableIdentity contains the fields of an identity which can be patched.
+type patchableIdentity struct {
+	Traits         json.RawMessage `json:"traits"`
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+	MetadataAdmin  json.RawMessage `json:"metadata_admin"`
+	State          State           `json:"state"`
+}
+
+// swagger:route PATCH /identities/{id} admin patchIdentity
+//
+// Patch an Identity
+//
+// This endpoint applies a JSON Patch (RFC 6902) to the identity's `traits`, `metadata_public`, `metadata_admin`,
+// and `state`. The patched identity is validated against its identity schema before it is stored.
+//
+// The update fails with 412 if the identity was modified in the meantime. If the `If-Match` header is set to the
+// identity's ETag, the patch is only applied if the identity was not modified since it was fetched.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/json-patch+json
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: identityResponse
+//       400: genericError
+//       404: genericError
+//       412: genericError
+//       500: genericError
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(err))
+		return
+	}
+
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the JSON Patch: %s", err)))
+		return
+	}
+
+	id := x.ParseUUID(ps.ByName("id"))
+	i, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if !ifMatch(r, i) {
+		h.r.Writer().WriteError(w, r, errors.WithStack(ErrIdentityModified))
+		return
+	}
+
+	original, err := json.Marshal(&patchableIdentity{
+		Traits:         json.RawMessage(i.Traits),
+		MetadataPublic: json.RawMessage(i.MetadataPublic),
+		MetadataAdmin:  json.RawMessage(i.MetadataAdmin),
+		State:          i.State,
+	})
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(err))
+		return
+	}
+
+	patched, err := patch.Apply(original)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to apply the JSON Patch: %s", err)))
+		return
+	}
+
+	var pi patchableIdentity
+	if err := jsonx.NewStrictDecoder(bytes.NewReader(patched)).Decode(&pi); err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Only the traits, metadata, and state of an identity can be patched: %s", err)))
+		return
+	}
+
+	if !pi.State.IsValid() {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Identity state %q is not supported.", pi.State)))
+		return
+	}
+
+	updatedAt := i.UpdatedAt
+	i.Traits = Traits(pi.Traits)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(pi.MetadataPublic)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(pi.MetadataAdmin)
+	i.State = pi.State
+	if err := h.r.IdentityManager().Update(r.Context(), i, ManagerAllowWriteProtectedTraits, ManagerIfUnmodified(updatedAt)); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.writeIdentity(w, r, id)
+}
+
+// swagger:parameters updateIdentityState
+// nolint:deadcode,unused
+type updateIdentityStateParameters struct {
+	// ID must be set to the ID of identity you want to update
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body UpdateIdentityState
+}
+
+type UpdateIdentityState struct {
+	// State is the identity's new state.
+	//
+	// required: true
+	State State `json:"state"`
+}
+
+// swagger:route PUT /identities/{id}/state admin updateIdentityState
+//
+// Activate or Deactivate an Identity
+//
+// This endpoint sets an identity's state. Inactive identities can not sign in and all of their sessions are
+// revoked when they are deactivated. Setting the state to "active" allows the identity to sign in again.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: identityResponse
+//       400: genericError
+//       404: genericError
+//       500: genericError
+func (h *Handler) updateState(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var ur UpdateIdentityState
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&ur); err != nil {
+		h.r.Writer().WriteErrorCode(w, r, http.StatusBadRequest, errors.WithStack(err))
+		return
+	}
+
+	if !ur.State.IsValid() {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Identity state %q is not supported.", ur.State)))
+		return
+	}
+
+	id := x.ParseUUID(ps.ByName("id"))
+	if err := h.r.PrivilegedIdentityPool().UpdateIdentityState(r.Context(), id, ur.State); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	h.r.Writer().Write(w, r, identity)
+	h.writeIdentity(w, r, id)
 }
 
 // swagger:parameters deleteIdentity
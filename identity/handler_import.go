@@ -0,0 +1,217 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
+
+	"kratos/hash"
+)
+
+// CreateIdentityCredentials contains the credentials to import when creating an identity.
+type CreateIdentityCredentials struct {
+	// Password imports a password which can be used to sign in with the identity's identifiers.
+	Password *CreateIdentityCredentialsPassword `json:"password"`
+
+	// OIDC links the identity to the subjects of OpenID Connect providers.
+	OIDC *CreateIdentityCredentialsOIDC `json:"oidc"`
+}
+
+type CreateIdentityCredentialsPassword struct {
+	// required: true
+	Config CreateIdentityCredentialsPasswordConfig `json:"config"`
+}
+
+type CreateIdentityCredentialsPasswordConfig struct {
+	// HashedPassword is a password hash in a format supported by Ory Kratos. Use it to migrate users from other
+	// systems. Supported are bcrypt (`$2a$...`), Argon2id (`$argon2id$...`), PBKDF2 (`$pbkdf2-sha256$...`),
+	// scrypt (`$scrypt$...`), Firebase scrypt (`$firescrypt$...`), salted SHA-1, SHA-256 and SHA-512
+	// (`$sha512$...`), and any of these computed from a peppered password (`$pepper$...`). The encodings are
+	// described in the [password documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/username-email-password#legacy-password-hashes).
+	HashedPassword string `json:"hashed_password"`
+
+	// Password is a password in clear text which is hashed before it is stored. Password
+	// policies are not enforced as users need to be able to sign in with their existing password.
+	Password string `json:"password"`
+}
+
+type CreateIdentityCredentialsOIDC struct {
+	// required: true
+	Config CreateIdentityCredentialsOIDCConfig `json:"config"`
+}
+
+type CreateIdentityCredentialsOIDCConfig struct {
+	// required: true
+	Providers []CreateIdentityCredentialsOIDCProvider `json:"providers"`
+}
+
+type CreateIdentityCredentialsOIDCProvider struct {
+	// Provider is the ID of the OpenID Connect provider as configured in Ory Kratos.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// Subject is the identity's subject at the OpenID Connect provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+}
+
+// CreateIdentityVerifiableAddress is an address to import together with its verification status.
+type CreateIdentityVerifiableAddress struct {
+	// Value is the address, for example an email address. It must be part of the identity's traits.
+	//
+	// required: true
+	Value string `json:"value"`
+
+	// Via is the address type, either "email" or "phone", and defaults to "email". Like in the identity schema,
+	// "sms" can be used for phone numbers.
+	Via VerifiableAddressType `json:"via"`
+
+	// Verified marks the address as verified.
+	Verified bool `json:"verified"`
+
+	// VerifiedAt is the time the address was verified. Defaults to the time of the import for verified addresses.
+	VerifiedAt sqlxx.NullTime `json:"verified_at"`
+}
+
+func (h *Handler) importCredentials(ctx context.Context, i *Identity, creds *CreateIdentityCredentials) error {
+	if creds == nil {
+		return nil
+	}
+
+	if creds.Password != nil {
+		if err := h.importPasswordCredentials(ctx, i, creds.Password.Config); err != nil {
+			return err
+		}
+	}
+
+	if creds.OIDC != nil {
+		if err := importOIDCCredentials(i, creds.OIDC.Config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) importPasswordCredentials(ctx context.Context, i *Identity, c CreateIdentityCredentialsPasswordConfig) error {
+	if (len(c.Password) == 0) == (len(c.HashedPassword) == 0) {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Either the password or the hashed password must be set when importing password credentials."))
+	}
+
+	hashed := []byte(c.HashedPassword)
+	if len(c.Password) > 0 {
+		var err error
+		if hashed, err = h.r.Hasher().Generate(ctx, []byte(c.Password)); err != nil {
+			return err
+		}
+	} else if !hash.IsKnownHash(hashed) {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The hashed password uses an unsupported hash algorithm."))
+	}
+
+	config, err := json.Marshal(&CredentialsPassword{HashedPassword: string(hashed)})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The identifiers are set from the traits when the identity is validated.
+	i.SetCredentials(CredentialsTypePassword, Credentials{Identifiers: []string{}, Config: config})
+	return nil
+}
+
+func importOIDCCredentials(i *Identity, c CreateIdentityCredentialsOIDCConfig) error {
+	if len(c.Providers) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("At least one provider must be set when importing OpenID Connect credentials."))
+	}
+
+	var conf CredentialsOIDC
+	identifiers := make([]string, len(c.Providers))
+	for k, p := range c.Providers {
+		if len(p.Provider) == 0 || len(p.Subject) == 0 {
+			return errors.WithStack(herodot.ErrBadRequest.WithReason("The provider and subject must be set when importing OpenID Connect credentials."))
+		}
+
+		conf.Providers = append(conf.Providers, CredentialsOIDCProvider{Provider: p.Provider, Subject: p.Subject})
+		identifiers[k] = OIDCUniqueID(p.Provider, p.Subject)
+	}
+
+	config, err := json.Marshal(&conf)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	i.SetCredentials(CredentialsTypeOIDC, Credentials{Identifiers: identifiers, Config: config})
+	return nil
+}
+
+// importVerifiableAddresses sets the addresses before the identity is validated which keeps their verification
+// status. Every address has to match one of the addresses which the identity schema derives from the traits.
+func (h *Handler) importVerifiableAddresses(ctx context.Context, i *Identity, addresses []CreateIdentityVerifiableAddress) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	imported := make([]VerifiableAddress, len(addresses))
+	for k, a := range addresses {
+		address := VerifiableAddress{
+			Value:      a.Value,
+			Via:        a.Via,
+			Verified:   a.Verified,
+			Status:     VerifiableAddressStatusPending,
+			IdentityID: i.ID,
+		}
+
+		switch address.Via {
+		case "", VerifiableAddressTypeEmail:
+			// Email addresses are case-insensitive.
+			address.Via = VerifiableAddressTypeEmail
+			address.Value = strings.ToLower(strings.TrimSpace(address.Value))
+		case VerifiableAddressTypePhone, "sms":
+			// The identity schema uses "sms" to mark phone numbers.
+			address.Via = VerifiableAddressTypePhone
+		default:
+			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Verifiable address %q uses the unsupported type %q.", a.Value, a.Via))
+		}
+
+		if address.Verified {
+			address.Status = VerifiableAddressStatusCompleted
+			address.VerifiedAt = a.VerifiedAt
+			if time.Time(address.VerifiedAt).IsZero() {
+				address.VerifiedAt = sqlxx.NullTime(time.Now().UTC())
+			}
+		}
+
+		imported[k] = address
+	}
+
+	// Validating the identity replaces the addresses with the ones found in the traits and keeps the
+	// imported ones which match. Addresses which do not match would be dropped without notice.
+	i.VerifiableAddresses = imported
+	if err := h.r.IdentityValidator().Validate(ctx, i); err != nil {
+		return err
+	}
+
+	for _, a := range imported {
+		if !hasVerifiableAddress(i.VerifiableAddresses, a) {
+			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Verifiable address %q does not match any trait of the identity schema.", a.Value))
+		}
+	}
+
+	return nil
+}
+
+func hasVerifiableAddress(haystack []VerifiableAddress, needle VerifiableAddress) bool {
+	for _, a := range haystack {
+		if a.Via == needle.Via && a.Verified == needle.Verified && sameAddressValue(a.Via, a.Value, needle.Value) {
+			return true
+		}
+	}
+	return false
+}
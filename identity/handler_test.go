@@ -2,12 +2,19 @@ package identity_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
 	"kratos/internal/testhelpers"
@@ -16,10 +23,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"golang.org/x/crypto/bcrypt"
 
 	"kratos/driver/config"
+	"kratos/hash"
 	"kratos/identity"
 	"kratos/internal"
+	"kratos/session"
 	"kratos/x"
 )
 
@@ -252,12 +262,446 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("suite=metadata", func(t *testing.T) {
+		var id string
+		t.Run("case=should create an identity with metadata", func(t *testing.T) {
+			res := send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}, "metadata_public": {"role":"user"}, "metadata_admin": {"note":"vip"}}`))
+			assert.EqualValues(t, "user", res.Get("metadata_public.role").String(), "%s", res.Raw)
+			assert.EqualValues(t, "vip", res.Get("metadata_admin.note").String(), "%s", res.Raw)
+			id = res.Get("id").String()
+
+			res = get(t, "/identities/"+id, http.StatusOK)
+			assert.EqualValues(t, "user", res.Get("metadata_public.role").String(), "%s", res.Raw)
+			assert.EqualValues(t, "vip", res.Get("metadata_admin.note").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should keep the metadata if it is not set on update", func(t *testing.T) {
+			res := send(t, "PUT", "/identities/"+id, http.StatusOK, json.RawMessage(`{"traits": {"bar":"baz"}, "metadata_public": {"role":"admin"}}`))
+			assert.EqualValues(t, "admin", res.Get("metadata_public.role").String(), "%s", res.Raw)
+			assert.EqualValues(t, "vip", res.Get("metadata_admin.note").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should remove the metadata if it is null", func(t *testing.T) {
+			res := send(t, "PUT", "/identities/"+id, http.StatusOK, json.RawMessage(`{"traits": {"bar":"baz"}, "metadata_admin": null}`))
+			assert.EqualValues(t, "admin", res.Get("metadata_public.role").String(), "%s", res.Raw)
+			assert.Nil(t, get(t, "/identities/"+id, http.StatusOK).Get("metadata_admin").Value())
+		})
+
+		t.Run("case=should not expose the admin metadata to the identity", func(t *testing.T) {
+			i := identity.NewIdentity("")
+			i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"role":"user"}`)
+			i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"note":"vip"}`)
+
+			actual := i.Declassify()
+			assert.JSONEq(t, `{"role":"user"}`, string(actual.MetadataPublic))
+			assert.Nil(t, actual.MetadataAdmin)
+			assert.NotNil(t, i.MetadataAdmin)
+		})
+	})
+
+	t.Run("suite=state", func(t *testing.T) {
+		t.Run("case=should create active identities by default", func(t *testing.T) {
+			res := send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}}`))
+			assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should create an inactive identity", func(t *testing.T) {
+			res := send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"traits": {"bar":"baz"}, "state": "inactive"}`))
+			assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should not create an identity with an unknown state", func(t *testing.T) {
+			send(t, "POST", "/identities", http.StatusBadRequest, json.RawMessage(`{"traits": {"bar":"baz"}, "state": "banned"}`))
+		})
+
+		t.Run("case=should deactivate an identity and revoke its sessions", func(t *testing.T) {
+			i := identity.NewIdentity("")
+			i.Traits = identity.Traits(`{"bar":"baz"}`)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+
+			s := session.NewActiveSession(i, conf, time.Now())
+			require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), s))
+
+			res := send(t, "PUT", "/identities/"+i.ID.String()+"/state", http.StatusOK, &identity.UpdateIdentityState{State: identity.StateInactive})
+			assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+
+			actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+			require.NoError(t, err)
+			assert.False(t, actual.Active)
+			assert.False(t, actual.Identity.IsActive())
+
+			res = send(t, "PUT", "/identities/"+i.ID.String()+"/state", http.StatusOK, &identity.UpdateIdentityState{State: identity.StateActive})
+			assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+			assert.EqualValues(t, identity.StateActive, get(t, "/identities/"+i.ID.String(), http.StatusOK).Get("state").String())
+		})
+
+		t.Run("case=should not set an unknown state", func(t *testing.T) {
+			i := identity.NewIdentity("")
+			i.Traits = identity.Traits(`{"bar":"baz"}`)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+			send(t, "PUT", "/identities/"+i.ID.String()+"/state", http.StatusBadRequest, json.RawMessage(`{"state": "banned"}`))
+		})
+
+		t.Run("case=should return 404 for non-existing identities", func(t *testing.T) {
+			send(t, "PUT", "/identities/"+x.NewUUID().String()+"/state", http.StatusNotFound, &identity.UpdateIdentityState{State: identity.StateInactive})
+		})
+	})
+
 	t.Run("case=should list all identities", func(t *testing.T) {
 		res := get(t, "/identities", http.StatusOK)
 		assert.Empty(t, res.Get("0.credentials").String(), "%s", res.Raw)
 		assert.EqualValues(t, "baz", res.Get(`#(traits.bar=="baz").traits.bar`).String(), "%s", res.Raw)
 	})
 
+	t.Run("suite=import", func(t *testing.T) {
+		var create = func(t *testing.T, expectCode int, credentials string, extra string) (string, gjson.Result) {
+			email := x.NewUUID().String() + "@ory.sh"
+			res := send(t, "POST", "/identities", expectCode, json.RawMessage(`{"schema_id": "employee", "traits": {"email": "`+email+`"}, "credentials": `+credentials+extra+`}`))
+			return email, res
+		}
+
+		var passwordHash = func(t *testing.T, id string) []byte {
+			i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(id))
+			require.NoError(t, err)
+			c, ok := i.GetCredentials(identity.CredentialsTypePassword)
+			require.True(t, ok)
+			var conf identity.CredentialsPassword
+			require.NoError(t, json.Unmarshal(c.Config, &conf))
+			return []byte(conf.HashedPassword)
+		}
+
+		t.Run("case=should import a clear text password", func(t *testing.T) {
+			email, res := create(t, http.StatusCreated, `{"password": {"config": {"password": "123456"}}}`, "")
+			hashed := passwordHash(t, res.Get("id").String())
+			assert.NotEqual(t, "123456", string(hashed))
+			require.NoError(t, hash.Compare(context.Background(), []byte("123456"), hashed))
+
+			_, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, email)
+			require.NoError(t, err)
+			assert.Equal(t, []string{email}, c.Identifiers)
+		})
+
+		t.Run("case=should import a hashed password", func(t *testing.T) {
+			bcrypted, err := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.MinCost)
+			require.NoError(t, err)
+			argon2ed, err := reg.Hasher().Generate(context.Background(), []byte("123456"))
+			require.NoError(t, err)
+
+			for _, hashed := range [][]byte{bcrypted, argon2ed} {
+				_, res := create(t, http.StatusCreated, `{"password": {"config": {"hashed_password": "`+string(hashed)+`"}}}`, "")
+				actual := passwordHash(t, res.Get("id").String())
+				assert.Equal(t, string(hashed), string(actual))
+				require.NoError(t, hash.Compare(context.Background(), []byte("123456"), actual))
+			}
+		})
+
+		t.Run("case=should reject invalid passwords", func(t *testing.T) {
+			for _, credentials := range []string{
+				`{"password": {"config": {"hashed_password": "$unknown$123456"}}}`,
+				`{"password": {"config": {"hashed_password": "$2a$31$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6"}}}`,
+				`{"password": {"config": {"hashed_password": "$argon2id$v=19$m=16777216,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw"}}}`,
+				`{"password": {"config": {"hashed_password": "$argon2id$v=19$m=32,t=100000,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw"}}}`,
+				`{"password": {"config": {"hashed_password": "$2a$10$abc", "password": "123456"}}}`,
+				`{"password": {"config": {}}}`,
+			} {
+				_, res := create(t, http.StatusBadRequest, credentials, "")
+				assert.NotEmpty(t, res.Get("error.reason").String(), "%s", res.Raw)
+			}
+		})
+
+		t.Run("case=should import OpenID Connect credentials", func(t *testing.T) {
+			subject := x.NewUUID().String()
+			_, res := create(t, http.StatusCreated, `{"oidc": {"config": {"providers": [{"provider": "github", "subject": "`+subject+`"}, {"provider": "google", "subject": "`+subject+`"}]}}}`, "")
+
+			for _, provider := range []string{"github", "google"} {
+				actual, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeOIDC, provider+":"+subject)
+				require.NoError(t, err)
+				assert.Equal(t, res.Get("id").String(), actual.ID.String())
+				assert.Equal(t, provider, gjson.GetBytes(c.Config, `providers.#(provider=="`+provider+`").provider`).String(), "%s", c.Config)
+			}
+		})
+
+		t.Run("case=should reject invalid OpenID Connect credentials", func(t *testing.T) {
+			create(t, http.StatusBadRequest, `{"oidc": {"config": {"providers": []}}}`, "")
+			create(t, http.StatusBadRequest, `{"oidc": {"config": {"providers": [{"provider": "github"}]}}}`, "")
+		})
+
+		t.Run("case=should keep the verification status of addresses", func(t *testing.T) {
+			email := x.NewUUID().String() + "@ory.sh"
+			res := send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{
+  "schema_id": "employee",
+  "traits": {"email": "`+email+`"},
+  "verifiable_addresses": [
+    {"value": "`+email+`", "via": "email", "verified": true}
+  ]
+}`))
+
+			res = get(t, "/identities/"+res.Get("id").String(), http.StatusOK)
+			assert.Len(t, res.Get("verifiable_addresses").Array(), 1, "%s", res.Raw)
+			assert.Equal(t, email, res.Get("verifiable_addresses.0.value").String(), "%s", res.Raw)
+			assert.True(t, res.Get("verifiable_addresses.0.verified").Bool(), "%s", res.Raw)
+			assert.EqualValues(t, identity.VerifiableAddressStatusCompleted, res.Get("verifiable_addresses.0.status").String(), "%s", res.Raw)
+			assert.NotEmpty(t, res.Get("verifiable_addresses.0.verified_at").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should match addresses like the identity schema", func(t *testing.T) {
+			email := x.NewUUID().String() + "@ory.sh"
+			res := send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{
+  "schema_id": "employee",
+  "traits": {"email": "`+email+`", "phone": "+4917612345678"},
+  "verifiable_addresses": [
+    {"value": "`+strings.ToUpper(email)+`", "via": "email", "verified": true},
+    {"value": "+4917612345678", "via": "sms", "verified": true}
+  ]
+}`))
+
+			res = get(t, "/identities/"+res.Get("id").String(), http.StatusOK)
+			assert.Len(t, res.Get("verifiable_addresses").Array(), 2, "%s", res.Raw)
+			assert.True(t, res.Get(`verifiable_addresses.#(value=="`+email+`").verified`).Bool(), "%s", res.Raw)
+			assert.EqualValues(t, identity.VerifiableAddressTypePhone, res.Get(`verifiable_addresses.#(value=="+4917612345678").via`).String(), "%s", res.Raw)
+			assert.True(t, res.Get(`verifiable_addresses.#(value=="+4917612345678").verified`).Bool(), "%s", res.Raw)
+		})
+
+		t.Run("case=should reject addresses which can not be imported", func(t *testing.T) {
+			email := x.NewUUID().String() + "@ory.sh"
+			for _, address := range []string{
+				`{"value": "not-in-traits@ory.sh", "via": "email", "verified": true}`,
+				`{"value": "` + email + `", "via": "pigeon", "verified": true}`,
+				`{"value": "` + email + `", "via": "sms", "verified": true}`,
+			} {
+				res := send(t, "POST", "/identities", http.StatusBadRequest, json.RawMessage(`{
+  "schema_id": "employee",
+  "traits": {"email": "`+email+`"},
+  "verifiable_addresses": [`+address+`]
+}`))
+				assert.NotEmpty(t, res.Get("error.reason").String(), "%s", res.Raw)
+			}
+		})
+	})
+
+	t.Run("suite=patch", func(t *testing.T) {
+		var patch = func(t *testing.T, id, ifMatch string, expectCode int, ops string) (gjson.Result, string) {
+			req, err := http.NewRequest("PATCH", ts.URL+"/identities/"+id, strings.NewReader(ops))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json-patch+json")
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+
+			res, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+			return gjson.ParseBytes(body), res.Header.Get("ETag")
+		}
+
+		var etag = func(t *testing.T, id string) string {
+			res, err := ts.Client().Get(ts.URL + "/identities/" + id)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.EqualValues(t, http.StatusOK, res.StatusCode)
+			require.NotEmpty(t, res.Header.Get("ETag"))
+			return res.Header.Get("ETag")
+		}
+
+		var create = func(t *testing.T) string {
+			return send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"schema_id": "customer", "traits": {"email": "`+x.NewUUID().String()+`@ory.sh", "address": "Berlin"}}`)).Get("id").String()
+		}
+
+		t.Run("case=should patch the traits, metadata, and state", func(t *testing.T) {
+			id := create(t)
+			res, tag := patch(t, id, "", http.StatusOK, `[
+  {"op": "replace", "path": "/traits/address", "value": "Munich"},
+  {"op": "add", "path": "/metadata_public", "value": {"plan": "pro"}},
+  {"op": "add", "path": "/metadata_admin", "value": {"note": "vip"}},
+  {"op": "replace", "path": "/state", "value": "inactive"}
+]`)
+			assert.Equal(t, "Munich", res.Get("traits.address").String(), "%s", res.Raw)
+			assert.Equal(t, "pro", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+			assert.Equal(t, "vip", res.Get("metadata_admin.note").String(), "%s", res.Raw)
+			assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+			assert.Equal(t, etag(t, id), tag)
+
+			res, _ = patch(t, id, "", http.StatusOK, `[{"op": "remove", "path": "/metadata_admin/note"}]`)
+			assert.False(t, res.Get("metadata_admin.note").Exists(), "%s", res.Raw)
+			assert.Equal(t, "pro", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should revoke the sessions when deactivating the identity", func(t *testing.T) {
+			i := identity.NewIdentity("")
+			i.Traits = identity.Traits(`{"bar":"baz"}`)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+
+			s := session.NewActiveSession(i, conf, time.Now())
+			require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), s))
+
+			patch(t, i.ID.String(), "", http.StatusOK, `[{"op": "replace", "path": "/state", "value": "inactive"}]`)
+
+			actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+			require.NoError(t, err)
+			assert.False(t, actual.Active)
+		})
+
+		t.Run("case=should reject invalid patches", func(t *testing.T) {
+			id := create(t)
+			for k, ops := range []string{
+				`{"op": "replace", "path": "/traits/address", "value": "Munich"}`,
+				`[{"op": "replace", "path": "/traits/does-not-exist", "value": "Munich"}]`,
+				`[{"op": "test", "path": "/traits/address", "value": "Munich"}]`,
+				`[{"op": "add", "path": "/credentials", "value": {}}]`,
+				`[{"op": "add", "path": "/schema_id", "value": "employee"}]`,
+				`[{"op": "replace", "path": "/traits/address", "value": 1234}]`,
+				`[{"op": "add", "path": "/traits/unknown", "value": "foo"}]`,
+				`[{"op": "replace", "path": "/state", "value": "banned"}]`,
+			} {
+				t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+					patch(t, id, "", http.StatusBadRequest, ops)
+				})
+			}
+
+			assert.Equal(t, "Berlin", get(t, "/identities/"+id, http.StatusOK).Get("traits.address").String())
+		})
+
+		t.Run("case=should only apply the patch if the ETag matches", func(t *testing.T) {
+			id := create(t)
+			tag := etag(t, id)
+
+			_, next := patch(t, id, tag, http.StatusOK, `[{"op": "replace", "path": "/traits/address", "value": "Munich"}]`)
+			assert.NotEqual(t, tag, next)
+
+			res, _ := patch(t, id, tag, http.StatusPreconditionFailed, `[{"op": "replace", "path": "/traits/address", "value": "Hamburg"}]`)
+			assert.Contains(t, res.Get("error.message").String(), "modified", "%s", res.Raw)
+			assert.Equal(t, "Munich", get(t, "/identities/"+id, http.StatusOK).Get("traits.address").String())
+
+			patch(t, id, next, http.StatusOK, `[{"op": "replace", "path": "/traits/address", "value": "Hamburg"}]`)
+			patch(t, id, "*", http.StatusOK, `[{"op": "replace", "path": "/traits/address", "value": "Hamburg"}]`)
+		})
+
+		t.Run("case=should only replace the identity if the ETag matches", func(t *testing.T) {
+			id := create(t)
+			tag := etag(t, id)
+
+			var update = func(t *testing.T, ifMatch string, expectCode int) {
+				req, err := http.NewRequest("PUT", ts.URL+"/identities/"+id, strings.NewReader(`{"traits": {"email": "`+x.NewUUID().String()+`@ory.sh", "address": "Munich"}}`))
+				require.NoError(t, err)
+				req.Header.Set("If-Match", ifMatch)
+				res, err := ts.Client().Do(req)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+				require.EqualValues(t, expectCode, res.StatusCode)
+			}
+
+			update(t, tag, http.StatusOK)
+			update(t, tag, http.StatusPreconditionFailed)
+			update(t, etag(t, id), http.StatusOK)
+		})
+
+		t.Run("case=should return 404 for non-existing identities", func(t *testing.T) {
+			patch(t, x.NewUUID().String(), "", http.StatusNotFound, `[{"op": "replace", "path": "/state", "value": "inactive"}]`)
+		})
+	})
+
+	t.Run("suite=list", func(t *testing.T) {
+		customer := send(t, "POST", "/identities", http.StatusCreated, json.RawMessage(`{"schema_id": "customer", "traits": {"email": "list-customer@ory.sh", "address": "Berlin"}}`))
+
+		t.Run("case=should filter by credentials identifier", func(t *testing.T) {
+			res := get(t, "/identities?credentials_identifier=list-customer@ory.sh", http.StatusOK)
+			assert.Len(t, res.Array(), 1, "%s", res.Raw)
+			assert.EqualValues(t, customer.Get("id").String(), res.Get("0.id").String(), "%s", res.Raw)
+
+			res = get(t, "/identities?credentials_identifier_prefix=list-cust", http.StatusOK)
+			assert.Len(t, res.Array(), 1, "%s", res.Raw)
+
+			res = get(t, "/identities?credentials_identifier=list-cust", http.StatusOK)
+			assert.Len(t, res.Array(), 0, "%s", res.Raw)
+		})
+
+		t.Run("case=should filter by schema and creation date", func(t *testing.T) {
+			res := get(t, "/identities?schema_id=customer&verified=false&created_after="+url.QueryEscape(time.Now().Add(-time.Minute).Format(time.RFC3339)), http.StatusOK)
+			assert.EqualValues(t, customer.Get("id").String(), res.Get(`#(traits.email=="list-customer@ory.sh").id`).String(), "%s", res.Raw)
+			for _, i := range res.Array() {
+				assert.Equal(t, "customer", i.Get("schema_id").String(), "%s", res.Raw)
+			}
+
+			res = get(t, "/identities?schema_id=customer&created_before="+url.QueryEscape(time.Now().Add(-time.Minute).Format(time.RFC3339)), http.StatusOK)
+			assert.Len(t, res.Array(), 0, "%s", res.Raw)
+		})
+
+		t.Run("case=should reject invalid filters", func(t *testing.T) {
+			for _, query := range []string{
+				"verified=maybe",
+				"created_after=yesterday",
+				"created_before=2021-01-01",
+				"trait=" + url.QueryEscape("email' OR '1'='1"),
+				"page_token=not-a-uuid",
+			} {
+				t.Run("query="+query, func(t *testing.T) {
+					get(t, "/identities?"+query, http.StatusBadRequest)
+				})
+			}
+		})
+
+		t.Run("case=should include the total count without a page token", func(t *testing.T) {
+			all := get(t, "/identities?per_page=500", http.StatusOK).Array()
+			require.Greater(t, len(all), 4)
+
+			res, err := ts.Client().Get(ts.URL + "/identities?per_page=2&page=1")
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.EqualValues(t, http.StatusOK, res.StatusCode)
+
+			assert.Equal(t, strconv.Itoa(len(all)), res.Header.Get("X-Total-Count"))
+			for _, rel := range []string{"first", "next", "prev", "last"} {
+				assert.Contains(t, res.Header.Get("Link"), `rel="`+rel+`"`)
+			}
+			assert.NotContains(t, res.Header.Get("Link"), "page_token")
+		})
+
+		t.Run("case=should paginate using the page token", func(t *testing.T) {
+			all := get(t, "/identities?per_page=500", http.StatusOK).Array()
+			require.Greater(t, len(all), 2)
+
+			ids := []string{all[0].Get("id").String()}
+			next := ts.URL + "/identities?per_page=2&page_token=" + ids[0]
+			for next != "" {
+				res, err := ts.Client().Get(next)
+				require.NoError(t, err)
+				body, err := ioutil.ReadAll(res.Body)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+				require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+				for _, i := range gjson.ParseBytes(body).Array() {
+					ids = append(ids, i.Get("id").String())
+				}
+
+				assert.Empty(t, res.Header.Get("X-Total-Count"))
+				next = ""
+				if link := res.Header.Get("Link"); link != "" {
+					assert.Contains(t, link, `rel="next"`)
+					next = link[strings.Index(link, "<")+1 : strings.Index(link, ">")]
+				}
+			}
+
+			require.Len(t, ids, len(all))
+			for k := range all {
+				assert.Equal(t, all[k].Get("id").String(), ids[k])
+			}
+		})
+
+		t.Run("case=should keep the filters in the pagination links", func(t *testing.T) {
+			for _, query := range []string{"schema_id=customer&page=0&per_page=1", "schema_id=customer&per_page=1"} {
+				res, err := ts.Client().Get(ts.URL + "/identities?" + query)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+				assert.Contains(t, res.Header.Get("Link"), "schema_id=customer", query)
+			}
+		})
+	})
+
 	t.Run("case=should not be able to update an identity that does not exist yet", func(t *testing.T) {
 		res := send(t, "PUT", "/identities/not-found", http.StatusNotFound, json.RawMessage(`{"traits": {"bar":"baz"}}`))
 		assert.Contains(t, res.Get("error.message").String(), "Unable to locate the resource", "%s", res.Raw)
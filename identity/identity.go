@@ -20,6 +20,27 @@ import (
 	"kratos/x"
 )
 
+// State represents whether an identity is allowed to sign in.
+//
+// swagger:model identityState
+type State string
+
+const (
+	// StateActive is the default state of identities.
+	StateActive State = "active"
+	// StateInactive identities can not sign in and their sessions are no longer accepted.
+	StateInactive State = "inactive"
+)
+
+// IsValid reports whether the state is known.
+func (s State) IsValid() bool {
+	switch s {
+	case StateActive, StateInactive:
+		return true
+	}
+	return false
+}
+
 type (
 	// Identity represents an Ory Kratos identity
 	//
@@ -59,6 +80,19 @@ type (
 		// required: true
 		Traits Traits `json:"traits" faker:"-" db:"traits"`
 
+		// State is the identity's state. Inactive identities can not sign in and their sessions are
+		// not accepted.
+		//
+		// required: true
+		State State `json:"state" faker:"-" db:"state"`
+
+		// MetadataPublic contains application data which is visible to the identity, for example in the
+		// session, but can only be changed by administrators.
+		MetadataPublic sqlxx.NullJSONRawMessage `json:"metadata_public" faker:"-" db:"metadata_public"`
+
+		// MetadataAdmin contains application data which is only visible and editable through the admin API.
+		MetadataAdmin sqlxx.NullJSONRawMessage `json:"metadata_admin,omitempty" faker:"-" db:"metadata_admin"`
+
 		// VerifiableAddresses contains all the addresses that can be verified by the user.
 		//
 		// Extensions:
@@ -161,12 +195,25 @@ func (i *Identity) ParseCredentials(t CredentialsType, config interface{}) (*Cre
 	return nil, herodot.ErrNotFound.WithReasonf("identity does not have credential type %s", t)
 }
 
+// IsActive reports whether the identity may sign in. Identities without a state are active.
+func (i *Identity) IsActive() bool {
+	return i.State != StateInactive
+}
+
 func (i *Identity) CopyWithoutCredentials() *Identity {
 	var ii = *i
 	ii.Credentials = nil
 	return &ii
 }
 
+// Declassify returns a copy of the identity which may be shown to the identity itself. Credentials and admin
+// metadata are removed.
+func (i *Identity) Declassify() *Identity {
+	ii := i.CopyWithoutCredentials()
+	ii.MetadataAdmin = nil
+	return ii
+}
+
 func NewIdentity(traitsSchemaID string) *Identity {
 	if traitsSchemaID == "" {
 		traitsSchemaID = config.DefaultIdentityTraitsSchemaID
@@ -177,6 +224,7 @@ func NewIdentity(traitsSchemaID string) *Identity {
 		Credentials:         map[CredentialsType]Credentials{},
 		Traits:              Traits("{}"),
 		SchemaID:            traitsSchemaID,
+		State:               StateActive,
 		VerifiableAddresses: []VerifiableAddress{},
 		l:                   new(sync.RWMutex),
 	}
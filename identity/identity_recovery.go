@@ -11,6 +11,7 @@ import (
 
 const (
 	RecoveryAddressTypeEmail RecoveryAddressType = AddressTypeEmail
+	RecoveryAddressTypePhone RecoveryAddressType = AddressTypePhone
 )
 
 type (
@@ -45,6 +46,8 @@ func (v RecoveryAddressType) HTMLFormInputType() string {
 	switch v {
 	case RecoveryAddressTypeEmail:
 		return "email"
+	case RecoveryAddressTypePhone:
+		return "tel"
 	}
 	return ""
 }
@@ -63,3 +66,14 @@ func NewRecoveryEmailAddress(
 		IdentityID: identity,
 	}
 }
+
+func NewRecoveryPhoneAddress(
+	value string,
+	identity uuid.UUID,
+) *RecoveryAddress {
+	return &RecoveryAddress{
+		Value:      value,
+		Via:        RecoveryAddressTypePhone,
+		IdentityID: identity,
+	}
+}
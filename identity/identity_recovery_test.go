@@ -16,3 +16,12 @@ func TestNewRecoveryEmailAddress(t *testing.T) {
 	assert.Equal(t, a.Via, RecoveryAddressTypeEmail)
 	assert.NotEmpty(t, a.ID)
 }
+
+func TestNewRecoveryPhoneAddress(t *testing.T) {
+	iid := x.NewUUID()
+	a := NewRecoveryPhoneAddress("+12065550100", iid)
+
+	assert.Equal(t, a.Value, "+12065550100")
+	assert.Equal(t, a.Via, RecoveryAddressTypePhone)
+	assert.Equal(t, a.IdentityID, iid)
+}
@@ -13,6 +13,7 @@ import (
 
 const (
 	VerifiableAddressTypeEmail VerifiableAddressType = AddressTypeEmail
+	VerifiableAddressTypePhone VerifiableAddressType = AddressTypePhone
 
 	VerifiableAddressStatusPending   VerifiableAddressStatus = "pending"
 	VerifiableAddressStatusCompleted VerifiableAddressStatus = "completed"
@@ -58,6 +59,8 @@ func (v VerifiableAddressType) HTMLFormInputType() string {
 	switch v {
 	case VerifiableAddressTypeEmail:
 		return "email"
+	case VerifiableAddressTypePhone:
+		return "tel"
 	}
 	return ""
 }
@@ -76,6 +79,16 @@ func NewVerifiableEmailAddress(value string, identity uuid.UUID) *VerifiableAddr
 	}
 }
 
+func NewVerifiablePhoneAddress(value string, identity uuid.UUID) *VerifiableAddress {
+	return &VerifiableAddress{
+		Value:      value,
+		Verified:   false,
+		Status:     VerifiableAddressStatusPending,
+		Via:        VerifiableAddressTypePhone,
+		IdentityID: identity,
+	}
+}
+
 func (a VerifiableAddress) GetID() uuid.UUID {
 	return a.ID
 }
@@ -20,3 +20,14 @@ func TestNewVerifiableEmailAddress(t *testing.T) {
 	assert.EqualValues(t, time.Time{}, a.VerifiedAt)
 	assert.NotEmpty(t, a.ID)
 }
+
+func TestNewVerifiablePhoneAddress(t *testing.T) {
+	iid := x.NewUUID()
+	a := NewVerifiablePhoneAddress("+12065550100", iid)
+
+	assert.Equal(t, a.Value, "+12065550100")
+	assert.Equal(t, a.Via, VerifiableAddressTypePhone)
+	assert.Equal(t, a.Status, VerifiableAddressStatusPending)
+	assert.Equal(t, a.Verified, false)
+	assert.Equal(t, a.IdentityID, iid)
+}
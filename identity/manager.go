@@ -2,7 +2,9 @@ package identity
 
 import (
 	"context"
+	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -19,6 +21,12 @@ import (
 var ErrProtectedFieldModified = herodot.ErrForbidden.
 	WithReasonf(`A field was modified that updates one or more credentials-related settings. This action was blocked because an unprivileged method was used to execute the update. This is either a configuration issue or a bug and should be reported to the system administrator.`)
 
+var ErrIdentityModified = herodot.DefaultError{
+	CodeField:   http.StatusPreconditionFailed,
+	StatusField: http.StatusText(http.StatusPreconditionFailed),
+	ErrorField:  "The identity was modified in the meantime. Fetch the identity again and retry the update.",
+}
+
 type (
 	managerDependencies interface {
 		PoolProvider
@@ -35,6 +43,7 @@ type (
 	managerOptions struct {
 		ExposeValidationErrors    bool
 		AllowWriteProtectedTraits bool
+		IfUnmodified              *time.Time
 	}
 
 	ManagerOption func(*managerOptions)
@@ -52,6 +61,14 @@ func ManagerAllowWriteProtectedTraits(options *managerOptions) {
 	options.AllowWriteProtectedTraits = true
 }
 
+// ManagerIfUnmodified only updates the identity if it was not modified after it was read with the given
+// updatedAt timestamp.
+func ManagerIfUnmodified(updatedAt time.Time) ManagerOption {
+	return func(options *managerOptions) {
+		options.IfUnmodified = &updatedAt
+	}
+}
+
 func newManagerOptions(opts []ManagerOption) *managerOptions {
 	var o managerOptions
 	for _, f := range opts {
@@ -103,6 +120,10 @@ func (m *Manager) Update(ctx context.Context, updated *Identity, opts ...Manager
 		return err
 	}
 
+	if o.IfUnmodified != nil {
+		return m.r.IdentityPool().(PrivilegedPool).UpdateIdentityIfUnmodified(ctx, updated, *o.IfUnmodified)
+	}
+
 	return m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, updated)
 }
 
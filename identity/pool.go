@@ -2,17 +2,27 @@ package identity
 
 import (
 	"context"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
 )
 
 type (
 	Pool interface {
-		// ListIdentities lists all identities in the store given the page and itemsPerPage.
-		ListIdentities(ctx context.Context, page, itemsPerPage int) ([]Identity, error)
+		// ListIdentities lists the identities which match the filters of the parameters. Identities are ordered by
+		// their ID in descending order.
+		ListIdentities(ctx context.Context, params ListIdentityParameters) ([]Identity, error)
 
-		// CountIdentities counts the number of identities in the store.
-		CountIdentities(ctx context.Context) (int64, error)
+		// CountIdentities counts the number of identities in the store which match the filters of the parameters.
+		// Pagination parameters are ignored.
+		CountIdentities(ctx context.Context, params ListIdentityParameters) (int64, error)
 
 		// GetIdentity returns an identity by its id. Will return an error if the identity does not exist or backend
 		// connectivity is broken.
@@ -25,6 +35,41 @@ type (
 		FindRecoveryAddressByValue(ctx context.Context, via RecoveryAddressType, address string) (*RecoveryAddress, error)
 	}
 
+	// ListIdentityParameters filters and paginates the identities returned by Pool.ListIdentities. Filters
+	// which are not set are ignored.
+	ListIdentityParameters struct {
+		// CredentialsIdentifier only returns identities with a credentials identifier equal to this value.
+		CredentialsIdentifier string
+
+		// CredentialsIdentifierPrefix only returns identities with a credentials identifier starting with this value.
+		CredentialsIdentifierPrefix string
+
+		// SchemaID only returns identities using this identity schema.
+		SchemaID string
+
+		// Verified only returns identities with at least one verified address if true, or identities without
+		// any verified address if false.
+		Verified *bool
+
+		// CreatedAfter only returns identities created at or after this time.
+		CreatedAfter time.Time
+
+		// CreatedBefore only returns identities created before this time.
+		CreatedBefore time.Time
+
+		// TraitPath and TraitValue only return identities where the trait at the given path, for example
+		// "address.city", equals the value. Values are compared as strings.
+		TraitPath  string
+		TraitValue string
+
+		// PageToken is the ID of the last identity of the previous page. If set, identities after it are returned
+		// and Page is ignored. Unlike Page, this stays fast for large offsets.
+		PageToken uuid.UUID
+
+		Page    int
+		PerPage int
+	}
+
 	PoolProvider interface {
 		IdentityPool() Pool
 	}
@@ -53,9 +98,22 @@ type (
 		// UpdateIdentity updates an identity including its confidential / privileged / protected data.
 		UpdateIdentity(context.Context, *Identity) error
 
+		// UpdateIdentityIfUnmodified updates an identity like UpdateIdentity but only if the identity stored still
+		// has the given updatedAt timestamp. Returns ErrIdentityModified otherwise.
+		UpdateIdentityIfUnmodified(ctx context.Context, i *Identity, updatedAt time.Time) error
+
 		// GetIdentityConfidential returns the identity including it's raw credentials. This should only be used internally.
 		GetIdentityConfidential(context.Context, uuid.UUID) (*Identity, error)
 
+		// UpdateIdentityState sets the identity's state. Deactivating an identity also revokes all of its sessions.
+		// Returns sql.ErrNoRows if the identity does not exist.
+		UpdateIdentityState(ctx context.Context, id uuid.UUID, state State) error
+
+		// UpdateCredentialsConfig passes the config of the identity's credentials of the given type to update and
+		// stores the returned config. The identity is locked while doing so which ensures that concurrent updates
+		// of the same credentials are applied one after another. Returns sql.ErrNoRows if the credentials do not exist.
+		UpdateCredentialsConfig(ctx context.Context, id uuid.UUID, ct CredentialsType, update func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error)) error
+
 		// ListVerifiableAddresses lists all tracked verifiable addresses, regardless of whether they are already verified
 		// or not.
 		ListVerifiableAddresses(ctx context.Context, page, itemsPerPage int) ([]VerifiableAddress, error)
@@ -64,3 +122,14 @@ type (
 		ListRecoveryAddresses(ctx context.Context, page, itemsPerPage int) ([]RecoveryAddress, error)
 	}
 )
+
+var traitPathPattern = regexp.MustCompile(`^[a-zA-Z0-9_]+(\.[a-zA-Z0-9_]+)*$`)
+
+// TraitPathKeys returns the keys of the trait path. Because the path is embedded in database queries, keys may only
+// contain letters, digits, and underscores.
+func (p *ListIdentityParameters) TraitPathKeys() ([]string, error) {
+	if !traitPathPattern.MatchString(p.TraitPath) {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The trait path %q is invalid. Use keys separated by dots, for example \"address.city\".", p.TraitPath))
+	}
+	return strings.Split(p.TraitPath, "."), nil
+}
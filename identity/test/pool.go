@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -93,13 +94,13 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			assert.Equal(t, nid, i.NID)
 			createdIDs = append(createdIDs, i.ID)
 
-			count, err := p.CountIdentities(ctx)
+			count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 			require.NoError(t, err)
 			assert.EqualValues(t, int64(1), count)
 
 			t.Run("different network", func(t *testing.T) {
 				_, p := testhelpers.NewNetwork(t, ctx, p)
-				count, err := p.CountIdentities(ctx)
+				count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 				require.NoError(t, err)
 				assert.EqualValues(t, int64(0), count)
 			})
@@ -118,7 +119,7 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			assert.Equal(t, defaultSchema.SchemaURL(exampleServerURL).String(), actual.SchemaURL)
 			assertEqual(t, expected, actual)
 
-			count, err := p.CountIdentities(ctx)
+			count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 			require.NoError(t, err)
 			assert.EqualValues(t, 2, count)
 
@@ -127,7 +128,7 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 				_, err := p.GetIdentity(ctx, expected.ID)
 				require.ErrorIs(t, err, sqlcon.ErrNoRows)
 
-				count, err := p.CountIdentities(ctx)
+				count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 				require.NoError(t, err)
 				assert.EqualValues(t, int64(0), count)
 			})
@@ -292,6 +293,74 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			})
 		})
 
+		t.Run("case=update an identity if it was not modified", func(t *testing.T) {
+			initial := passwordIdentity("", x.NewUUID().String())
+			require.NoError(t, p.CreateIdentity(ctx, initial))
+			createdIDs = append(createdIDs, initial.ID)
+
+			first, err := p.GetIdentityConfidential(ctx, initial.ID)
+			require.NoError(t, err)
+			second, err := p.GetIdentityConfidential(ctx, initial.ID)
+			require.NoError(t, err)
+
+			first.Traits = identity.Traits(`{"update":"first"}`)
+			require.NoError(t, p.UpdateIdentityIfUnmodified(ctx, first, second.UpdatedAt))
+
+			second.Traits = identity.Traits(`{"update":"second"}`)
+			require.ErrorIs(t, p.UpdateIdentityIfUnmodified(ctx, second, second.UpdatedAt), identity.ErrIdentityModified)
+
+			actual, err := p.GetIdentityConfidential(ctx, initial.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"update":"first"}`, string(actual.Traits))
+			assert.NotEmpty(t, actual.Credentials[identity.CredentialsTypePassword])
+
+			actual.Traits = identity.Traits(`{"update":"third"}`)
+			require.NoError(t, p.UpdateIdentityIfUnmodified(ctx, actual, actual.UpdatedAt))
+
+			t.Run("fails on different network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, p.UpdateIdentityIfUnmodified(ctx, actual, actual.UpdatedAt), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=update credentials config", func(t *testing.T) {
+			initial := oidcIdentity("", x.NewUUID().String())
+			require.NoError(t, p.CreateIdentity(ctx, initial))
+			createdIDs = append(createdIDs, initial.ID)
+
+			update := func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error) {
+				assert.JSONEq(t, `{}`, string(config))
+				return sqlxx.JSONRawMessage(`{"updated":true}`), nil
+			}
+
+			t.Run("fails on different network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, p.UpdateCredentialsConfig(ctx, initial.ID, identity.CredentialsTypeOIDC, update), sqlcon.ErrNoRows)
+			})
+
+			t.Run("fails for missing credentials", func(t *testing.T) {
+				require.ErrorIs(t, p.UpdateCredentialsConfig(ctx, initial.ID, identity.CredentialsTypePassword, update), sqlcon.ErrNoRows)
+			})
+
+			t.Run("does not store the config if update fails", func(t *testing.T) {
+				expected := errors.New("update failed")
+				require.ErrorIs(t, p.UpdateCredentialsConfig(ctx, initial.ID, identity.CredentialsTypeOIDC, func(sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error) {
+					return sqlxx.JSONRawMessage(`{"updated":true}`), expected
+				}), expected)
+
+				actual, err := p.GetIdentityConfidential(ctx, initial.ID)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{}`, string(actual.Credentials[identity.CredentialsTypeOIDC].Config))
+			})
+
+			require.NoError(t, p.UpdateCredentialsConfig(ctx, initial.ID, identity.CredentialsTypeOIDC, update))
+
+			actual, err := p.GetIdentityConfidential(ctx, initial.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"updated":true}`, string(actual.Credentials[identity.CredentialsTypeOIDC].Config))
+			assert.Equal(t, initial.Credentials[identity.CredentialsTypeOIDC].Identifiers, actual.Credentials[identity.CredentialsTypeOIDC].Identifiers)
+		})
+
 		t.Run("case=fail to update because validation fails", func(t *testing.T) {
 			initial := oidcIdentity("", x.NewUUID().String())
 
@@ -390,7 +459,7 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 		})
 
 		t.Run("case=list", func(t *testing.T) {
-			is, err := p.ListIdentities(ctx, 0, 25)
+			is, err := p.ListIdentities(ctx, identity.ListIdentityParameters{PerPage: 25})
 			require.NoError(t, err)
 			assert.Len(t, is, len(createdIDs))
 			for _, id := range createdIDs {
@@ -405,12 +474,108 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 
 			t.Run("no results on other network", func(t *testing.T) {
 				_, p := testhelpers.NewNetwork(t, ctx, p)
-				is, err := p.ListIdentities(ctx, 0, 25)
+				is, err := p.ListIdentities(ctx, identity.ListIdentityParameters{PerPage: 25})
 				require.NoError(t, err)
 				assert.Len(t, is, 0)
 			})
 		})
 
+		t.Run("case=list with filters", func(t *testing.T) {
+			_, p := testhelpers.NewNetwork(t, ctx, p)
+
+			create := func(t *testing.T, email, schemaID, city string, verified bool, createdAt time.Time) *identity.Identity {
+				i := passwordIdentity(schemaID, email)
+				i.Traits = identity.Traits(fmt.Sprintf(`{"email":%q,"address":{"city":%q}}`, email, city))
+				i.CreatedAt = createdAt
+				address := identity.NewVerifiableEmailAddress(email, i.ID)
+				address.Verified = verified
+				i.VerifiableAddresses = append(i.VerifiableAddresses, *address)
+				require.NoError(t, p.CreateIdentity(ctx, i))
+				return i
+			}
+
+			now := time.Now().UTC().Truncate(time.Second)
+			alice := create(t, "alice@ory.sh", defaultSchema.ID, "Berlin", true, now.Add(-time.Hour*48))
+			bob := create(t, "bob@ory.sh", altSchema.ID, "Munich", false, now.Add(-time.Hour*24))
+			carol := create(t, "carol_1@example.org", defaultSchema.ID, "Berlin", false, now)
+
+			// SQLite only supports JSON functions if built with the json1 tag.
+			supportsJSON := p.GetConnection(ctx).RawQuery(`SELECT json_extract('{}', '$')`).Exec() == nil
+
+			verified, unverified := true, false
+			for k, tc := range []struct {
+				params   identity.ListIdentityParameters
+				expected []*identity.Identity
+			}{
+				{params: identity.ListIdentityParameters{}, expected: []*identity.Identity{alice, bob, carol}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifier: "bob@ory.sh"}, expected: []*identity.Identity{bob}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifier: "bob"}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifierPrefix: "a"}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifierPrefix: "carol_"}, expected: []*identity.Identity{carol}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifierPrefix: "caro%"}},
+				{params: identity.ListIdentityParameters{SchemaID: altSchema.ID}, expected: []*identity.Identity{bob}},
+				{params: identity.ListIdentityParameters{Verified: &verified}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentityParameters{Verified: &unverified}, expected: []*identity.Identity{bob, carol}},
+				{params: identity.ListIdentityParameters{CreatedAfter: now.Add(-time.Hour * 30)}, expected: []*identity.Identity{bob, carol}},
+				{params: identity.ListIdentityParameters{CreatedBefore: now.Add(-time.Hour * 30)}, expected: []*identity.Identity{alice}},
+				{params: identity.ListIdentityParameters{TraitPath: "address.city", TraitValue: "Berlin"}, expected: []*identity.Identity{alice, carol}},
+				{params: identity.ListIdentityParameters{TraitPath: "address.city", TraitValue: "Berlin", Verified: &unverified}, expected: []*identity.Identity{carol}},
+				{params: identity.ListIdentityParameters{TraitPath: "address.zip", TraitValue: "Berlin"}},
+			} {
+				t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+					if tc.params.TraitPath != "" && !supportsJSON {
+						t.Skip("The database does not support JSON functions.")
+					}
+
+					tc.params.PerPage = 100
+					is, err := p.ListIdentities(ctx, tc.params)
+					require.NoError(t, err)
+
+					actual := make([]uuid.UUID, len(is))
+					for k := range is {
+						actual[k] = is[k].ID
+					}
+					expected := make([]uuid.UUID, len(tc.expected))
+					for k := range tc.expected {
+						expected[k] = tc.expected[k].ID
+					}
+					assert.ElementsMatch(t, expected, actual)
+
+					count, err := p.CountIdentities(ctx, tc.params)
+					require.NoError(t, err)
+					assert.EqualValues(t, len(expected), count)
+				})
+			}
+
+			t.Run("case=invalid trait path", func(t *testing.T) {
+				_, err := p.ListIdentities(ctx, identity.ListIdentityParameters{TraitPath: "address') OR 1=1 --"})
+				require.Error(t, err)
+			})
+
+			t.Run("case=paginate using the page token", func(t *testing.T) {
+				all, err := p.ListIdentities(ctx, identity.ListIdentityParameters{PerPage: 100})
+				require.NoError(t, err)
+				require.Len(t, all, 3)
+
+				var actual []identity.Identity
+				params := identity.ListIdentityParameters{PerPage: 2}
+				for {
+					is, err := p.ListIdentities(ctx, params)
+					require.NoError(t, err)
+					actual = append(actual, is...)
+					if len(is) < params.PerPage {
+						break
+					}
+					params.PageToken = is[len(is)-1].ID
+				}
+
+				require.Len(t, actual, len(all))
+				for k := range all {
+					assert.Equal(t, all[k].ID, actual[k].ID)
+				}
+			})
+		})
+
 		t.Run("case=find identity by its credentials identifier", func(t *testing.T) {
 			expected := passwordIdentity("", "find-credentials-identifier@ory.sh")
 			expected.Traits = identity.Traits(`{}`)
@@ -670,8 +835,8 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			require.NoError(t, p.GetConnection(ctx).RawQuery("INSERT INTO identity_credentials (id, identity_id, nid, identity_credential_type_id, created_at, updated_at, config) VALUES (?, ?, ?, ?, ?, ?, '{}')", cid2, iid, nid2, m[0].ID, time.Now(), time.Now()).Exec())
 
 			ici1, ici2 := x.NewUUID(), x.NewUUID()
-			require.NoError(t, p.GetConnection(ctx).RawQuery("INSERT INTO identity_credential_identifiers (id, identity_credential_id, nid, identifier, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)", ici1, cid1, nid1, "nid1", time.Now(), time.Now()).Exec())
-			require.NoError(t, p.GetConnection(ctx).RawQuery("INSERT INTO identity_credential_identifiers (id, identity_credential_id, nid, identifier, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)", ici2, cid2, nid2, "nid2", time.Now(), time.Now()).Exec())
+			require.NoError(t, p.GetConnection(ctx).RawQuery("INSERT INTO identity_credential_identifiers (id, identity_credential_id, nid, identifier, identity_credential_type_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)", ici1, cid1, nid1, "nid1", m[0].ID, time.Now(), time.Now()).Exec())
+			require.NoError(t, p.GetConnection(ctx).RawQuery("INSERT INTO identity_credential_identifiers (id, identity_credential_id, nid, identifier, identity_credential_type_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)", ici2, cid2, nid2, "nid2", m[0].ID, time.Now(), time.Now()).Exec())
 
 			_, err := p.GetIdentity(ctx, nid1)
 			require.ErrorIs(t, err, sqlcon.ErrNoRows)
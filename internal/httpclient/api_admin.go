@@ -28,64 +28,59 @@ var (
 // AdminApiService AdminApi service
 type AdminApiService service
 
-type AdminApiApiCreateIdentityRequest struct {
-	ctx            context.Context
-	ApiService     *AdminApiService
-	createIdentity *CreateIdentity
-}
-
-func (r AdminApiApiCreateIdentityRequest) CreateIdentity(createIdentity CreateIdentity) AdminApiApiCreateIdentityRequest {
-	r.createIdentity = &createIdentity
-	return r
+type AdminApiApiAdminGetSessionRequest struct {
+	ctx        context.Context
+	ApiService *AdminApiService
+	id         string
 }
 
-func (r AdminApiApiCreateIdentityRequest) Execute() (*Identity, *http.Response, error) {
-	return r.ApiService.CreateIdentityExecute(r)
+func (r AdminApiApiAdminGetSessionRequest) Execute() (*Session, *http.Response, error) {
+	return r.ApiService.AdminGetSessionExecute(r)
 }
 
 /*
- * CreateIdentity Create an Identity
- * This endpoint creates an identity. It is NOT possible to set an identity's credentials (password, ...)
-using this method! A way to achieve that will be introduced in the future.
-
-Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+ * AdminGetSession Get a Session
+ * This endpoint returns the session with the given ID, regardless of whether it is active or not.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiCreateIdentityRequest
-*/
-func (a *AdminApiService) CreateIdentity(ctx context.Context) AdminApiApiCreateIdentityRequest {
-	return AdminApiApiCreateIdentityRequest{
+ * @param id ID is the session's ID.
+ * @return AdminApiApiAdminGetSessionRequest
+ */
+func (a *AdminApiService) AdminGetSession(ctx context.Context, id string) AdminApiApiAdminGetSessionRequest {
+	return AdminApiApiAdminGetSessionRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return Identity
+ * @return Session
  */
-func (a *AdminApiService) CreateIdentityExecute(r AdminApiApiCreateIdentityRequest) (*Identity, *http.Response, error) {
+func (a *AdminApiService) AdminGetSessionExecute(r AdminApiApiAdminGetSessionRequest) (*Session, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodPost
+		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Identity
+		localVarReturnValue  *Session
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.CreateIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.AdminGetSession")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/identities"
+	localVarPath := localBasePath + "/sessions/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -101,8 +96,6 @@ func (a *AdminApiService) CreateIdentityExecute(r AdminApiApiCreateIdentityReque
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.createIdentity
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -125,17 +118,7 @@ func (a *AdminApiService) CreateIdentityExecute(r AdminApiApiCreateIdentityReque
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 409 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -169,62 +152,84 @@ func (a *AdminApiService) CreateIdentityExecute(r AdminApiApiCreateIdentityReque
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiCreateRecoveryLinkRequest struct {
-	ctx                context.Context
-	ApiService         *AdminApiService
-	createRecoveryLink *CreateRecoveryLink
+type AdminApiApiAdminListIdentitySessionsRequest struct {
+	ctx        context.Context
+	ApiService *AdminApiService
+	id         string
+	active     *bool
+	perPage    *int64
+	page       *int64
 }
 
-func (r AdminApiApiCreateRecoveryLinkRequest) CreateRecoveryLink(createRecoveryLink CreateRecoveryLink) AdminApiApiCreateRecoveryLinkRequest {
-	r.createRecoveryLink = &createRecoveryLink
+func (r AdminApiApiAdminListIdentitySessionsRequest) Active(active bool) AdminApiApiAdminListIdentitySessionsRequest {
+	r.active = &active
+	return r
+}
+func (r AdminApiApiAdminListIdentitySessionsRequest) PerPage(perPage int64) AdminApiApiAdminListIdentitySessionsRequest {
+	r.perPage = &perPage
+	return r
+}
+func (r AdminApiApiAdminListIdentitySessionsRequest) Page(page int64) AdminApiApiAdminListIdentitySessionsRequest {
+	r.page = &page
 	return r
 }
 
-func (r AdminApiApiCreateRecoveryLinkRequest) Execute() (*RecoveryLink, *http.Response, error) {
-	return r.ApiService.CreateRecoveryLinkExecute(r)
+func (r AdminApiApiAdminListIdentitySessionsRequest) Execute() ([]Session, *http.Response, error) {
+	return r.ApiService.AdminListIdentitySessionsExecute(r)
 }
 
 /*
- * CreateRecoveryLink Create a Recovery Link
- * This endpoint creates a recovery link which should be given to the user in order for them to recover
-(or activate) their account.
+ * AdminListIdentitySessions List All Sessions of an Identity
+ * This endpoint returns all sessions that belong to the given identity, newest first.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiCreateRecoveryLinkRequest
-*/
-func (a *AdminApiService) CreateRecoveryLink(ctx context.Context) AdminApiApiCreateRecoveryLinkRequest {
-	return AdminApiApiCreateRecoveryLinkRequest{
+ * @param id ID is the identity's ID.
+ * @return AdminApiApiAdminListIdentitySessionsRequest
+ */
+func (a *AdminApiService) AdminListIdentitySessions(ctx context.Context, id string) AdminApiApiAdminListIdentitySessionsRequest {
+	return AdminApiApiAdminListIdentitySessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return RecoveryLink
+ * @return []Session
  */
-func (a *AdminApiService) CreateRecoveryLinkExecute(r AdminApiApiCreateRecoveryLinkRequest) (*RecoveryLink, *http.Response, error) {
+func (a *AdminApiService) AdminListIdentitySessionsExecute(r AdminApiApiAdminListIdentitySessionsRequest) ([]Session, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodPost
+		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *RecoveryLink
+		localVarReturnValue  []Session
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.CreateRecoveryLink")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.AdminListIdentitySessions")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/recovery/link"
+	localVarPath := localBasePath + "/identities/{id}/sessions"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.active != nil {
+		localVarQueryParams.Add("active", parameterToString(*r.active, ""))
+	}
+	if r.perPage != nil {
+		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
+	}
+	if r.page != nil {
+		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
+	}
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -240,8 +245,6 @@ func (a *AdminApiService) CreateRecoveryLinkExecute(r AdminApiApiCreateRecoveryL
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.createRecoveryLink
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -308,29 +311,26 @@ func (a *AdminApiService) CreateRecoveryLinkExecute(r AdminApiApiCreateRecoveryL
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiDeleteIdentityRequest struct {
+type AdminApiApiAdminRevokeIdentitySessionsRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
 	id         string
 }
 
-func (r AdminApiApiDeleteIdentityRequest) Execute() (*http.Response, error) {
-	return r.ApiService.DeleteIdentityExecute(r)
+func (r AdminApiApiAdminRevokeIdentitySessionsRequest) Execute() (*http.Response, error) {
+	return r.ApiService.AdminRevokeIdentitySessionsExecute(r)
 }
 
 /*
- * DeleteIdentity Delete an Identity
- * Calling this endpoint irrecoverably and permanently deletes the identity given its ID. This action can not be undone.
-This endpoint returns 204 when the identity was deleted or when the identity was not found, in which case it is
-assumed that is has been deleted already.
-
-Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+ * AdminRevokeIdentitySessions Revoke All Sessions of an Identity
+ * This endpoint marks all active sessions of the given identity as inactive. Use this endpoint to sign
+an identity out of all devices, for example when the account was compromised.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
  * @param id ID is the identity's ID.
- * @return AdminApiApiDeleteIdentityRequest
+ * @return AdminApiApiAdminRevokeIdentitySessionsRequest
 */
-func (a *AdminApiService) DeleteIdentity(ctx context.Context, id string) AdminApiApiDeleteIdentityRequest {
-	return AdminApiApiDeleteIdentityRequest{
+func (a *AdminApiService) AdminRevokeIdentitySessions(ctx context.Context, id string) AdminApiApiAdminRevokeIdentitySessionsRequest {
+	return AdminApiApiAdminRevokeIdentitySessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -340,7 +340,7 @@ func (a *AdminApiService) DeleteIdentity(ctx context.Context, id string) AdminAp
 /*
  * Execute executes the request
  */
-func (a *AdminApiService) DeleteIdentityExecute(r AdminApiApiDeleteIdentityRequest) (*http.Response, error) {
+func (a *AdminApiService) AdminRevokeIdentitySessionsExecute(r AdminApiApiAdminRevokeIdentitySessionsRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
@@ -349,12 +349,12 @@ func (a *AdminApiService) DeleteIdentityExecute(r AdminApiApiDeleteIdentityReque
 		localVarFileBytes    []byte
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.DeleteIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.AdminRevokeIdentitySessions")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/identities/{id}"
+	localVarPath := localBasePath + "/identities/{id}/sessions"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
@@ -425,25 +425,26 @@ func (a *AdminApiService) DeleteIdentityExecute(r AdminApiApiDeleteIdentityReque
 	return localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetIdentityRequest struct {
+type AdminApiApiAdminRevokeSessionRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
 	id         string
 }
 
-func (r AdminApiApiGetIdentityRequest) Execute() (*Identity, *http.Response, error) {
-	return r.ApiService.GetIdentityExecute(r)
+func (r AdminApiApiAdminRevokeSessionRequest) Execute() (*http.Response, error) {
+	return r.ApiService.AdminRevokeSessionExecute(r)
 }
 
 /*
- * GetIdentity Get an Identity
- * Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+ * AdminRevokeSession Revoke a Session
+ * This endpoint marks the session with the given ID as inactive. The session can no longer be used
+to authenticate requests.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @param id ID must be set to the ID of identity you want to get
- * @return AdminApiApiGetIdentityRequest
- */
-func (a *AdminApiService) GetIdentity(ctx context.Context, id string) AdminApiApiGetIdentityRequest {
-	return AdminApiApiGetIdentityRequest{
+ * @param id ID is the session's ID.
+ * @return AdminApiApiAdminRevokeSessionRequest
+*/
+func (a *AdminApiService) AdminRevokeSession(ctx context.Context, id string) AdminApiApiAdminRevokeSessionRequest {
+	return AdminApiApiAdminRevokeSessionRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -452,24 +453,22 @@ func (a *AdminApiService) GetIdentity(ctx context.Context, id string) AdminApiAp
 
 /*
  * Execute executes the request
- * @return Identity
  */
-func (a *AdminApiService) GetIdentityExecute(r AdminApiApiGetIdentityRequest) (*Identity, *http.Response, error) {
+func (a *AdminApiService) AdminRevokeSessionExecute(r AdminApiApiAdminRevokeSessionRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetIdentity")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.AdminRevokeSession")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/identities/{id}"
+	localVarPath := localBasePath + "/sessions/{id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
@@ -495,19 +494,19 @@ func (a *AdminApiService) GetIdentityExecute(r AdminApiApiGetIdentityRequest) (*
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -515,103 +514,91 @@ func (a *AdminApiService) GetIdentityExecute(r AdminApiApiGetIdentityRequest) (*
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+	return localVarHTTPResponse, nil
+}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+type AdminApiApiCreateIdentityRequest struct {
+	ctx            context.Context
+	ApiService     *AdminApiService
+	createIdentity *CreateIdentity
 }
 
-type AdminApiApiGetSchemaRequest struct {
-	ctx        context.Context
-	ApiService *AdminApiService
-	id         string
+func (r AdminApiApiCreateIdentityRequest) CreateIdentity(createIdentity CreateIdentity) AdminApiApiCreateIdentityRequest {
+	r.createIdentity = &createIdentity
+	return r
 }
 
-func (r AdminApiApiGetSchemaRequest) Execute() (map[string]interface{}, *http.Response, error) {
-	return r.ApiService.GetSchemaExecute(r)
+func (r AdminApiApiCreateIdentityRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.CreateIdentityExecute(r)
 }
 
 /*
- * GetSchema Method for GetSchema
- * Get a Traits Schema Definition
+ * CreateIdentity Create an Identity
+ * This endpoint creates an identity. Password and OpenID Connect credentials as well as the verification status
+of addresses can be imported, for example when migrating users from another system. Passwords can be imported
+in clear text or as a hash (bcrypt, Argon2id, PBKDF2, scrypt, Firebase scrypt, salted SHA, or a peppered
+variant of these).
+
+Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @param id ID must be set to the ID of schema you want to get
- * @return AdminApiApiGetSchemaRequest
- */
-func (a *AdminApiService) GetSchema(ctx context.Context, id string) AdminApiApiGetSchemaRequest {
-	return AdminApiApiGetSchemaRequest{
+ * @return AdminApiApiCreateIdentityRequest
+*/
+func (a *AdminApiService) CreateIdentity(ctx context.Context) AdminApiApiCreateIdentityRequest {
+	return AdminApiApiCreateIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return map[string]interface{}
+ * @return Identity
  */
-func (a *AdminApiService) GetSchemaExecute(r AdminApiApiGetSchemaRequest) (map[string]interface{}, *http.Response, error) {
+func (a *AdminApiService) CreateIdentityExecute(r AdminApiApiCreateIdentityRequest) (*Identity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  map[string]interface{}
+		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSchema")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.CreateIdentity")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/schemas/{id}"
-	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+	localVarPath := localBasePath + "/identities"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -627,6 +614,8 @@ func (a *AdminApiService) GetSchemaExecute(r AdminApiApiGetSchemaRequest) (map[s
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.createIdentity
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -649,7 +638,17 @@ func (a *AdminApiService) GetSchemaExecute(r AdminApiApiGetSchemaRequest) (map[s
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -683,35 +682,30 @@ func (a *AdminApiService) GetSchemaExecute(r AdminApiApiGetSchemaRequest) (map[s
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetSelfServiceErrorRequest struct {
-	ctx        context.Context
-	ApiService *AdminApiService
-	error_     *string
+type AdminApiApiCreateRecoveryLinkRequest struct {
+	ctx                context.Context
+	ApiService         *AdminApiService
+	createRecoveryLink *CreateRecoveryLink
 }
 
-func (r AdminApiApiGetSelfServiceErrorRequest) Error_(error_ string) AdminApiApiGetSelfServiceErrorRequest {
-	r.error_ = &error_
+func (r AdminApiApiCreateRecoveryLinkRequest) CreateRecoveryLink(createRecoveryLink CreateRecoveryLink) AdminApiApiCreateRecoveryLinkRequest {
+	r.createRecoveryLink = &createRecoveryLink
 	return r
 }
 
-func (r AdminApiApiGetSelfServiceErrorRequest) Execute() (*ErrorContainer, *http.Response, error) {
-	return r.ApiService.GetSelfServiceErrorExecute(r)
+func (r AdminApiApiCreateRecoveryLinkRequest) Execute() (*RecoveryLink, *http.Response, error) {
+	return r.ApiService.CreateRecoveryLinkExecute(r)
 }
 
 /*
- * GetSelfServiceError Get User-Facing Self-Service Errors
- * This endpoint returns the error associated with a user-facing self service errors.
-
-This endpoint supports stub values to help you implement the error UI:
-
-`?error=stub:500` - returns a stub 500 (Internal Server Error) error.
-
-More information can be found at [Ory Kratos User User Facing Error Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-facing-errors).
+ * CreateRecoveryLink Create a Recovery Link
+ * This endpoint creates a recovery link which should be given to the user in order for them to recover
+(or activate) their account.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetSelfServiceErrorRequest
+ * @return AdminApiApiCreateRecoveryLinkRequest
 */
-func (a *AdminApiService) GetSelfServiceError(ctx context.Context) AdminApiApiGetSelfServiceErrorRequest {
-	return AdminApiApiGetSelfServiceErrorRequest{
+func (a *AdminApiService) CreateRecoveryLink(ctx context.Context) AdminApiApiCreateRecoveryLinkRequest {
+	return AdminApiApiCreateRecoveryLinkRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -719,35 +713,31 @@ func (a *AdminApiService) GetSelfServiceError(ctx context.Context) AdminApiApiGe
 
 /*
  * Execute executes the request
- * @return ErrorContainer
+ * @return RecoveryLink
  */
-func (a *AdminApiService) GetSelfServiceErrorExecute(r AdminApiApiGetSelfServiceErrorRequest) (*ErrorContainer, *http.Response, error) {
+func (a *AdminApiService) CreateRecoveryLinkExecute(r AdminApiApiCreateRecoveryLinkRequest) (*RecoveryLink, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *ErrorContainer
+		localVarReturnValue  *RecoveryLink
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceError")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.CreateRecoveryLink")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/errors"
+	localVarPath := localBasePath + "/recovery/link"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.error_ == nil {
-		return localVarReturnValue, nil, reportError("error_ is required and must be specified")
-	}
 
-	localVarQueryParams.Add("error", parameterToString(*r.error_, ""))
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -763,6 +753,8 @@ func (a *AdminApiService) GetSelfServiceErrorExecute(r AdminApiApiGetSelfService
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.createRecoveryLink
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -785,7 +777,7 @@ func (a *AdminApiService) GetSelfServiceErrorExecute(r AdminApiApiGetSelfService
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 403 {
+		if localVarHTTPResponse.StatusCode == 400 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -829,65 +821,59 @@ func (a *AdminApiService) GetSelfServiceErrorExecute(r AdminApiApiGetSelfService
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetSelfServiceLoginFlowRequest struct {
+type AdminApiApiDeleteIdentityRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
-	id         *string
-}
-
-func (r AdminApiApiGetSelfServiceLoginFlowRequest) Id(id string) AdminApiApiGetSelfServiceLoginFlowRequest {
-	r.id = &id
-	return r
+	id         string
 }
 
-func (r AdminApiApiGetSelfServiceLoginFlowRequest) Execute() (*LoginFlow, *http.Response, error) {
-	return r.ApiService.GetSelfServiceLoginFlowExecute(r)
+func (r AdminApiApiDeleteIdentityRequest) Execute() (*http.Response, error) {
+	return r.ApiService.DeleteIdentityExecute(r)
 }
 
 /*
- * GetSelfServiceLoginFlow Get Login Flow
- * This endpoint returns a login flow's context with, for example, error details and other information.
+ * DeleteIdentity Delete an Identity
+ * Calling this endpoint irrecoverably and permanently deletes the identity given its ID. This action can not be undone.
+This endpoint returns 204 when the identity was deleted or when the identity was not found, in which case it is
+assumed that is has been deleted already.
 
-More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetSelfServiceLoginFlowRequest
+ * @param id ID is the identity's ID.
+ * @return AdminApiApiDeleteIdentityRequest
 */
-func (a *AdminApiService) GetSelfServiceLoginFlow(ctx context.Context) AdminApiApiGetSelfServiceLoginFlowRequest {
-	return AdminApiApiGetSelfServiceLoginFlowRequest{
+func (a *AdminApiService) DeleteIdentity(ctx context.Context, id string) AdminApiApiDeleteIdentityRequest {
+	return AdminApiApiDeleteIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return LoginFlow
  */
-func (a *AdminApiService) GetSelfServiceLoginFlowExecute(r AdminApiApiGetSelfServiceLoginFlowRequest) (*LoginFlow, *http.Response, error) {
+func (a *AdminApiService) DeleteIdentityExecute(r AdminApiApiDeleteIdentityRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodDelete
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *LoginFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceLoginFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.DeleteIdentity")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/login/flows"
+	localVarPath := localBasePath + "/identities/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.id == nil {
-		return localVarReturnValue, nil, reportError("id is required and must be specified")
-	}
 
-	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -907,19 +893,19 @@ func (a *AdminApiService) GetSelfServiceLoginFlowExecute(r AdminApiApiGetSelfSer
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -927,119 +913,82 @@ func (a *AdminApiService) GetSelfServiceLoginFlowExecute(r AdminApiApiGetSelfSer
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 403 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 410 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	return localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetSelfServiceRecoveryFlowRequest struct {
+type AdminApiApiGetIdentityRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
-	id         *string
-}
-
-func (r AdminApiApiGetSelfServiceRecoveryFlowRequest) Id(id string) AdminApiApiGetSelfServiceRecoveryFlowRequest {
-	r.id = &id
-	return r
+	id         string
 }
 
-func (r AdminApiApiGetSelfServiceRecoveryFlowRequest) Execute() (*RecoveryFlow, *http.Response, error) {
-	return r.ApiService.GetSelfServiceRecoveryFlowExecute(r)
+func (r AdminApiApiGetIdentityRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.GetIdentityExecute(r)
 }
 
 /*
- * GetSelfServiceRecoveryFlow Get information about a recovery flow
- * This endpoint returns a recovery flow's context with, for example, error details and other information.
-
-More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+ * GetIdentity Get an Identity
+ * Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetSelfServiceRecoveryFlowRequest
-*/
-func (a *AdminApiService) GetSelfServiceRecoveryFlow(ctx context.Context) AdminApiApiGetSelfServiceRecoveryFlowRequest {
-	return AdminApiApiGetSelfServiceRecoveryFlowRequest{
+ * @param id ID must be set to the ID of identity you want to get
+ * @return AdminApiApiGetIdentityRequest
+ */
+func (a *AdminApiService) GetIdentity(ctx context.Context, id string) AdminApiApiGetIdentityRequest {
+	return AdminApiApiGetIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return RecoveryFlow
+ * @return Identity
  */
-func (a *AdminApiService) GetSelfServiceRecoveryFlowExecute(r AdminApiApiGetSelfServiceRecoveryFlowRequest) (*RecoveryFlow, *http.Response, error) {
+func (a *AdminApiService) GetIdentityExecute(r AdminApiApiGetIdentityRequest) (*Identity, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *RecoveryFlow
+		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceRecoveryFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetIdentity")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/recovery/flows"
+	localVarPath := localBasePath + "/identities/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.id == nil {
-		return localVarReturnValue, nil, reportError("id is required and must be specified")
-	}
 
-	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1079,7 +1028,7 @@ func (a *AdminApiService) GetSelfServiceRecoveryFlowExecute(r AdminApiApiGetSelf
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 404 {
+		if localVarHTTPResponse.StatusCode == 400 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -1089,7 +1038,7 @@ func (a *AdminApiService) GetSelfServiceRecoveryFlowExecute(r AdminApiApiGetSelf
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 410 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -1123,65 +1072,57 @@ func (a *AdminApiService) GetSelfServiceRecoveryFlowExecute(r AdminApiApiGetSelf
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetSelfServiceRegistrationFlowRequest struct {
+type AdminApiApiGetSchemaRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
-	id         *string
-}
-
-func (r AdminApiApiGetSelfServiceRegistrationFlowRequest) Id(id string) AdminApiApiGetSelfServiceRegistrationFlowRequest {
-	r.id = &id
-	return r
+	id         string
 }
 
-func (r AdminApiApiGetSelfServiceRegistrationFlowRequest) Execute() (*RegistrationFlow, *http.Response, error) {
-	return r.ApiService.GetSelfServiceRegistrationFlowExecute(r)
+func (r AdminApiApiGetSchemaRequest) Execute() (map[string]interface{}, *http.Response, error) {
+	return r.ApiService.GetSchemaExecute(r)
 }
 
 /*
- * GetSelfServiceRegistrationFlow Get Registration Flow
- * This endpoint returns a registration flow's context with, for example, error details and other information.
-
-More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+ * GetSchema Method for GetSchema
+ * Get a Traits Schema Definition
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetSelfServiceRegistrationFlowRequest
-*/
-func (a *AdminApiService) GetSelfServiceRegistrationFlow(ctx context.Context) AdminApiApiGetSelfServiceRegistrationFlowRequest {
-	return AdminApiApiGetSelfServiceRegistrationFlowRequest{
+ * @param id ID must be set to the ID of schema you want to get
+ * @return AdminApiApiGetSchemaRequest
+ */
+func (a *AdminApiService) GetSchema(ctx context.Context, id string) AdminApiApiGetSchemaRequest {
+	return AdminApiApiGetSchemaRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return RegistrationFlow
+ * @return map[string]interface{}
  */
-func (a *AdminApiService) GetSelfServiceRegistrationFlowExecute(r AdminApiApiGetSelfServiceRegistrationFlowRequest) (*RegistrationFlow, *http.Response, error) {
+func (a *AdminApiService) GetSchemaExecute(r AdminApiApiGetSchemaRequest) (map[string]interface{}, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *RegistrationFlow
+		localVarReturnValue  map[string]interface{}
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceRegistrationFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSchema")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/registration/flows"
+	localVarPath := localBasePath + "/schemas/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.id == nil {
-		return localVarReturnValue, nil, reportError("id is required and must be specified")
-	}
 
-	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1221,16 +1162,6 @@ func (a *AdminApiService) GetSelfServiceRegistrationFlowExecute(r AdminApiApiGet
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 403 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1241,16 +1172,6 @@ func (a *AdminApiService) GetSelfServiceRegistrationFlowExecute(r AdminApiApiGet
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 410 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1275,69 +1196,69 @@ func (a *AdminApiService) GetSelfServiceRegistrationFlowExecute(r AdminApiApiGet
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetSelfServiceSettingsFlowRequest struct {
+type AdminApiApiGetSelfServiceErrorRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
-	id         *string
+	error_     *string
 }
 
-func (r AdminApiApiGetSelfServiceSettingsFlowRequest) Id(id string) AdminApiApiGetSelfServiceSettingsFlowRequest {
-	r.id = &id
+func (r AdminApiApiGetSelfServiceErrorRequest) Error_(error_ string) AdminApiApiGetSelfServiceErrorRequest {
+	r.error_ = &error_
 	return r
 }
 
-func (r AdminApiApiGetSelfServiceSettingsFlowRequest) Execute() (*SettingsFlow, *http.Response, error) {
-	return r.ApiService.GetSelfServiceSettingsFlowExecute(r)
+func (r AdminApiApiGetSelfServiceErrorRequest) Execute() (*ErrorContainer, *http.Response, error) {
+	return r.ApiService.GetSelfServiceErrorExecute(r)
 }
 
 /*
- * GetSelfServiceSettingsFlow Get Settings Flow
- * When accessing this endpoint through Ory Kratos' Public API you must ensure that either the Ory Kratos Session Cookie
-or the Ory Kratos Session Token are set. The public endpoint does not return 404 status codes
-but instead 403 or 500 to improve data privacy.
+ * GetSelfServiceError Get User-Facing Self-Service Errors
+ * This endpoint returns the error associated with a user-facing self service errors.
 
-You can access this endpoint without credentials when using Ory Kratos' Admin API.
+This endpoint supports stub values to help you implement the error UI:
 
-More information can be found at [Ory Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
+`?error=stub:500` - returns a stub 500 (Internal Server Error) error.
+
+More information can be found at [Ory Kratos User User Facing Error Documentation](https://www.ory.sh/docs/kratos/self-service/flows/user-facing-errors).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetSelfServiceSettingsFlowRequest
+ * @return AdminApiApiGetSelfServiceErrorRequest
 */
-func (a *AdminApiService) GetSelfServiceSettingsFlow(ctx context.Context) AdminApiApiGetSelfServiceSettingsFlowRequest {
-	return AdminApiApiGetSelfServiceSettingsFlowRequest{
-		ApiService: a,
+func (a *AdminApiService) GetSelfServiceError(ctx context.Context) AdminApiApiGetSelfServiceErrorRequest {
+	return AdminApiApiGetSelfServiceErrorRequest{
+		ApiService: a,
 		ctx:        ctx,
 	}
 }
 
 /*
  * Execute executes the request
- * @return SettingsFlow
+ * @return ErrorContainer
  */
-func (a *AdminApiService) GetSelfServiceSettingsFlowExecute(r AdminApiApiGetSelfServiceSettingsFlowRequest) (*SettingsFlow, *http.Response, error) {
+func (a *AdminApiService) GetSelfServiceErrorExecute(r AdminApiApiGetSelfServiceErrorRequest) (*ErrorContainer, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *SettingsFlow
+		localVarReturnValue  *ErrorContainer
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceSettingsFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceError")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/settings/flows"
+	localVarPath := localBasePath + "/self-service/errors"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.id == nil {
-		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	if r.error_ == nil {
+		return localVarReturnValue, nil, reportError("error_ is required and must be specified")
 	}
 
-	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
+	localVarQueryParams.Add("error", parameterToString(*r.error_, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1355,20 +1276,6 @@ func (a *AdminApiService) GetSelfServiceSettingsFlowExecute(r AdminApiApiGetSelf
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	if r.ctx != nil {
-		// API Key Authentication
-		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
-			if apiKey, ok := auth["sessionToken"]; ok {
-				var key string
-				if apiKey.Prefix != "" {
-					key = apiKey.Prefix + " " + apiKey.Key
-				} else {
-					key = apiKey.Key
-				}
-				localVarHeaderParams["X-Session-Token"] = key
-			}
-		}
-	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -1411,16 +1318,6 @@ func (a *AdminApiService) GetSelfServiceSettingsFlowExecute(r AdminApiApiGetSelf
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 410 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1445,31 +1342,31 @@ func (a *AdminApiService) GetSelfServiceSettingsFlowExecute(r AdminApiApiGetSelf
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetSelfServiceVerificationFlowRequest struct {
+type AdminApiApiGetSelfServiceLoginFlowRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
 	id         *string
 }
 
-func (r AdminApiApiGetSelfServiceVerificationFlowRequest) Id(id string) AdminApiApiGetSelfServiceVerificationFlowRequest {
+func (r AdminApiApiGetSelfServiceLoginFlowRequest) Id(id string) AdminApiApiGetSelfServiceLoginFlowRequest {
 	r.id = &id
 	return r
 }
 
-func (r AdminApiApiGetSelfServiceVerificationFlowRequest) Execute() (*VerificationFlow, *http.Response, error) {
-	return r.ApiService.GetSelfServiceVerificationFlowExecute(r)
+func (r AdminApiApiGetSelfServiceLoginFlowRequest) Execute() (*LoginFlow, *http.Response, error) {
+	return r.ApiService.GetSelfServiceLoginFlowExecute(r)
 }
 
 /*
- * GetSelfServiceVerificationFlow Get Verification Flow
- * This endpoint returns a verification flow's context with, for example, error details and other information.
+ * GetSelfServiceLoginFlow Get Login Flow
+ * This endpoint returns a login flow's context with, for example, error details and other information.
 
-More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/selfservice/flows/verify-email-account-activation).
+More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetSelfServiceVerificationFlowRequest
+ * @return AdminApiApiGetSelfServiceLoginFlowRequest
 */
-func (a *AdminApiService) GetSelfServiceVerificationFlow(ctx context.Context) AdminApiApiGetSelfServiceVerificationFlowRequest {
-	return AdminApiApiGetSelfServiceVerificationFlowRequest{
+func (a *AdminApiService) GetSelfServiceLoginFlow(ctx context.Context) AdminApiApiGetSelfServiceLoginFlowRequest {
+	return AdminApiApiGetSelfServiceLoginFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -1477,24 +1374,24 @@ func (a *AdminApiService) GetSelfServiceVerificationFlow(ctx context.Context) Ad
 
 /*
  * Execute executes the request
- * @return VerificationFlow
+ * @return LoginFlow
  */
-func (a *AdminApiService) GetSelfServiceVerificationFlowExecute(r AdminApiApiGetSelfServiceVerificationFlowRequest) (*VerificationFlow, *http.Response, error) {
+func (a *AdminApiService) GetSelfServiceLoginFlowExecute(r AdminApiApiGetSelfServiceLoginFlowRequest) (*LoginFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *VerificationFlow
+		localVarReturnValue  *LoginFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceVerificationFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceLoginFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/verification/flows"
+	localVarPath := localBasePath + "/self-service/login/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -1563,6 +1460,16 @@ func (a *AdminApiService) GetSelfServiceVerificationFlowExecute(r AdminApiApiGet
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1587,29 +1494,31 @@ func (a *AdminApiService) GetSelfServiceVerificationFlowExecute(r AdminApiApiGet
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiGetVersionRequest struct {
+type AdminApiApiGetSelfServiceRecoveryFlowRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
+	id         *string
 }
 
-func (r AdminApiApiGetVersionRequest) Execute() (*InlineResponse2001, *http.Response, error) {
-	return r.ApiService.GetVersionExecute(r)
+func (r AdminApiApiGetSelfServiceRecoveryFlowRequest) Id(id string) AdminApiApiGetSelfServiceRecoveryFlowRequest {
+	r.id = &id
+	return r
 }
 
-/*
- * GetVersion Return Running Software Version.
- * This endpoint returns the version of Ory Kratos.
+func (r AdminApiApiGetSelfServiceRecoveryFlowRequest) Execute() (*RecoveryFlow, *http.Response, error) {
+	return r.ApiService.GetSelfServiceRecoveryFlowExecute(r)
+}
 
-If the service supports TLS Edge Termination, this endpoint does not require the
-`X-Forwarded-Proto` header to be set.
+/*
+ * GetSelfServiceRecoveryFlow Get information about a recovery flow
+ * This endpoint returns a recovery flow's context with, for example, error details and other information.
 
-Be aware that if you are running multiple nodes of this service, the version will never
-refer to the cluster state, only to a single instance.
+More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiGetVersionRequest
+ * @return AdminApiApiGetSelfServiceRecoveryFlowRequest
 */
-func (a *AdminApiService) GetVersion(ctx context.Context) AdminApiApiGetVersionRequest {
-	return AdminApiApiGetVersionRequest{
+func (a *AdminApiService) GetSelfServiceRecoveryFlow(ctx context.Context) AdminApiApiGetSelfServiceRecoveryFlowRequest {
+	return AdminApiApiGetSelfServiceRecoveryFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -1617,29 +1526,33 @@ func (a *AdminApiService) GetVersion(ctx context.Context) AdminApiApiGetVersionR
 
 /*
  * Execute executes the request
- * @return InlineResponse2001
+ * @return RecoveryFlow
  */
-func (a *AdminApiService) GetVersionExecute(r AdminApiApiGetVersionRequest) (*InlineResponse2001, *http.Response, error) {
+func (a *AdminApiService) GetSelfServiceRecoveryFlowExecute(r AdminApiApiGetSelfServiceRecoveryFlowRequest) (*RecoveryFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *InlineResponse2001
+		localVarReturnValue  *RecoveryFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetVersion")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceRecoveryFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/version"
+	localVarPath := localBasePath + "/self-service/recovery/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
 
+	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1679,6 +1592,35 @@ func (a *AdminApiService) GetVersionExecute(r AdminApiApiGetVersionRequest) (*In
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
@@ -1694,30 +1636,31 @@ func (a *AdminApiService) GetVersionExecute(r AdminApiApiGetVersionRequest) (*In
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiIsAliveRequest struct {
+type AdminApiApiGetSelfServiceRegistrationFlowRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
+	id         *string
 }
 
-func (r AdminApiApiIsAliveRequest) Execute() (*InlineResponse200, *http.Response, error) {
-	return r.ApiService.IsAliveExecute(r)
+func (r AdminApiApiGetSelfServiceRegistrationFlowRequest) Id(id string) AdminApiApiGetSelfServiceRegistrationFlowRequest {
+	r.id = &id
+	return r
 }
 
-/*
- * IsAlive Check HTTP Server Status
- * This endpoint returns a HTTP 200 status code when Ory Kratos is accepting incoming
-HTTP requests. This status does currently not include checks whether the database connection is working.
+func (r AdminApiApiGetSelfServiceRegistrationFlowRequest) Execute() (*RegistrationFlow, *http.Response, error) {
+	return r.ApiService.GetSelfServiceRegistrationFlowExecute(r)
+}
 
-If the service supports TLS Edge Termination, this endpoint does not require the
-`X-Forwarded-Proto` header to be set.
+/*
+ * GetSelfServiceRegistrationFlow Get Registration Flow
+ * This endpoint returns a registration flow's context with, for example, error details and other information.
 
-Be aware that if you are running multiple nodes of this service, the health status will never
-refer to the cluster state, only to a single instance.
+More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiIsAliveRequest
+ * @return AdminApiApiGetSelfServiceRegistrationFlowRequest
 */
-func (a *AdminApiService) IsAlive(ctx context.Context) AdminApiApiIsAliveRequest {
-	return AdminApiApiIsAliveRequest{
+func (a *AdminApiService) GetSelfServiceRegistrationFlow(ctx context.Context) AdminApiApiGetSelfServiceRegistrationFlowRequest {
+	return AdminApiApiGetSelfServiceRegistrationFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -1725,29 +1668,33 @@ func (a *AdminApiService) IsAlive(ctx context.Context) AdminApiApiIsAliveRequest
 
 /*
  * Execute executes the request
- * @return InlineResponse200
+ * @return RegistrationFlow
  */
-func (a *AdminApiService) IsAliveExecute(r AdminApiApiIsAliveRequest) (*InlineResponse200, *http.Response, error) {
+func (a *AdminApiService) GetSelfServiceRegistrationFlowExecute(r AdminApiApiGetSelfServiceRegistrationFlowRequest) (*RegistrationFlow, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *InlineResponse200
+		localVarReturnValue  *RegistrationFlow
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.IsAlive")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceRegistrationFlow")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/health/alive"
+	localVarPath := localBasePath + "/self-service/registration/flows"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
 
+	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1787,6 +1734,36 @@ func (a *AdminApiService) IsAliveExecute(r AdminApiApiIsAliveRequest) (*InlineRe
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1811,30 +1788,735 @@ func (a *AdminApiService) IsAliveExecute(r AdminApiApiIsAliveRequest) (*InlineRe
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiIsReadyRequest struct {
+type AdminApiApiGetSelfServiceSettingsFlowRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
+	id         *string
 }
 
-func (r AdminApiApiIsReadyRequest) Execute() (*InlineResponse200, *http.Response, error) {
-	return r.ApiService.IsReadyExecute(r)
+func (r AdminApiApiGetSelfServiceSettingsFlowRequest) Id(id string) AdminApiApiGetSelfServiceSettingsFlowRequest {
+	r.id = &id
+	return r
+}
+
+func (r AdminApiApiGetSelfServiceSettingsFlowRequest) Execute() (*SettingsFlow, *http.Response, error) {
+	return r.ApiService.GetSelfServiceSettingsFlowExecute(r)
 }
 
 /*
- * IsReady Check HTTP Server and Database Status
- * This endpoint returns a HTTP 200 status code when Ory Kratos is up running and the environment dependencies (e.g.
-the database) are responsive as well.
+ * GetSelfServiceSettingsFlow Get Settings Flow
+ * When accessing this endpoint through Ory Kratos' Public API you must ensure that either the Ory Kratos Session Cookie
+or the Ory Kratos Session Token are set. The public endpoint does not return 404 status codes
+but instead 403 or 500 to improve data privacy.
 
-If the service supports TLS Edge Termination, this endpoint does not require the
-`X-Forwarded-Proto` header to be set.
+You can access this endpoint without credentials when using Ory Kratos' Admin API.
 
-Be aware that if you are running multiple nodes of Ory Kratos, the health status will never
-refer to the cluster state, only to a single instance.
+More information can be found at [Ory Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiIsReadyRequest
+ * @return AdminApiApiGetSelfServiceSettingsFlowRequest
 */
-func (a *AdminApiService) IsReady(ctx context.Context) AdminApiApiIsReadyRequest {
-	return AdminApiApiIsReadyRequest{
+func (a *AdminApiService) GetSelfServiceSettingsFlow(ctx context.Context) AdminApiApiGetSelfServiceSettingsFlowRequest {
+	return AdminApiApiGetSelfServiceSettingsFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return SettingsFlow
+ */
+func (a *AdminApiService) GetSelfServiceSettingsFlowExecute(r AdminApiApiGetSelfServiceSettingsFlowRequest) (*SettingsFlow, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *SettingsFlow
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceSettingsFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/settings/flows"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
+
+	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["sessionToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["X-Session-Token"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 410 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type AdminApiApiGetSelfServiceVerificationFlowRequest struct {
+	ctx        context.Context
+	ApiService *AdminApiService
+	id         *string
+}
+
+func (r AdminApiApiGetSelfServiceVerificationFlowRequest) Id(id string) AdminApiApiGetSelfServiceVerificationFlowRequest {
+	r.id = &id
+	return r
+}
+
+func (r AdminApiApiGetSelfServiceVerificationFlowRequest) Execute() (*VerificationFlow, *http.Response, error) {
+	return r.ApiService.GetSelfServiceVerificationFlowExecute(r)
+}
+
+/*
+ * GetSelfServiceVerificationFlow Get Verification Flow
+ * This endpoint returns a verification flow's context with, for example, error details and other information.
+
+More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/selfservice/flows/verify-email-account-activation).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return AdminApiApiGetSelfServiceVerificationFlowRequest
+*/
+func (a *AdminApiService) GetSelfServiceVerificationFlow(ctx context.Context) AdminApiApiGetSelfServiceVerificationFlowRequest {
+	return AdminApiApiGetSelfServiceVerificationFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return VerificationFlow
+ */
+func (a *AdminApiService) GetSelfServiceVerificationFlowExecute(r AdminApiApiGetSelfServiceVerificationFlowRequest) (*VerificationFlow, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *VerificationFlow
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetSelfServiceVerificationFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/verification/flows"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.id == nil {
+		return localVarReturnValue, nil, reportError("id is required and must be specified")
+	}
+
+	localVarQueryParams.Add("id", parameterToString(*r.id, ""))
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type AdminApiApiGetVersionRequest struct {
+	ctx        context.Context
+	ApiService *AdminApiService
+}
+
+func (r AdminApiApiGetVersionRequest) Execute() (*InlineResponse2001, *http.Response, error) {
+	return r.ApiService.GetVersionExecute(r)
+}
+
+/*
+ * GetVersion Return Running Software Version.
+ * This endpoint returns the version of Ory Kratos.
+
+If the service supports TLS Edge Termination, this endpoint does not require the
+`X-Forwarded-Proto` header to be set.
+
+Be aware that if you are running multiple nodes of this service, the version will never
+refer to the cluster state, only to a single instance.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return AdminApiApiGetVersionRequest
+*/
+func (a *AdminApiService) GetVersion(ctx context.Context) AdminApiApiGetVersionRequest {
+	return AdminApiApiGetVersionRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return InlineResponse2001
+ */
+func (a *AdminApiService) GetVersionExecute(r AdminApiApiGetVersionRequest) (*InlineResponse2001, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *InlineResponse2001
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.GetVersion")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/version"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type AdminApiApiIsAliveRequest struct {
+	ctx        context.Context
+	ApiService *AdminApiService
+}
+
+func (r AdminApiApiIsAliveRequest) Execute() (*InlineResponse200, *http.Response, error) {
+	return r.ApiService.IsAliveExecute(r)
+}
+
+/*
+ * IsAlive Check HTTP Server Status
+ * This endpoint returns a HTTP 200 status code when Ory Kratos is accepting incoming
+HTTP requests. This status does currently not include checks whether the database connection is working.
+
+If the service supports TLS Edge Termination, this endpoint does not require the
+`X-Forwarded-Proto` header to be set.
+
+Be aware that if you are running multiple nodes of this service, the health status will never
+refer to the cluster state, only to a single instance.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return AdminApiApiIsAliveRequest
+*/
+func (a *AdminApiService) IsAlive(ctx context.Context) AdminApiApiIsAliveRequest {
+	return AdminApiApiIsAliveRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return InlineResponse200
+ */
+func (a *AdminApiService) IsAliveExecute(r AdminApiApiIsAliveRequest) (*InlineResponse200, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *InlineResponse200
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.IsAlive")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/health/alive"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type AdminApiApiIsReadyRequest struct {
+	ctx        context.Context
+	ApiService *AdminApiService
+}
+
+func (r AdminApiApiIsReadyRequest) Execute() (*InlineResponse200, *http.Response, error) {
+	return r.ApiService.IsReadyExecute(r)
+}
+
+/*
+ * IsReady Check HTTP Server and Database Status
+ * This endpoint returns a HTTP 200 status code when Ory Kratos is up running and the environment dependencies (e.g.
+the database) are responsive as well.
+
+If the service supports TLS Edge Termination, this endpoint does not require the
+`X-Forwarded-Proto` header to be set.
+
+Be aware that if you are running multiple nodes of Ory Kratos, the health status will never
+refer to the cluster state, only to a single instance.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return AdminApiApiIsReadyRequest
+*/
+func (a *AdminApiService) IsReady(ctx context.Context) AdminApiApiIsReadyRequest {
+	return AdminApiApiIsReadyRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return InlineResponse200
+ */
+func (a *AdminApiService) IsReadyExecute(r AdminApiApiIsReadyRequest) (*InlineResponse200, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *InlineResponse200
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.IsReady")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/health/ready"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 503 {
+			var v InlineResponse503
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type AdminApiApiListIdentitiesRequest struct {
+	ctx                         context.Context
+	ApiService                  *AdminApiService
+	perPage                     *int64
+	page                        *int64
+	pageToken                   *string
+	credentialsIdentifier       *string
+	credentialsIdentifierPrefix *string
+	schemaId                    *string
+	verified                    *bool
+	createdAfter                *string
+	createdBefore               *string
+	trait                       *string
+	traitValue                  *string
+}
+
+func (r AdminApiApiListIdentitiesRequest) PerPage(perPage int64) AdminApiApiListIdentitiesRequest {
+	r.perPage = &perPage
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) Page(page int64) AdminApiApiListIdentitiesRequest {
+	r.page = &page
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) PageToken(pageToken string) AdminApiApiListIdentitiesRequest {
+	r.pageToken = &pageToken
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) CredentialsIdentifier(credentialsIdentifier string) AdminApiApiListIdentitiesRequest {
+	r.credentialsIdentifier = &credentialsIdentifier
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) CredentialsIdentifierPrefix(credentialsIdentifierPrefix string) AdminApiApiListIdentitiesRequest {
+	r.credentialsIdentifierPrefix = &credentialsIdentifierPrefix
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) SchemaId(schemaId string) AdminApiApiListIdentitiesRequest {
+	r.schemaId = &schemaId
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) Verified(verified bool) AdminApiApiListIdentitiesRequest {
+	r.verified = &verified
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) CreatedAfter(createdAfter string) AdminApiApiListIdentitiesRequest {
+	r.createdAfter = &createdAfter
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) CreatedBefore(createdBefore string) AdminApiApiListIdentitiesRequest {
+	r.createdBefore = &createdBefore
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) Trait(trait string) AdminApiApiListIdentitiesRequest {
+	r.trait = &trait
+	return r
+}
+func (r AdminApiApiListIdentitiesRequest) TraitValue(traitValue string) AdminApiApiListIdentitiesRequest {
+	r.traitValue = &traitValue
+	return r
+}
+
+func (r AdminApiApiListIdentitiesRequest) Execute() ([]Identity, *http.Response, error) {
+	return r.ApiService.ListIdentitiesExecute(r)
+}
+
+/*
+ * ListIdentities List Identities
+ * Lists identities. The list can be filtered by credentials identifier, identity schema, verification
+status, creation date, and trait value.
+
+Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return AdminApiApiListIdentitiesRequest
+*/
+func (a *AdminApiService) ListIdentities(ctx context.Context) AdminApiApiListIdentitiesRequest {
+	return AdminApiApiListIdentitiesRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -1842,29 +2524,62 @@ func (a *AdminApiService) IsReady(ctx context.Context) AdminApiApiIsReadyRequest
 
 /*
  * Execute executes the request
- * @return InlineResponse200
+ * @return []Identity
  */
-func (a *AdminApiService) IsReadyExecute(r AdminApiApiIsReadyRequest) (*InlineResponse200, *http.Response, error) {
+func (a *AdminApiService) ListIdentitiesExecute(r AdminApiApiListIdentitiesRequest) ([]Identity, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *InlineResponse200
+		localVarReturnValue  []Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.IsReady")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.ListIdentities")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/health/ready"
+	localVarPath := localBasePath + "/identities"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.perPage != nil {
+		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
+	}
+	if r.page != nil {
+		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
+	}
+	if r.pageToken != nil {
+		localVarQueryParams.Add("page_token", parameterToString(*r.pageToken, ""))
+	}
+	if r.credentialsIdentifier != nil {
+		localVarQueryParams.Add("credentials_identifier", parameterToString(*r.credentialsIdentifier, ""))
+	}
+	if r.credentialsIdentifierPrefix != nil {
+		localVarQueryParams.Add("credentials_identifier_prefix", parameterToString(*r.credentialsIdentifierPrefix, ""))
+	}
+	if r.schemaId != nil {
+		localVarQueryParams.Add("schema_id", parameterToString(*r.schemaId, ""))
+	}
+	if r.verified != nil {
+		localVarQueryParams.Add("verified", parameterToString(*r.verified, ""))
+	}
+	if r.createdAfter != nil {
+		localVarQueryParams.Add("created_after", parameterToString(*r.createdAfter, ""))
+	}
+	if r.createdBefore != nil {
+		localVarQueryParams.Add("created_before", parameterToString(*r.createdBefore, ""))
+	}
+	if r.trait != nil {
+		localVarQueryParams.Add("trait", parameterToString(*r.trait, ""))
+	}
+	if r.traitValue != nil {
+		localVarQueryParams.Add("trait_value", parameterToString(*r.traitValue, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1904,8 +2619,18 @@ func (a *AdminApiService) IsReadyExecute(r AdminApiApiIsReadyRequest) (*InlineRe
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 503 {
-			var v InlineResponse503
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
@@ -1928,74 +2653,76 @@ func (a *AdminApiService) IsReadyExecute(r AdminApiApiIsReadyRequest) (*InlineRe
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type AdminApiApiListIdentitiesRequest struct {
+type AdminApiApiPatchIdentityRequest struct {
 	ctx        context.Context
 	ApiService *AdminApiService
-	perPage    *int64
-	page       *int64
+	id         string
+	ifMatch    *string
+	jsonPatch  *[]JsonPatch
 }
 
-func (r AdminApiApiListIdentitiesRequest) PerPage(perPage int64) AdminApiApiListIdentitiesRequest {
-	r.perPage = &perPage
+func (r AdminApiApiPatchIdentityRequest) IfMatch(ifMatch string) AdminApiApiPatchIdentityRequest {
+	r.ifMatch = &ifMatch
 	return r
 }
-func (r AdminApiApiListIdentitiesRequest) Page(page int64) AdminApiApiListIdentitiesRequest {
-	r.page = &page
+func (r AdminApiApiPatchIdentityRequest) JsonPatch(jsonPatch []JsonPatch) AdminApiApiPatchIdentityRequest {
+	r.jsonPatch = &jsonPatch
 	return r
 }
 
-func (r AdminApiApiListIdentitiesRequest) Execute() ([]Identity, *http.Response, error) {
-	return r.ApiService.ListIdentitiesExecute(r)
+func (r AdminApiApiPatchIdentityRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.PatchIdentityExecute(r)
 }
 
 /*
- * ListIdentities List Identities
- * Lists all identities. Does not support search at the moment.
+ * PatchIdentity Patch an Identity
+ * This endpoint applies a JSON Patch (RFC 6902) to the identity's `traits`, `metadata_public`, `metadata_admin`,
+and `state`. The patched identity is validated against its identity schema before it is stored.
+
+The update fails with 412 if the identity was modified in the meantime. If the `If-Match` header is set to the
+identity's ETag, the patch is only applied if the identity was not modified since it was fetched.
 
 Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return AdminApiApiListIdentitiesRequest
+ * @param id ID must be set to the ID of identity you want to update
+ * @return AdminApiApiPatchIdentityRequest
 */
-func (a *AdminApiService) ListIdentities(ctx context.Context) AdminApiApiListIdentitiesRequest {
-	return AdminApiApiListIdentitiesRequest{
+func (a *AdminApiService) PatchIdentity(ctx context.Context, id string) AdminApiApiPatchIdentityRequest {
+	return AdminApiApiPatchIdentityRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 /*
  * Execute executes the request
- * @return []Identity
+ * @return Identity
  */
-func (a *AdminApiService) ListIdentitiesExecute(r AdminApiApiListIdentitiesRequest) ([]Identity, *http.Response, error) {
+func (a *AdminApiService) PatchIdentityExecute(r AdminApiApiPatchIdentityRequest) (*Identity, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodGet
+		localVarHTTPMethod   = http.MethodPatch
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  []Identity
+		localVarReturnValue  *Identity
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.ListIdentities")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.PatchIdentity")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/identities"
+	localVarPath := localBasePath + "/identities/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.perPage != nil {
-		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
-	}
-	if r.page != nil {
-		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
-	}
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json", "application/json-patch+json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -2011,6 +2738,11 @@ func (a *AdminApiService) ListIdentitiesExecute(r AdminApiApiListIdentitiesReque
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		localVarHeaderParams["If-Match"] = parameterToString(*r.ifMatch, "")
+	}
+	// body params
+	localVarPostBody = r.jsonPatch
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -2033,6 +2765,36 @@ func (a *AdminApiService) ListIdentitiesExecute(r AdminApiApiListIdentitiesReque
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -2156,9 +2918,14 @@ type AdminApiApiUpdateIdentityRequest struct {
 	ctx            context.Context
 	ApiService     *AdminApiService
 	id             string
+	ifMatch        *string
 	updateIdentity *UpdateIdentity
 }
 
+func (r AdminApiApiUpdateIdentityRequest) IfMatch(ifMatch string) AdminApiApiUpdateIdentityRequest {
+	r.ifMatch = &ifMatch
+	return r
+}
 func (r AdminApiApiUpdateIdentityRequest) UpdateIdentity(updateIdentity UpdateIdentity) AdminApiApiUpdateIdentityRequest {
 	r.updateIdentity = &updateIdentity
 	return r
@@ -2173,7 +2940,11 @@ func (r AdminApiApiUpdateIdentityRequest) Execute() (*Identity, *http.Response,
  * This endpoint updates an identity. It is NOT possible to set an identity's credentials (password, ...)
 using this method! A way to achieve that will be introduced in the future.
 
-The full identity payload (except credentials) is expected. This endpoint does not support patching.
+The full identity payload (except credentials) is expected. Use the `PATCH` method to update parts of the
+identity instead.
+
+If the `If-Match` header is set to the identity's ETag, the identity is only updated if it was not modified
+in the meantime.
 
 Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
@@ -2231,6 +3002,9 @@ func (a *AdminApiService) UpdateIdentityExecute(r AdminApiApiUpdateIdentityReque
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	if r.ifMatch != nil {
+		localVarHeaderParams["If-Match"] = parameterToString(*r.ifMatch, "")
+	}
 	// body params
 	localVarPostBody = r.updateIdentity
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
@@ -2250,6 +3024,161 @@ func (a *AdminApiService) UpdateIdentityExecute(r AdminApiApiUpdateIdentityReque
 		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 412 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type AdminApiApiUpdateIdentityStateRequest struct {
+	ctx                 context.Context
+	ApiService          *AdminApiService
+	id                  string
+	updateIdentityState *UpdateIdentityState
+}
+
+func (r AdminApiApiUpdateIdentityStateRequest) UpdateIdentityState(updateIdentityState UpdateIdentityState) AdminApiApiUpdateIdentityStateRequest {
+	r.updateIdentityState = &updateIdentityState
+	return r
+}
+
+func (r AdminApiApiUpdateIdentityStateRequest) Execute() (*Identity, *http.Response, error) {
+	return r.ApiService.UpdateIdentityStateExecute(r)
+}
+
+/*
+ * UpdateIdentityState Activate or Deactivate an Identity
+ * This endpoint sets an identity's state. Inactive identities can not sign in and all of their sessions are
+revoked when they are deactivated. Setting the state to "active" allows the identity to sign in again.
+
+Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID must be set to the ID of identity you want to update
+ * @return AdminApiApiUpdateIdentityStateRequest
+*/
+func (a *AdminApiService) UpdateIdentityState(ctx context.Context, id string) AdminApiApiUpdateIdentityStateRequest {
+	return AdminApiApiUpdateIdentityStateRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return Identity
+ */
+func (a *AdminApiService) UpdateIdentityStateExecute(r AdminApiApiUpdateIdentityStateRequest) (*Identity, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPut
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *Identity
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "AdminApiService.UpdateIdentityState")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/identities/{id}/state"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.updateIdentityState
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
 	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
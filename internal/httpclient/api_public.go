@@ -1056,6 +1056,117 @@ func (a *PublicApiService) GetSelfServiceVerificationFlowExecute(r PublicApiApiG
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type PublicApiApiGetSessionJSONWebKeySetRequest struct {
+	ctx        context.Context
+	ApiService *PublicApiService
+}
+
+func (r PublicApiApiGetSessionJSONWebKeySetRequest) Execute() (*JsonWebKeySet, *http.Response, error) {
+	return r.ApiService.GetSessionJSONWebKeySetExecute(r)
+}
+
+/*
+ * GetSessionJSONWebKeySet Get the JSON Web Key Set for Tokenized Sessions
+ * Returns the public keys which are used to sign tokenized sessions. The set is empty if tokenizing
+sessions is disabled.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiGetSessionJSONWebKeySetRequest
+*/
+func (a *PublicApiService) GetSessionJSONWebKeySet(ctx context.Context) PublicApiApiGetSessionJSONWebKeySetRequest {
+	return PublicApiApiGetSessionJSONWebKeySetRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return JsonWebKeySet
+ */
+func (a *PublicApiService) GetSessionJSONWebKeySetExecute(r PublicApiApiGetSessionJSONWebKeySetRequest) (*JsonWebKeySet, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *JsonWebKeySet
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.GetSessionJSONWebKeySet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/.well-known/jwks.json"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type PublicApiApiInitializeSelfServiceBrowserLogoutFlowRequest struct {
 	ctx        context.Context
 	ApiService *PublicApiService
@@ -1167,12 +1278,17 @@ type PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest struct {
 	ctx        context.Context
 	ApiService *PublicApiService
 	refresh    *bool
+	aal        *string
 }
 
 func (r PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest) Refresh(refresh bool) PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest {
 	r.refresh = &refresh
 	return r
 }
+func (r PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest) Aal(aal string) PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest {
+	r.aal = &aal
+	return r
+}
 
 func (r PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest) Execute() (*LoginFlow, *http.Response, error) {
 	return r.ApiService.InitializeSelfServiceLoginViaAPIFlowExecute(r)
@@ -1183,7 +1299,8 @@ func (r PublicApiApiInitializeSelfServiceLoginViaAPIFlowRequest) Execute() (*Log
  * This endpoint initiates a login flow for API clients such as mobile devices, smart TVs, and so on.
 
 If a valid provided session cookie or session token is provided, a 400 Bad Request error
-will be returned unless the URL query parameter `?refresh=true` is set.
+will be returned unless the URL query parameter `?refresh=true` is set, or `?aal=aal2` is set
+and the session has not yet completed a second authentication factor.
 
 To fetch an existing login flow call `/self-service/login/flows?flow=<flow_id>`.
 
@@ -1236,6 +1353,9 @@ func (a *PublicApiService) InitializeSelfServiceLoginViaAPIFlowExecute(r PublicA
 	if r.refresh != nil {
 		localVarQueryParams.Add("refresh", parameterToString(*r.refresh, ""))
 	}
+	if r.aal != nil {
+		localVarQueryParams.Add("aal", parameterToString(*r.aal, ""))
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1323,7 +1443,7 @@ func (r PublicApiApiInitializeSelfServiceLoginViaBrowserFlowRequest) Execute() (
  * This endpoint initializes a browser-based user login flow. Once initialized, the browser will be redirected to
 `selfservice.flows.login.ui_url` with the flow ID set as the query parameter `?flow=`. If a valid user session
 exists already, the browser will be redirected to `urls.default_redirect_url` unless the query parameter
-`?refresh=true` was set.
+`?refresh=true` was set, or `?aal=aal2` was set and the session has not yet completed a second authentication factor.
 
 This endpoint is NOT INTENDED for API clients and only works with browsers (Chrome, Firefox, ...).
 
@@ -2413,32 +2533,45 @@ func (a *PublicApiService) InitializeSelfServiceVerificationViaBrowserFlowExecut
 	return localVarHTTPResponse, nil
 }
 
-type PublicApiApiRevokeSessionRequest struct {
+type PublicApiApiListMySessionsRequest struct {
 	ctx           context.Context
 	ApiService    *PublicApiService
-	revokeSession *RevokeSession
+	cookie        *string
+	authorization *string
+	perPage       *int64
+	page          *int64
 }
 
-func (r PublicApiApiRevokeSessionRequest) RevokeSession(revokeSession RevokeSession) PublicApiApiRevokeSessionRequest {
-	r.revokeSession = &revokeSession
+func (r PublicApiApiListMySessionsRequest) Cookie(cookie string) PublicApiApiListMySessionsRequest {
+	r.cookie = &cookie
+	return r
+}
+func (r PublicApiApiListMySessionsRequest) Authorization(authorization string) PublicApiApiListMySessionsRequest {
+	r.authorization = &authorization
+	return r
+}
+func (r PublicApiApiListMySessionsRequest) PerPage(perPage int64) PublicApiApiListMySessionsRequest {
+	r.perPage = &perPage
+	return r
+}
+func (r PublicApiApiListMySessionsRequest) Page(page int64) PublicApiApiListMySessionsRequest {
+	r.page = &page
 	return r
 }
 
-func (r PublicApiApiRevokeSessionRequest) Execute() (*http.Response, error) {
-	return r.ApiService.RevokeSessionExecute(r)
+func (r PublicApiApiListMySessionsRequest) Execute() ([]Session, *http.Response, error) {
+	return r.ApiService.ListMySessionsExecute(r)
 }
 
 /*
- * RevokeSession Initialize Logout Flow for API Clients - Revoke a Session
- * Use this endpoint to revoke a session using its token. This endpoint is particularly useful for API clients
-such as mobile apps to log the user out of the system and invalidate the session.
-
-This endpoint does not remove any HTTP Cookies - use the Browser-Based Self-Service Logout Flow instead.
+ * ListMySessions List the Other Active Sessions of the Current Identity
+ * This endpoint returns all active sessions of the identity the current session belongs to, except the
+current session itself. Each session lists the devices which used it.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiRevokeSessionRequest
+ * @return PublicApiApiListMySessionsRequest
 */
-func (a *PublicApiService) RevokeSession(ctx context.Context) PublicApiApiRevokeSessionRequest {
-	return PublicApiApiRevokeSessionRequest{
+func (a *PublicApiService) ListMySessions(ctx context.Context) PublicApiApiListMySessionsRequest {
+	return PublicApiApiListMySessionsRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -2446,19 +2579,21 @@ func (a *PublicApiService) RevokeSession(ctx context.Context) PublicApiApiRevoke
 
 /*
  * Execute executes the request
+ * @return []Session
  */
-func (a *PublicApiService) RevokeSessionExecute(r PublicApiApiRevokeSessionRequest) (*http.Response, error) {
+func (a *PublicApiService) ListMySessionsExecute(r PublicApiApiListMySessionsRequest) ([]Session, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodDelete
+		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
+		localVarReturnValue  []Session
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.RevokeSession")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.ListMySessions")
 	if err != nil {
-		return nil, &GenericOpenAPIError{error: err.Error()}
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
 	localVarPath := localBasePath + "/sessions"
@@ -2466,12 +2601,15 @@ func (a *PublicApiService) RevokeSessionExecute(r PublicApiApiRevokeSessionReque
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.revokeSession == nil {
-		return nil, reportError("revokeSession is required and must be specified")
-	}
 
+	if r.perPage != nil {
+		localVarQueryParams.Add("per_page", parameterToString(*r.perPage, ""))
+	}
+	if r.page != nil {
+		localVarQueryParams.Add("page", parameterToString(*r.page, ""))
+	}
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -2487,23 +2625,41 @@ func (a *PublicApiService) RevokeSessionExecute(r PublicApiApiRevokeSessionReque
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.revokeSession
+	if r.cookie != nil {
+		localVarHeaderParams["Cookie"] = parameterToString(*r.cookie, "")
+	}
+	if r.authorization != nil {
+		localVarHeaderParams["Authorization"] = parameterToString(*r.authorization, "")
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["sessionToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["X-Session-Token"] = key
+			}
+		}
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return nil, err
+		return localVarReturnValue, nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -2511,71 +2667,72 @@ func (a *PublicApiService) RevokeSessionExecute(r PublicApiApiRevokeSessionReque
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
+		if localVarHTTPResponse.StatusCode == 401 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
+				return localVarReturnValue, localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarHTTPResponse, newErr
+			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
+				return localVarReturnValue, localVarHTTPResponse, newErr
 			}
 			newErr.model = v
 		}
-		return localVarHTTPResponse, newErr
+		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarHTTPResponse, nil
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type PublicApiApiSubmitSelfServiceLoginFlowRequest struct {
-	ctx                        context.Context
-	ApiService                 *PublicApiService
-	flow                       *string
-	submitSelfServiceLoginFlow *SubmitSelfServiceLoginFlow
+type PublicApiApiRefreshSessionRequest struct {
+	ctx           context.Context
+	ApiService    *PublicApiService
+	cookie        *string
+	authorization *string
 }
 
-func (r PublicApiApiSubmitSelfServiceLoginFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceLoginFlowRequest {
-	r.flow = &flow
+func (r PublicApiApiRefreshSessionRequest) Cookie(cookie string) PublicApiApiRefreshSessionRequest {
+	r.cookie = &cookie
 	return r
 }
-func (r PublicApiApiSubmitSelfServiceLoginFlowRequest) SubmitSelfServiceLoginFlow(submitSelfServiceLoginFlow SubmitSelfServiceLoginFlow) PublicApiApiSubmitSelfServiceLoginFlowRequest {
-	r.submitSelfServiceLoginFlow = &submitSelfServiceLoginFlow
+func (r PublicApiApiRefreshSessionRequest) Authorization(authorization string) PublicApiApiRefreshSessionRequest {
+	r.authorization = &authorization
 	return r
 }
 
-func (r PublicApiApiSubmitSelfServiceLoginFlowRequest) Execute() (*LoginViaApiResponse, *http.Response, error) {
-	return r.ApiService.SubmitSelfServiceLoginFlowExecute(r)
+func (r PublicApiApiRefreshSessionRequest) Execute() (*Session, *http.Response, error) {
+	return r.ApiService.RefreshSessionExecute(r)
 }
 
 /*
- * SubmitSelfServiceLoginFlow Submit a Login Flow
- * Use this endpoint to complete a login flow. This endpoint
-behaves differently for API and browser flows.
+ * RefreshSession Refresh the Current Session
+ * Extends the session which is sent in the request so that it expires one session lifespan from now, and returns
+the session. This endpoint is particularly useful for API clients such as mobile apps which want to keep
+their session token alive. If the session is sent as a cookie, the cookie is re-issued.
 
-API flows expect `application/json` to be sent in the body and responds with
-HTTP 200 and a application/json body with the session token on success;
-HTTP 302 redirect to a fresh login flow if the original flow expired with the appropriate error messages set;
-HTTP 400 on form validation errors.
-
-Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
-a HTTP 302 redirect to the post/after login URL or the `return_to` value if it was set and if the login succeeded;
-a HTTP 302 redirect to the login UI URL with the flow ID containing the validation errors otherwise.
-
-More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+This endpoint returns 403 if `session.sliding_expiry` is not enabled.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiSubmitSelfServiceLoginFlowRequest
+ * @return PublicApiApiRefreshSessionRequest
 */
-func (a *PublicApiService) SubmitSelfServiceLoginFlow(ctx context.Context) PublicApiApiSubmitSelfServiceLoginFlowRequest {
-	return PublicApiApiSubmitSelfServiceLoginFlowRequest{
+func (a *PublicApiService) RefreshSession(ctx context.Context) PublicApiApiRefreshSessionRequest {
+	return PublicApiApiRefreshSessionRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -2583,35 +2740,31 @@ func (a *PublicApiService) SubmitSelfServiceLoginFlow(ctx context.Context) Publi
 
 /*
  * Execute executes the request
- * @return LoginViaApiResponse
+ * @return Session
  */
-func (a *PublicApiService) SubmitSelfServiceLoginFlowExecute(r PublicApiApiSubmitSelfServiceLoginFlowRequest) (*LoginViaApiResponse, *http.Response, error) {
+func (a *PublicApiService) RefreshSessionExecute(r PublicApiApiRefreshSessionRequest) (*Session, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *LoginViaApiResponse
+		localVarReturnValue  *Session
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceLoginFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.RefreshSession")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/login"
+	localVarPath := localBasePath + "/sessions/refresh"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.flow == nil {
-		return localVarReturnValue, nil, reportError("flow is required and must be specified")
-	}
 
-	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -2627,8 +2780,1186 @@ func (a *PublicApiService) SubmitSelfServiceLoginFlowExecute(r PublicApiApiSubmi
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.submitSelfServiceLoginFlow
+	if r.cookie != nil {
+		localVarHeaderParams["Cookie"] = parameterToString(*r.cookie, "")
+	}
+	if r.authorization != nil {
+		localVarHeaderParams["Authorization"] = parameterToString(*r.authorization, "")
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["sessionToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["X-Session-Token"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type PublicApiApiRevokeMyOtherSessionsRequest struct {
+	ctx           context.Context
+	ApiService    *PublicApiService
+	cookie        *string
+	authorization *string
+}
+
+func (r PublicApiApiRevokeMyOtherSessionsRequest) Cookie(cookie string) PublicApiApiRevokeMyOtherSessionsRequest {
+	r.cookie = &cookie
+	return r
+}
+func (r PublicApiApiRevokeMyOtherSessionsRequest) Authorization(authorization string) PublicApiApiRevokeMyOtherSessionsRequest {
+	r.authorization = &authorization
+	return r
+}
+
+func (r PublicApiApiRevokeMyOtherSessionsRequest) Execute() (*http.Response, error) {
+	return r.ApiService.RevokeMyOtherSessionsExecute(r)
+}
+
+/*
+ * RevokeMyOtherSessions Revoke All Other Sessions of the Current Identity
+ * This endpoint signs the identity the current session belongs to out of all other devices. The
+current session stays active.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiRevokeMyOtherSessionsRequest
+*/
+func (a *PublicApiService) RevokeMyOtherSessions(ctx context.Context) PublicApiApiRevokeMyOtherSessionsRequest {
+	return PublicApiApiRevokeMyOtherSessionsRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *PublicApiService) RevokeMyOtherSessionsExecute(r PublicApiApiRevokeMyOtherSessionsRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.RevokeMyOtherSessions")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/sessions/others"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.cookie != nil {
+		localVarHeaderParams["Cookie"] = parameterToString(*r.cookie, "")
+	}
+	if r.authorization != nil {
+		localVarHeaderParams["Authorization"] = parameterToString(*r.authorization, "")
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["sessionToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["X-Session-Token"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type PublicApiApiRevokeMySessionRequest struct {
+	ctx           context.Context
+	ApiService    *PublicApiService
+	id            string
+	cookie        *string
+	authorization *string
+}
+
+func (r PublicApiApiRevokeMySessionRequest) Cookie(cookie string) PublicApiApiRevokeMySessionRequest {
+	r.cookie = &cookie
+	return r
+}
+func (r PublicApiApiRevokeMySessionRequest) Authorization(authorization string) PublicApiApiRevokeMySessionRequest {
+	r.authorization = &authorization
+	return r
+}
+
+func (r PublicApiApiRevokeMySessionRequest) Execute() (*http.Response, error) {
+	return r.ApiService.RevokeMySessionExecute(r)
+}
+
+/*
+ * RevokeMySession Revoke Another Session of the Current Identity
+ * This endpoint revokes a session which belongs to the same identity as the current session. The current
+session can not be revoked using this endpoint, use the logout flow instead.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @param id ID is the ID of the session to revoke.
+ * @return PublicApiApiRevokeMySessionRequest
+*/
+func (a *PublicApiService) RevokeMySession(ctx context.Context, id string) PublicApiApiRevokeMySessionRequest {
+	return PublicApiApiRevokeMySessionRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *PublicApiService) RevokeMySessionExecute(r PublicApiApiRevokeMySessionRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.RevokeMySession")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/sessions/others/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterToString(r.id, "")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.cookie != nil {
+		localVarHeaderParams["Cookie"] = parameterToString(*r.cookie, "")
+	}
+	if r.authorization != nil {
+		localVarHeaderParams["Authorization"] = parameterToString(*r.authorization, "")
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["sessionToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["X-Session-Token"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type PublicApiApiRevokeSessionRequest struct {
+	ctx           context.Context
+	ApiService    *PublicApiService
+	revokeSession *RevokeSession
+}
+
+func (r PublicApiApiRevokeSessionRequest) RevokeSession(revokeSession RevokeSession) PublicApiApiRevokeSessionRequest {
+	r.revokeSession = &revokeSession
+	return r
+}
+
+func (r PublicApiApiRevokeSessionRequest) Execute() (*http.Response, error) {
+	return r.ApiService.RevokeSessionExecute(r)
+}
+
+/*
+ * RevokeSession Initialize Logout Flow for API Clients - Revoke a Session
+ * Use this endpoint to revoke a session using its token. This endpoint is particularly useful for API clients
+such as mobile apps to log the user out of the system and invalidate the session.
+
+This endpoint does not remove any HTTP Cookies - use the Browser-Based Self-Service Logout Flow instead.
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiRevokeSessionRequest
+*/
+func (a *PublicApiService) RevokeSession(ctx context.Context) PublicApiApiRevokeSessionRequest {
+	return PublicApiApiRevokeSessionRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *PublicApiService) RevokeSessionExecute(r PublicApiApiRevokeSessionRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodDelete
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.RevokeSession")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/sessions"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.revokeSession == nil {
+		return nil, reportError("revokeSession is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.revokeSession
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type PublicApiApiSubmitSelfServiceLoginFlowRequest struct {
+	ctx                        context.Context
+	ApiService                 *PublicApiService
+	flow                       *string
+	submitSelfServiceLoginFlow *SubmitSelfServiceLoginFlow
+}
+
+func (r PublicApiApiSubmitSelfServiceLoginFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceLoginFlowRequest {
+	r.flow = &flow
+	return r
+}
+func (r PublicApiApiSubmitSelfServiceLoginFlowRequest) SubmitSelfServiceLoginFlow(submitSelfServiceLoginFlow SubmitSelfServiceLoginFlow) PublicApiApiSubmitSelfServiceLoginFlowRequest {
+	r.submitSelfServiceLoginFlow = &submitSelfServiceLoginFlow
+	return r
+}
+
+func (r PublicApiApiSubmitSelfServiceLoginFlowRequest) Execute() (*LoginViaApiResponse, *http.Response, error) {
+	return r.ApiService.SubmitSelfServiceLoginFlowExecute(r)
+}
+
+/*
+ * SubmitSelfServiceLoginFlow Submit a Login Flow
+ * Use this endpoint to complete a login flow. This endpoint
+behaves differently for API and browser flows.
+
+API flows expect `application/json` to be sent in the body and responds with
+HTTP 200 and a application/json body with the session token on success;
+HTTP 302 redirect to a fresh login flow if the original flow expired with the appropriate error messages set;
+HTTP 400 on form validation errors.
+
+Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
+a HTTP 302 redirect to the post/after login URL or the `return_to` value if it was set and if the login succeeded;
+a HTTP 302 redirect to the login UI URL with the flow ID containing the validation errors otherwise.
+
+More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiSubmitSelfServiceLoginFlowRequest
+*/
+func (a *PublicApiService) SubmitSelfServiceLoginFlow(ctx context.Context) PublicApiApiSubmitSelfServiceLoginFlowRequest {
+	return PublicApiApiSubmitSelfServiceLoginFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return LoginViaApiResponse
+ */
+func (a *PublicApiService) SubmitSelfServiceLoginFlowExecute(r PublicApiApiSubmitSelfServiceLoginFlowRequest) (*LoginViaApiResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *LoginViaApiResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceLoginFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/login"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.flow == nil {
+		return localVarReturnValue, nil, reportError("flow is required and must be specified")
+	}
+
+	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.submitSelfServiceLoginFlow
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v LoginFlow
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type PublicApiApiSubmitSelfServiceRecoveryFlowRequest struct {
+	ctx        context.Context
+	ApiService *PublicApiService
+	flow       *string
+	body       *map[string]interface{}
+}
+
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceRecoveryFlowRequest {
+	r.flow = &flow
+	return r
+}
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) Body(body map[string]interface{}) PublicApiApiSubmitSelfServiceRecoveryFlowRequest {
+	r.body = &body
+	return r
+}
+
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) Execute() (*http.Response, error) {
+	return r.ApiService.SubmitSelfServiceRecoveryFlowExecute(r)
+}
+
+/*
+ * SubmitSelfServiceRecoveryFlow Complete Recovery Flow
+ * Use this endpoint to complete a recovery flow. This endpoint
+behaves differently for API and browser flows and has several states:
+
+`choose_method` expects `flow` (in the URL query) and `email` (in the body) to be sent
+and works with API- and Browser-initiated flows.
+For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
+and a HTTP 302 Found redirect with a fresh recovery flow if the flow was otherwise invalid (e.g. expired).
+For Browser clients it returns a HTTP 302 Found redirect to the Recovery UI URL with the Recovery Flow ID appended.
+`sent_email` is the success state after `choose_method` for the `link` method and allows the user to request another recovery email. It
+works for both API and Browser-initiated flows and returns the same responses as the flow in `choose_method` state.
+`passed_challenge` expects a `token` to be sent in the URL query and given the nature of the flow ("sending a recovery link")
+does not have any API capabilities. The server responds with a HTTP 302 Found redirect either to the Settings UI URL
+(if the link was valid) and instructs the user to update their password, or a redirect to the Recover UI URL with
+a new Recovery Flow ID which contains an error message that the recovery link was invalid.
+
+More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiSubmitSelfServiceRecoveryFlowRequest
+*/
+func (a *PublicApiService) SubmitSelfServiceRecoveryFlow(ctx context.Context) PublicApiApiSubmitSelfServiceRecoveryFlowRequest {
+	return PublicApiApiSubmitSelfServiceRecoveryFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *PublicApiService) SubmitSelfServiceRecoveryFlowExecute(r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRecoveryFlow")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/recovery"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.flow == nil {
+		return nil, reportError("flow is required and must be specified")
+	}
+
+	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.body
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v RecoveryFlow
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type PublicApiApiSubmitSelfServiceRecoveryFlowWithCodeMethodRequest struct {
+	ctx        context.Context
+	ApiService *PublicApiService
+}
+
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithCodeMethodRequest) Execute() (*RecoveryViaApiResponse, *http.Response, error) {
+	return r.ApiService.SubmitSelfServiceRecoveryFlowWithCodeMethodExecute(r)
+}
+
+/*
+ * SubmitSelfServiceRecoveryFlowWithCodeMethod Complete Recovery Flow with Code Method
+ * Use this endpoint to complete a recovery flow using the code method. This endpoint
+behaves differently for API and browser flows and has several states:
+
+`choose_method` expects `flow` (in the URL query) and `email` or `phone` (in the body) to be sent
+and works with API- and Browser-initiated flows. A short numeric code is sent to the address.
+For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
+and a HTTP 302 Found redirect with a fresh recovery flow if the flow was otherwise invalid (e.g. expired).
+For Browser clients it returns a HTTP 302 Found redirect to the Recovery UI URL with the Recovery Flow ID appended.
+`sent_email` expects the `code` to be sent in the body. If the code is valid, API clients receive a session
+token and Browser clients are redirected to the Settings UI URL and instructed to update their password. If the
+code is invalid, HTTP 400 is returned. Once the code expired or was entered incorrectly too many times, a new
+recovery flow is initiated. Submitting the form without a code sends a new code.
+
+More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiSubmitSelfServiceRecoveryFlowWithCodeMethodRequest
+*/
+func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithCodeMethod(ctx context.Context) PublicApiApiSubmitSelfServiceRecoveryFlowWithCodeMethodRequest {
+	return PublicApiApiSubmitSelfServiceRecoveryFlowWithCodeMethodRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return RecoveryViaApiResponse
+ */
+func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithCodeMethodExecute(r PublicApiApiSubmitSelfServiceRecoveryFlowWithCodeMethodRequest) (*RecoveryViaApiResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *RecoveryViaApiResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRecoveryFlowWithCodeMethod")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/recovery/methods/code"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v RecoveryFlow
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest struct {
+	ctx                                         context.Context
+	ApiService                                  *PublicApiService
+	token                                       *string
+	flow                                        *string
+	submitSelfServiceRecoveryFlowWithLinkMethod *SubmitSelfServiceRecoveryFlowWithLinkMethod
+}
+
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) Token(token string) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
+	r.token = &token
+	return r
+}
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) Flow(flow string) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
+	r.flow = &flow
+	return r
+}
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) SubmitSelfServiceRecoveryFlowWithLinkMethod(submitSelfServiceRecoveryFlowWithLinkMethod SubmitSelfServiceRecoveryFlowWithLinkMethod) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
+	r.submitSelfServiceRecoveryFlowWithLinkMethod = &submitSelfServiceRecoveryFlowWithLinkMethod
+	return r
+}
+
+func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) Execute() (*http.Response, error) {
+	return r.ApiService.SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r)
+}
+
+/*
+ * SubmitSelfServiceRecoveryFlowWithLinkMethod Complete Recovery Flow with Link Method
+ * Use this endpoint to complete a recovery flow using the link method. This endpoint
+behaves differently for API and browser flows and has several states:
+
+`choose_method` expects `flow` (in the URL query) and `email` (in the body) to be sent
+and works with API- and Browser-initiated flows.
+For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
+and a HTTP 302 Found redirect with a fresh recovery flow if the flow was otherwise invalid (e.g. expired).
+For Browser clients it returns a HTTP 302 Found redirect to the Recovery UI URL with the Recovery Flow ID appended.
+`sent_email` is the success state after `choose_method` and allows the user to request another recovery email. It
+works for both API and Browser-initiated flows and returns the same responses as the flow in `choose_method` state.
+`passed_challenge` expects a `token` to be sent in the URL query and given the nature of the flow ("sending a recovery link")
+does not have any API capabilities. The server responds with a HTTP 302 Found redirect either to the Settings UI URL
+(if the link was valid) and instructs the user to update their password, or a redirect to the Recover UI URL with
+a new Recovery Flow ID which contains an error message that the recovery link was invalid.
+
+More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest
+*/
+func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethod(ctx context.Context) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
+	return PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ */
+func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRecoveryFlowWithLinkMethod")
+	if err != nil {
+		return nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/recovery/methods/link"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.token != nil {
+		localVarQueryParams.Add("token", parameterToString(*r.token, ""))
+	}
+	if r.flow != nil {
+		localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.submitSelfServiceRecoveryFlowWithLinkMethod
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v RecoveryFlow
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+		}
+		return localVarHTTPResponse, newErr
+	}
+
+	return localVarHTTPResponse, nil
+}
+
+type PublicApiApiSubmitSelfServiceRegistrationFlowRequest struct {
+	ctx                               context.Context
+	ApiService                        *PublicApiService
+	flow                              *string
+	submitSelfServiceRegistrationFlow *SubmitSelfServiceRegistrationFlow
+}
+
+func (r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceRegistrationFlowRequest {
+	r.flow = &flow
+	return r
+}
+func (r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) SubmitSelfServiceRegistrationFlow(submitSelfServiceRegistrationFlow SubmitSelfServiceRegistrationFlow) PublicApiApiSubmitSelfServiceRegistrationFlowRequest {
+	r.submitSelfServiceRegistrationFlow = &submitSelfServiceRegistrationFlow
+	return r
+}
+
+func (r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) Execute() (*RegistrationViaApiResponse, *http.Response, error) {
+	return r.ApiService.SubmitSelfServiceRegistrationFlowExecute(r)
+}
+
+/*
+ * SubmitSelfServiceRegistrationFlow Submit a Registration Flow
+ * Use this endpoint to complete a registration flow by sending an identity's traits and password. This endpoint
+behaves differently for API and browser flows.
+
+API flows expect `application/json` to be sent in the body and respond with
+HTTP 200 and a application/json body with the created identity success - if the session hook is configured the
+`session` and `session_token` will also be included;
+HTTP 302 redirect to a fresh registration flow if the original flow expired with the appropriate error messages set;
+HTTP 400 on form validation errors.
+
+Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
+a HTTP 302 redirect to the post/after registration URL or the `return_to` value if it was set and if the registration succeeded;
+a HTTP 302 redirect to the registration UI URL with the flow ID containing the validation errors otherwise.
+
+More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+ * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ * @return PublicApiApiSubmitSelfServiceRegistrationFlowRequest
+*/
+func (a *PublicApiService) SubmitSelfServiceRegistrationFlow(ctx context.Context) PublicApiApiSubmitSelfServiceRegistrationFlowRequest {
+	return PublicApiApiSubmitSelfServiceRegistrationFlowRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+/*
+ * Execute executes the request
+ * @return RegistrationViaApiResponse
+ */
+func (a *PublicApiService) SubmitSelfServiceRegistrationFlowExecute(r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) (*RegistrationViaApiResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		localVarFormFileName string
+		localVarFileName     string
+		localVarFileBytes    []byte
+		localVarReturnValue  *RegistrationViaApiResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRegistrationFlow")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/self-service/registration"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.flow == nil {
+		return localVarReturnValue, nil, reportError("flow is required and must be specified")
+	}
+
+	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.submitSelfServiceRegistrationFlow
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -2652,7 +3983,7 @@ func (a *PublicApiService) SubmitSelfServiceLoginFlowExecute(r PublicApiApiSubmi
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 400 {
-			var v LoginFlow
+			var v RegistrationFlow
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
@@ -2685,49 +4016,50 @@ func (a *PublicApiService) SubmitSelfServiceLoginFlowExecute(r PublicApiApiSubmi
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type PublicApiApiSubmitSelfServiceRecoveryFlowRequest struct {
-	ctx        context.Context
-	ApiService *PublicApiService
-	flow       *string
-	body       *map[string]interface{}
+type PublicApiApiSubmitSelfServiceSettingsFlowRequest struct {
+	ctx                           context.Context
+	ApiService                    *PublicApiService
+	flow                          *string
+	submitSelfServiceSettingsFlow *SubmitSelfServiceSettingsFlow
 }
 
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceRecoveryFlowRequest {
+func (r PublicApiApiSubmitSelfServiceSettingsFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceSettingsFlowRequest {
 	r.flow = &flow
 	return r
 }
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) Body(body map[string]interface{}) PublicApiApiSubmitSelfServiceRecoveryFlowRequest {
-	r.body = &body
+func (r PublicApiApiSubmitSelfServiceSettingsFlowRequest) SubmitSelfServiceSettingsFlow(submitSelfServiceSettingsFlow SubmitSelfServiceSettingsFlow) PublicApiApiSubmitSelfServiceSettingsFlowRequest {
+	r.submitSelfServiceSettingsFlow = &submitSelfServiceSettingsFlow
 	return r
 }
 
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) Execute() (*http.Response, error) {
-	return r.ApiService.SubmitSelfServiceRecoveryFlowExecute(r)
+func (r PublicApiApiSubmitSelfServiceSettingsFlowRequest) Execute() (*SettingsViaApiResponse, *http.Response, error) {
+	return r.ApiService.SubmitSelfServiceSettingsFlowExecute(r)
 }
 
 /*
- * SubmitSelfServiceRecoveryFlow Complete Recovery Flow
- * Use this endpoint to complete a recovery flow. This endpoint
-behaves differently for API and browser flows and has several states:
+ * SubmitSelfServiceSettingsFlow Complete Settings Flow
+ * Use this endpoint to complete a settings flow by sending an identity's updated password. This endpoint
+behaves differently for API and browser flows.
 
-`choose_method` expects `flow` (in the URL query) and `email` (in the body) to be sent
-and works with API- and Browser-initiated flows.
-For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
-and a HTTP 302 Found redirect with a fresh recovery flow if the flow was otherwise invalid (e.g. expired).
-For Browser clients it returns a HTTP 302 Found redirect to the Recovery UI URL with the Recovery Flow ID appended.
-`sent_email` is the success state after `choose_method` for the `link` method and allows the user to request another recovery email. It
-works for both API and Browser-initiated flows and returns the same responses as the flow in `choose_method` state.
-`passed_challenge` expects a `token` to be sent in the URL query and given the nature of the flow ("sending a recovery link")
-does not have any API capabilities. The server responds with a HTTP 302 Found redirect either to the Settings UI URL
-(if the link was valid) and instructs the user to update their password, or a redirect to the Recover UI URL with
-a new Recovery Flow ID which contains an error message that the recovery link was invalid.
+API-initiated flows expect `application/json` to be sent in the body and respond with
+HTTP 200 and an application/json body with the session token on success;
+HTTP 302 redirect to a fresh settings flow if the original flow expired with the appropriate error messages set;
+HTTP 400 on form validation errors.
+HTTP 401 when the endpoint is called without a valid session token.
+HTTP 403 when `selfservice.flows.settings.privileged_session_max_age` was reached.
+Implies that the user needs to re-authenticate.
 
-More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
+a HTTP 302 redirect to the post/after settings URL or the `return_to` value if it was set and if the flow succeeded;
+a HTTP 302 redirect to the Settings UI URL with the flow ID containing the validation errors otherwise.
+a HTTP 302 redirect to the login endpoint when `selfservice.flows.settings.privileged_session_max_age` was reached.
+
+More information can be found at [Ory Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiSubmitSelfServiceRecoveryFlowRequest
+ * @return PublicApiApiSubmitSelfServiceSettingsFlowRequest
 */
-func (a *PublicApiService) SubmitSelfServiceRecoveryFlow(ctx context.Context) PublicApiApiSubmitSelfServiceRecoveryFlowRequest {
-	return PublicApiApiSubmitSelfServiceRecoveryFlowRequest{
+func (a *PublicApiService) SubmitSelfServiceSettingsFlow(ctx context.Context) PublicApiApiSubmitSelfServiceSettingsFlowRequest {
+	return PublicApiApiSubmitSelfServiceSettingsFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -2735,28 +4067,30 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlow(ctx context.Context) Pu
 
 /*
  * Execute executes the request
+ * @return SettingsViaApiResponse
  */
-func (a *PublicApiService) SubmitSelfServiceRecoveryFlowExecute(r PublicApiApiSubmitSelfServiceRecoveryFlowRequest) (*http.Response, error) {
+func (a *PublicApiService) SubmitSelfServiceSettingsFlowExecute(r PublicApiApiSubmitSelfServiceSettingsFlowRequest) (*SettingsViaApiResponse, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
+		localVarReturnValue  *SettingsViaApiResponse
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRecoveryFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceSettingsFlow")
 	if err != nil {
-		return nil, &GenericOpenAPIError{error: err.Error()}
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/recovery"
+	localVarPath := localBasePath + "/self-service/settings"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 	if r.flow == nil {
-		return nil, reportError("flow is required and must be specified")
+		return localVarReturnValue, nil, reportError("flow is required and must be specified")
 	}
 
 	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
@@ -2778,22 +4112,36 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowExecute(r PublicApiApiSu
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.body
+	localVarPostBody = r.submitSelfServiceSettingsFlow
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["sessionToken"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["X-Session-Token"] = key
+			}
+		}
+	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return nil, err
+		return localVarReturnValue, nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -2802,78 +4150,102 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowExecute(r PublicApiApiSu
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 400 {
-			var v RecoveryFlow
+			var v SettingsFlow
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
+				return localVarReturnValue, localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarHTTPResponse, newErr
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
+				return localVarReturnValue, localVarHTTPResponse, newErr
 			}
 			newErr.model = v
 		}
-		return localVarHTTPResponse, newErr
+		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarHTTPResponse, nil
-}
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
 
-type PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest struct {
-	ctx                                         context.Context
-	ApiService                                  *PublicApiService
-	token                                       *string
-	flow                                        *string
-	submitSelfServiceRecoveryFlowWithLinkMethod *SubmitSelfServiceRecoveryFlowWithLinkMethod
+	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) Token(token string) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
-	r.token = &token
-	return r
+type PublicApiApiSubmitSelfServiceVerificationFlowRequest struct {
+	ctx        context.Context
+	ApiService *PublicApiService
+	flow       *string
+	body       *map[string]interface{}
 }
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) Flow(flow string) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
+
+func (r PublicApiApiSubmitSelfServiceVerificationFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceVerificationFlowRequest {
 	r.flow = &flow
 	return r
 }
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) SubmitSelfServiceRecoveryFlowWithLinkMethod(submitSelfServiceRecoveryFlowWithLinkMethod SubmitSelfServiceRecoveryFlowWithLinkMethod) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
-	r.submitSelfServiceRecoveryFlowWithLinkMethod = &submitSelfServiceRecoveryFlowWithLinkMethod
+func (r PublicApiApiSubmitSelfServiceVerificationFlowRequest) Body(body map[string]interface{}) PublicApiApiSubmitSelfServiceVerificationFlowRequest {
+	r.body = &body
 	return r
 }
 
-func (r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) Execute() (*http.Response, error) {
-	return r.ApiService.SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r)
+func (r PublicApiApiSubmitSelfServiceVerificationFlowRequest) Execute() (*http.Response, error) {
+	return r.ApiService.SubmitSelfServiceVerificationFlowExecute(r)
 }
 
 /*
- * SubmitSelfServiceRecoveryFlowWithLinkMethod Complete Recovery Flow with Link Method
- * Use this endpoint to complete a recovery flow using the link method. This endpoint
+ * SubmitSelfServiceVerificationFlow Complete Verification Flow
+ * Use this endpoint to complete a verification flow. This endpoint
 behaves differently for API and browser flows and has several states:
 
 `choose_method` expects `flow` (in the URL query) and `email` (in the body) to be sent
 and works with API- and Browser-initiated flows.
 For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
-and a HTTP 302 Found redirect with a fresh recovery flow if the flow was otherwise invalid (e.g. expired).
-For Browser clients it returns a HTTP 302 Found redirect to the Recovery UI URL with the Recovery Flow ID appended.
-`sent_email` is the success state after `choose_method` and allows the user to request another recovery email. It
+and a HTTP 302 Found redirect with a fresh verification flow if the flow was otherwise invalid (e.g. expired).
+For Browser clients it returns a HTTP 302 Found redirect to the Verification UI URL with the Verification Flow ID appended.
+`sent_email` is the success state after `choose_method` when using the `link` method and allows the user to request another verification email. It
 works for both API and Browser-initiated flows and returns the same responses as the flow in `choose_method` state.
-`passed_challenge` expects a `token` to be sent in the URL query and given the nature of the flow ("sending a recovery link")
+`passed_challenge` expects a `token` to be sent in the URL query and given the nature of the flow ("sending a verification link")
 does not have any API capabilities. The server responds with a HTTP 302 Found redirect either to the Settings UI URL
-(if the link was valid) and instructs the user to update their password, or a redirect to the Recover UI URL with
-a new Recovery Flow ID which contains an error message that the recovery link was invalid.
+(if the link was valid) and instructs the user to update their password, or a redirect to the Verification UI URL with
+a new Verification Flow ID which contains an error message that the verification link was invalid.
 
-More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/selfservice/flows/verify-email-account-activation).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest
+ * @return PublicApiApiSubmitSelfServiceVerificationFlowRequest
 */
-func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethod(ctx context.Context) PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest {
-	return PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest{
+func (a *PublicApiService) SubmitSelfServiceVerificationFlow(ctx context.Context) PublicApiApiSubmitSelfServiceVerificationFlowRequest {
+	return PublicApiApiSubmitSelfServiceVerificationFlowRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -2882,7 +4254,7 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethod(ctx conte
 /*
  * Execute executes the request
  */
-func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r PublicApiApiSubmitSelfServiceRecoveryFlowWithLinkMethodRequest) (*http.Response, error) {
+func (a *PublicApiService) SubmitSelfServiceVerificationFlowExecute(r PublicApiApiSubmitSelfServiceVerificationFlowRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
@@ -2891,23 +4263,21 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r
 		localVarFileBytes    []byte
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRecoveryFlowWithLinkMethod")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceVerificationFlow")
 	if err != nil {
 		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/recovery/methods/link"
+	localVarPath := localBasePath + "/self-service/verification/methods/link"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-
-	if r.token != nil {
-		localVarQueryParams.Add("token", parameterToString(*r.token, ""))
-	}
-	if r.flow != nil {
-		localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
+	if r.flow == nil {
+		return nil, reportError("flow is required and must be specified")
 	}
+
+	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
 
@@ -2926,7 +4296,7 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.submitSelfServiceRecoveryFlowWithLinkMethod
+	localVarPostBody = r.body
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
 		return nil, err
@@ -2950,7 +4320,7 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 400 {
-			var v RecoveryFlow
+			var v VerificationFlow
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
@@ -2974,47 +4344,36 @@ func (a *PublicApiService) SubmitSelfServiceRecoveryFlowWithLinkMethodExecute(r
 	return localVarHTTPResponse, nil
 }
 
-type PublicApiApiSubmitSelfServiceRegistrationFlowRequest struct {
-	ctx                               context.Context
-	ApiService                        *PublicApiService
-	flow                              *string
-	submitSelfServiceRegistrationFlow *SubmitSelfServiceRegistrationFlow
-}
-
-func (r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceRegistrationFlowRequest {
-	r.flow = &flow
-	return r
-}
-func (r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) SubmitSelfServiceRegistrationFlow(submitSelfServiceRegistrationFlow SubmitSelfServiceRegistrationFlow) PublicApiApiSubmitSelfServiceRegistrationFlowRequest {
-	r.submitSelfServiceRegistrationFlow = &submitSelfServiceRegistrationFlow
-	return r
+type PublicApiApiSubmitSelfServiceVerificationFlowWithCodeMethodRequest struct {
+	ctx        context.Context
+	ApiService *PublicApiService
 }
 
-func (r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) Execute() (*RegistrationViaApiResponse, *http.Response, error) {
-	return r.ApiService.SubmitSelfServiceRegistrationFlowExecute(r)
+func (r PublicApiApiSubmitSelfServiceVerificationFlowWithCodeMethodRequest) Execute() (*http.Response, error) {
+	return r.ApiService.SubmitSelfServiceVerificationFlowWithCodeMethodExecute(r)
 }
 
 /*
- * SubmitSelfServiceRegistrationFlow Submit a Registration Flow
- * Use this endpoint to complete a registration flow by sending an identity's traits and password. This endpoint
-behaves differently for API and browser flows.
-
-API flows expect `application/json` to be sent in the body and respond with
-HTTP 200 and a application/json body with the created identity success - if the session hook is configured the
-`session` and `session_token` will also be included;
-HTTP 302 redirect to a fresh registration flow if the original flow expired with the appropriate error messages set;
-HTTP 400 on form validation errors.
+ * SubmitSelfServiceVerificationFlowWithCodeMethod Complete Verification Flow with Code Method
+ * Use this endpoint to complete a verification flow using the code method. This endpoint
+behaves differently for API and browser flows and has several states:
 
-Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
-a HTTP 302 redirect to the post/after registration URL or the `return_to` value if it was set and if the registration succeeded;
-a HTTP 302 redirect to the registration UI URL with the flow ID containing the validation errors otherwise.
+`choose_method` expects `flow` (in the URL query) and `email` or `phone` (in the body) to be sent
+and works with API- and Browser-initiated flows. A short numeric code is sent to the address.
+For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
+and a HTTP 302 Found redirect with a fresh verification flow if the flow was otherwise invalid (e.g. expired).
+For Browser clients it returns a HTTP 302 Found redirect to the Verification UI URL with the Verification Flow ID appended.
+`sent_email` expects the `code` to be sent in the body. If the code is valid, the address is marked as verified
+and the flow transitions to `passed_challenge`. If the code is invalid, HTTP 400 is returned. Once the code expired
+or was entered incorrectly too many times, a new verification flow is initiated. Submitting the form without
+a code sends a new code.
 
-More information can be found at [Ory Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/selfservice/flows/verify-email-account-activation).
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiSubmitSelfServiceRegistrationFlowRequest
+ * @return PublicApiApiSubmitSelfServiceVerificationFlowWithCodeMethodRequest
 */
-func (a *PublicApiService) SubmitSelfServiceRegistrationFlow(ctx context.Context) PublicApiApiSubmitSelfServiceRegistrationFlowRequest {
-	return PublicApiApiSubmitSelfServiceRegistrationFlowRequest{
+func (a *PublicApiService) SubmitSelfServiceVerificationFlowWithCodeMethod(ctx context.Context) PublicApiApiSubmitSelfServiceVerificationFlowWithCodeMethodRequest {
+	return PublicApiApiSubmitSelfServiceVerificationFlowWithCodeMethodRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3022,35 +4381,29 @@ func (a *PublicApiService) SubmitSelfServiceRegistrationFlow(ctx context.Context
 
 /*
  * Execute executes the request
- * @return RegistrationViaApiResponse
  */
-func (a *PublicApiService) SubmitSelfServiceRegistrationFlowExecute(r PublicApiApiSubmitSelfServiceRegistrationFlowRequest) (*RegistrationViaApiResponse, *http.Response, error) {
+func (a *PublicApiService) SubmitSelfServiceVerificationFlowWithCodeMethodExecute(r PublicApiApiSubmitSelfServiceVerificationFlowWithCodeMethodRequest) (*http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *RegistrationViaApiResponse
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceRegistrationFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceVerificationFlowWithCodeMethod")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/registration"
+	localVarPath := localBasePath + "/self-service/verification/methods/code"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.flow == nil {
-		return localVarReturnValue, nil, reportError("flow is required and must be specified")
-	}
 
-	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -3066,23 +4419,21 @@ func (a *PublicApiService) SubmitSelfServiceRegistrationFlowExecute(r PublicApiA
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.submitSelfServiceRegistrationFlow
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -3091,83 +4442,63 @@ func (a *PublicApiService) SubmitSelfServiceRegistrationFlowExecute(r PublicApiA
 			error: localVarHTTPResponse.Status,
 		}
 		if localVarHTTPResponse.StatusCode == 400 {
-			var v RegistrationFlow
+			var v VerificationFlow
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.model = v
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	return localVarHTTPResponse, nil
 }
 
-type PublicApiApiSubmitSelfServiceSettingsFlowRequest struct {
-	ctx                           context.Context
-	ApiService                    *PublicApiService
-	flow                          *string
-	submitSelfServiceSettingsFlow *SubmitSelfServiceSettingsFlow
+type PublicApiApiTokenizeSessionRequest struct {
+	ctx           context.Context
+	ApiService    *PublicApiService
+	cookie        *string
+	authorization *string
 }
 
-func (r PublicApiApiSubmitSelfServiceSettingsFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceSettingsFlowRequest {
-	r.flow = &flow
+func (r PublicApiApiTokenizeSessionRequest) Cookie(cookie string) PublicApiApiTokenizeSessionRequest {
+	r.cookie = &cookie
 	return r
 }
-func (r PublicApiApiSubmitSelfServiceSettingsFlowRequest) SubmitSelfServiceSettingsFlow(submitSelfServiceSettingsFlow SubmitSelfServiceSettingsFlow) PublicApiApiSubmitSelfServiceSettingsFlowRequest {
-	r.submitSelfServiceSettingsFlow = &submitSelfServiceSettingsFlow
+func (r PublicApiApiTokenizeSessionRequest) Authorization(authorization string) PublicApiApiTokenizeSessionRequest {
+	r.authorization = &authorization
 	return r
 }
 
-func (r PublicApiApiSubmitSelfServiceSettingsFlowRequest) Execute() (*SettingsViaApiResponse, *http.Response, error) {
-	return r.ApiService.SubmitSelfServiceSettingsFlowExecute(r)
+func (r PublicApiApiTokenizeSessionRequest) Execute() (*TokenizedSession, *http.Response, error) {
+	return r.ApiService.TokenizeSessionExecute(r)
 }
 
 /*
- * SubmitSelfServiceSettingsFlow Complete Settings Flow
- * Use this endpoint to complete a settings flow by sending an identity's updated password. This endpoint
-behaves differently for API and browser flows.
-
-API-initiated flows expect `application/json` to be sent in the body and respond with
-HTTP 200 and an application/json body with the session token on success;
-HTTP 302 redirect to a fresh settings flow if the original flow expired with the appropriate error messages set;
-HTTP 400 on form validation errors.
-HTTP 401 when the endpoint is called without a valid session token.
-HTTP 403 when `selfservice.flows.settings.privileged_session_max_age` was reached.
-Implies that the user needs to re-authenticate.
-
-Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
-a HTTP 302 redirect to the post/after settings URL or the `return_to` value if it was set and if the flow succeeded;
-a HTTP 302 redirect to the Settings UI URL with the flow ID containing the validation errors otherwise.
-a HTTP 302 redirect to the login endpoint when `selfservice.flows.settings.privileged_session_max_age` was reached.
+ * TokenizeSession Exchange the Current Session for a JSON Web Token
+ * Returns a short-lived JSON Web Token for the session which is sent in the request. Services can verify the
+token offline using the keys published at `/.well-known/jwks.json` instead of calling `/sessions/whoami`
+on every request. The claims of the token are configured using `session.tokenizer.claims_mapper_url`.
 
-More information can be found at [Ory Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
+The same authenticator assurance level as for `/sessions/whoami` is required. This endpoint returns 403
+if `session.tokenizer.jwks_url` is not set.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiSubmitSelfServiceSettingsFlowRequest
+ * @return PublicApiApiTokenizeSessionRequest
 */
-func (a *PublicApiService) SubmitSelfServiceSettingsFlow(ctx context.Context) PublicApiApiSubmitSelfServiceSettingsFlowRequest {
-	return PublicApiApiSubmitSelfServiceSettingsFlowRequest{
+func (a *PublicApiService) TokenizeSession(ctx context.Context) PublicApiApiTokenizeSessionRequest {
+	return PublicApiApiTokenizeSessionRequest{
 		ApiService: a,
 		ctx:        ctx,
 	}
@@ -3175,35 +4506,31 @@ func (a *PublicApiService) SubmitSelfServiceSettingsFlow(ctx context.Context) Pu
 
 /*
  * Execute executes the request
- * @return SettingsViaApiResponse
+ * @return TokenizedSession
  */
-func (a *PublicApiService) SubmitSelfServiceSettingsFlowExecute(r PublicApiApiSubmitSelfServiceSettingsFlowRequest) (*SettingsViaApiResponse, *http.Response, error) {
+func (a *PublicApiService) TokenizeSessionExecute(r PublicApiApiTokenizeSessionRequest) (*TokenizedSession, *http.Response, error) {
 	var (
-		localVarHTTPMethod   = http.MethodPost
+		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		localVarFormFileName string
 		localVarFileName     string
 		localVarFileBytes    []byte
-		localVarReturnValue  *SettingsViaApiResponse
+		localVarReturnValue  *TokenizedSession
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceSettingsFlow")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.TokenizeSession")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/self-service/settings"
+	localVarPath := localBasePath + "/sessions/tokenize"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.flow == nil {
-		return localVarReturnValue, nil, reportError("flow is required and must be specified")
-	}
 
-	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -3219,8 +4546,12 @@ func (a *PublicApiService) SubmitSelfServiceSettingsFlowExecute(r PublicApiApiSu
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.submitSelfServiceSettingsFlow
+	if r.cookie != nil {
+		localVarHeaderParams["Cookie"] = parameterToString(*r.cookie, "")
+	}
+	if r.authorization != nil {
+		localVarHeaderParams["Authorization"] = parameterToString(*r.authorization, "")
+	}
 	if r.ctx != nil {
 		// API Key Authentication
 		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
@@ -3257,16 +4588,6 @@ func (a *PublicApiService) SubmitSelfServiceSettingsFlowExecute(r PublicApiApiSu
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v SettingsFlow
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 401 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -3311,147 +4632,6 @@ func (a *PublicApiService) SubmitSelfServiceSettingsFlowExecute(r PublicApiApiSu
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type PublicApiApiSubmitSelfServiceVerificationFlowRequest struct {
-	ctx        context.Context
-	ApiService *PublicApiService
-	flow       *string
-	body       *map[string]interface{}
-}
-
-func (r PublicApiApiSubmitSelfServiceVerificationFlowRequest) Flow(flow string) PublicApiApiSubmitSelfServiceVerificationFlowRequest {
-	r.flow = &flow
-	return r
-}
-func (r PublicApiApiSubmitSelfServiceVerificationFlowRequest) Body(body map[string]interface{}) PublicApiApiSubmitSelfServiceVerificationFlowRequest {
-	r.body = &body
-	return r
-}
-
-func (r PublicApiApiSubmitSelfServiceVerificationFlowRequest) Execute() (*http.Response, error) {
-	return r.ApiService.SubmitSelfServiceVerificationFlowExecute(r)
-}
-
-/*
- * SubmitSelfServiceVerificationFlow Complete Verification Flow
- * Use this endpoint to complete a verification flow. This endpoint
-behaves differently for API and browser flows and has several states:
-
-`choose_method` expects `flow` (in the URL query) and `email` (in the body) to be sent
-and works with API- and Browser-initiated flows.
-For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
-and a HTTP 302 Found redirect with a fresh verification flow if the flow was otherwise invalid (e.g. expired).
-For Browser clients it returns a HTTP 302 Found redirect to the Verification UI URL with the Verification Flow ID appended.
-`sent_email` is the success state after `choose_method` when using the `link` method and allows the user to request another verification email. It
-works for both API and Browser-initiated flows and returns the same responses as the flow in `choose_method` state.
-`passed_challenge` expects a `token` to be sent in the URL query and given the nature of the flow ("sending a verification link")
-does not have any API capabilities. The server responds with a HTTP 302 Found redirect either to the Settings UI URL
-(if the link was valid) and instructs the user to update their password, or a redirect to the Verification UI URL with
-a new Verification Flow ID which contains an error message that the verification link was invalid.
-
-More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/selfservice/flows/verify-email-account-activation).
- * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
- * @return PublicApiApiSubmitSelfServiceVerificationFlowRequest
-*/
-func (a *PublicApiService) SubmitSelfServiceVerificationFlow(ctx context.Context) PublicApiApiSubmitSelfServiceVerificationFlowRequest {
-	return PublicApiApiSubmitSelfServiceVerificationFlowRequest{
-		ApiService: a,
-		ctx:        ctx,
-	}
-}
-
-/*
- * Execute executes the request
- */
-func (a *PublicApiService) SubmitSelfServiceVerificationFlowExecute(r PublicApiApiSubmitSelfServiceVerificationFlowRequest) (*http.Response, error) {
-	var (
-		localVarHTTPMethod   = http.MethodPost
-		localVarPostBody     interface{}
-		localVarFormFileName string
-		localVarFileName     string
-		localVarFileBytes    []byte
-	)
-
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "PublicApiService.SubmitSelfServiceVerificationFlow")
-	if err != nil {
-		return nil, &GenericOpenAPIError{error: err.Error()}
-	}
-
-	localVarPath := localBasePath + "/self-service/verification/methods/link"
-
-	localVarHeaderParams := make(map[string]string)
-	localVarQueryParams := url.Values{}
-	localVarFormParams := url.Values{}
-	if r.flow == nil {
-		return nil, reportError("flow is required and must be specified")
-	}
-
-	localVarQueryParams.Add("flow", parameterToString(*r.flow, ""))
-	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json", "application/x-www-form-urlencoded"}
-
-	// set Content-Type header
-	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
-	if localVarHTTPContentType != "" {
-		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
-	}
-
-	// to determine the Accept header
-	localVarHTTPHeaderAccepts := []string{"application/json"}
-
-	// set Accept header
-	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
-	if localVarHTTPHeaderAccept != "" {
-		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
-	}
-	// body params
-	localVarPostBody = r.body
-	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, localVarFormFileName, localVarFileName, localVarFileBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	localVarHTTPResponse, err := a.client.callAPI(req)
-	if err != nil || localVarHTTPResponse == nil {
-		return localVarHTTPResponse, err
-	}
-
-	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
-	localVarHTTPResponse.Body.Close()
-	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
-	if err != nil {
-		return localVarHTTPResponse, err
-	}
-
-	if localVarHTTPResponse.StatusCode >= 300 {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: localVarHTTPResponse.Status,
-		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v VerificationFlow
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-			return localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 500 {
-			var v GenericError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarHTTPResponse, newErr
-			}
-			newErr.model = v
-		}
-		return localVarHTTPResponse, newErr
-	}
-
-	return localVarHTTPResponse, nil
-}
-
 type PublicApiApiWhoamiRequest struct {
 	ctx           context.Context
 	ApiService    *PublicApiService
@@ -3478,6 +4658,11 @@ func (r PublicApiApiWhoamiRequest) Execute() (*Session, *http.Response, error) {
 Returns a session object in the body or 401 if the credentials are invalid or no credentials were sent.
 Additionally when the request it successful it adds the user ID to the 'X-Kratos-Authenticated-Identity-Id' header in the response.
 
+If `session.whoami.required_aal` is set to `highest_available`, this endpoint returns 403 if the
+session has not completed all authentication factors available to the identity.
+
+If `session.sliding_expiry` is enabled, sessions which expire soon are extended by this endpoint.
+
 This endpoint is useful for reverse proxies and API Gateways.
  * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
  * @return PublicApiApiWhoamiRequest
@@ -3583,6 +4768,16 @@ func (a *PublicApiService) WhoamiExecute(r PublicApiApiWhoamiRequest) (*Session,
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v GenericError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v GenericError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -17,10 +17,18 @@ import (
 
 // CreateIdentity struct for CreateIdentity
 type CreateIdentity struct {
+	Credentials *CreateIdentityCredentials `json:"credentials,omitempty"`
+	// MetadataAdmin contains application data which is only visible and editable through the admin API.  in: body
+	MetadataAdmin map[string]interface{} `json:"metadata_admin,omitempty"`
+	// MetadataPublic contains application data which is visible to the identity, for example in the session, but can only be changed by administrators.  in: body
+	MetadataPublic map[string]interface{} `json:"metadata_public,omitempty"`
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits.
-	SchemaId string `json:"schema_id"`
+	SchemaId string  `json:"schema_id"`
+	State    *string `json:"state,omitempty"`
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits in a self-service manner. The input will always be validated against the JSON Schema defined in `schema_url`.
 	Traits map[string]interface{} `json:"traits"`
+	// VerifiableAddresses imports the verification status of the identity's addresses. Addresses which are not part of the identity's traits are rejected.  in: body
+	VerifiableAddresses []CreateIdentityVerifiableAddress `json:"verifiable_addresses,omitempty"`
 }
 
 // NewCreateIdentity instantiates a new CreateIdentity object
@@ -42,6 +50,102 @@ func NewCreateIdentityWithDefaults() *CreateIdentity {
 	return &this
 }
 
+// GetCredentials returns the Credentials field value if set, zero value otherwise.
+func (o *CreateIdentity) GetCredentials() CreateIdentityCredentials {
+	if o == nil || o.Credentials == nil {
+		var ret CreateIdentityCredentials
+		return ret
+	}
+	return *o.Credentials
+}
+
+// GetCredentialsOk returns a tuple with the Credentials field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateIdentity) GetCredentialsOk() (*CreateIdentityCredentials, bool) {
+	if o == nil || o.Credentials == nil {
+		return nil, false
+	}
+	return o.Credentials, true
+}
+
+// HasCredentials returns a boolean if a field has been set.
+func (o *CreateIdentity) HasCredentials() bool {
+	if o != nil && o.Credentials != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetCredentials gets a reference to the given CreateIdentityCredentials and assigns it to the Credentials field.
+func (o *CreateIdentity) SetCredentials(v CreateIdentityCredentials) {
+	o.Credentials = &v
+}
+
+// GetMetadataAdmin returns the MetadataAdmin field value if set, zero value otherwise.
+func (o *CreateIdentity) GetMetadataAdmin() map[string]interface{} {
+	if o == nil || o.MetadataAdmin == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataAdmin
+}
+
+// GetMetadataAdminOk returns a tuple with the MetadataAdmin field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateIdentity) GetMetadataAdminOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataAdmin == nil {
+		return nil, false
+	}
+	return o.MetadataAdmin, true
+}
+
+// HasMetadataAdmin returns a boolean if a field has been set.
+func (o *CreateIdentity) HasMetadataAdmin() bool {
+	if o != nil && o.MetadataAdmin != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataAdmin gets a reference to the given map[string]interface{} and assigns it to the MetadataAdmin field.
+func (o *CreateIdentity) SetMetadataAdmin(v map[string]interface{}) {
+	o.MetadataAdmin = v
+}
+
+// GetMetadataPublic returns the MetadataPublic field value if set, zero value otherwise.
+func (o *CreateIdentity) GetMetadataPublic() map[string]interface{} {
+	if o == nil || o.MetadataPublic == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataPublic
+}
+
+// GetMetadataPublicOk returns a tuple with the MetadataPublic field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateIdentity) GetMetadataPublicOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataPublic == nil {
+		return nil, false
+	}
+	return o.MetadataPublic, true
+}
+
+// HasMetadataPublic returns a boolean if a field has been set.
+func (o *CreateIdentity) HasMetadataPublic() bool {
+	if o != nil && o.MetadataPublic != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataPublic gets a reference to the given map[string]interface{} and assigns it to the MetadataPublic field.
+func (o *CreateIdentity) SetMetadataPublic(v map[string]interface{}) {
+	o.MetadataPublic = v
+}
+
 // GetSchemaId returns the SchemaId field value
 func (o *CreateIdentity) GetSchemaId() string {
 	if o == nil {
@@ -66,6 +170,38 @@ func (o *CreateIdentity) SetSchemaId(v string) {
 	o.SchemaId = v
 }
 
+// GetState returns the State field value if set, zero value otherwise.
+func (o *CreateIdentity) GetState() string {
+	if o == nil || o.State == nil {
+		var ret string
+		return ret
+	}
+	return *o.State
+}
+
+// GetStateOk returns a tuple with the State field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateIdentity) GetStateOk() (*string, bool) {
+	if o == nil || o.State == nil {
+		return nil, false
+	}
+	return o.State, true
+}
+
+// HasState returns a boolean if a field has been set.
+func (o *CreateIdentity) HasState() bool {
+	if o != nil && o.State != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetState gets a reference to the given string and assigns it to the State field.
+func (o *CreateIdentity) SetState(v string) {
+	o.State = &v
+}
+
 // GetTraits returns the Traits field value
 func (o *CreateIdentity) GetTraits() map[string]interface{} {
 	if o == nil {
@@ -90,14 +226,61 @@ func (o *CreateIdentity) SetTraits(v map[string]interface{}) {
 	o.Traits = v
 }
 
+// GetVerifiableAddresses returns the VerifiableAddresses field value if set, zero value otherwise.
+func (o *CreateIdentity) GetVerifiableAddresses() []CreateIdentityVerifiableAddress {
+	if o == nil || o.VerifiableAddresses == nil {
+		var ret []CreateIdentityVerifiableAddress
+		return ret
+	}
+	return o.VerifiableAddresses
+}
+
+// GetVerifiableAddressesOk returns a tuple with the VerifiableAddresses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateIdentity) GetVerifiableAddressesOk() ([]CreateIdentityVerifiableAddress, bool) {
+	if o == nil || o.VerifiableAddresses == nil {
+		return nil, false
+	}
+	return o.VerifiableAddresses, true
+}
+
+// HasVerifiableAddresses returns a boolean if a field has been set.
+func (o *CreateIdentity) HasVerifiableAddresses() bool {
+	if o != nil && o.VerifiableAddresses != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetVerifiableAddresses gets a reference to the given []CreateIdentityVerifiableAddress and assigns it to the VerifiableAddresses field.
+func (o *CreateIdentity) SetVerifiableAddresses(v []CreateIdentityVerifiableAddress) {
+	o.VerifiableAddresses = v
+}
+
 func (o CreateIdentity) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.Credentials != nil {
+		toSerialize["credentials"] = o.Credentials
+	}
+	if o.MetadataAdmin != nil {
+		toSerialize["metadata_admin"] = o.MetadataAdmin
+	}
+	if o.MetadataPublic != nil {
+		toSerialize["metadata_public"] = o.MetadataPublic
+	}
 	if true {
 		toSerialize["schema_id"] = o.SchemaId
 	}
+	if o.State != nil {
+		toSerialize["state"] = o.State
+	}
 	if true {
 		toSerialize["traits"] = o.Traits
 	}
+	if o.VerifiableAddresses != nil {
+		toSerialize["verifiable_addresses"] = o.VerifiableAddresses
+	}
 	return json.Marshal(toSerialize)
 }
 
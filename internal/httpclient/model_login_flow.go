@@ -29,6 +29,8 @@ type LoginFlow struct {
 	IssuedAt time.Time `json:"issued_at"`
 	// RequestURL is the initial URL that was requested from Ory Kratos. It can be used to forward information contained in the URL's path or query for example.
 	RequestUrl string `json:"request_url"`
+	// aal0: The session was not authenticated (for example because it was issued by an administrator). aal1: The identity completed a single authentication factor (for example a password). aal2: The identity completed a second authentication factor (for example a TOTP code or a security key).
+	RequestedAal *string `json:"requested_aal,omitempty"`
 	// The flow type can either be `api` or `browser`.
 	Type string      `json:"type"`
 	Ui   UiContainer `json:"ui"`
@@ -217,6 +219,38 @@ func (o *LoginFlow) SetRequestUrl(v string) {
 	o.RequestUrl = v
 }
 
+// GetRequestedAal returns the RequestedAal field value if set, zero value otherwise.
+func (o *LoginFlow) GetRequestedAal() string {
+	if o == nil || o.RequestedAal == nil {
+		var ret string
+		return ret
+	}
+	return *o.RequestedAal
+}
+
+// GetRequestedAalOk returns a tuple with the RequestedAal field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoginFlow) GetRequestedAalOk() (*string, bool) {
+	if o == nil || o.RequestedAal == nil {
+		return nil, false
+	}
+	return o.RequestedAal, true
+}
+
+// HasRequestedAal returns a boolean if a field has been set.
+func (o *LoginFlow) HasRequestedAal() bool {
+	if o != nil && o.RequestedAal != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetRequestedAal gets a reference to the given string and assigns it to the RequestedAal field.
+func (o *LoginFlow) SetRequestedAal(v string) {
+	o.RequestedAal = &v
+}
+
 // GetType returns the Type field value
 func (o *LoginFlow) GetType() string {
 	if o == nil {
@@ -285,6 +319,9 @@ func (o LoginFlow) MarshalJSON() ([]byte, error) {
 	if true {
 		toSerialize["request_url"] = o.RequestUrl
 	}
+	if o.RequestedAal != nil {
+		toSerialize["requested_aal"] = o.RequestedAal
+	}
 	if true {
 		toSerialize["type"] = o.Type
 	}
@@ -18,12 +18,16 @@ import (
 
 // Session struct for Session
 type Session struct {
-	Active          *bool     `json:"active,omitempty"`
-	AuthenticatedAt time.Time `json:"authenticated_at"`
-	ExpiresAt       time.Time `json:"expires_at"`
-	Id              string    `json:"id"`
-	Identity        Identity  `json:"identity"`
-	IssuedAt        time.Time `json:"issued_at"`
+	// aal0: The session was not authenticated (for example because it was issued by an administrator). aal1: The identity completed a single authentication factor (for example a password). aal2: The identity completed a second authentication factor (for example a TOTP code or a security key).
+	Aal                   *string                       `json:"aal,omitempty"`
+	Active                *bool                         `json:"active,omitempty"`
+	AuthenticatedAt       time.Time                     `json:"authenticated_at"`
+	AuthenticationMethods []SessionAuthenticationMethod `json:"authentication_methods,omitempty"`
+	Devices               []SessionDevice               `json:"devices,omitempty"`
+	ExpiresAt             time.Time                     `json:"expires_at"`
+	Id                    string                        `json:"id"`
+	Identity              Identity                      `json:"identity"`
+	IssuedAt              time.Time                     `json:"issued_at"`
 }
 
 // NewSession instantiates a new Session object
@@ -48,6 +52,38 @@ func NewSessionWithDefaults() *Session {
 	return &this
 }
 
+// GetAal returns the Aal field value if set, zero value otherwise.
+func (o *Session) GetAal() string {
+	if o == nil || o.Aal == nil {
+		var ret string
+		return ret
+	}
+	return *o.Aal
+}
+
+// GetAalOk returns a tuple with the Aal field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Session) GetAalOk() (*string, bool) {
+	if o == nil || o.Aal == nil {
+		return nil, false
+	}
+	return o.Aal, true
+}
+
+// HasAal returns a boolean if a field has been set.
+func (o *Session) HasAal() bool {
+	if o != nil && o.Aal != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetAal gets a reference to the given string and assigns it to the Aal field.
+func (o *Session) SetAal(v string) {
+	o.Aal = &v
+}
+
 // GetActive returns the Active field value if set, zero value otherwise.
 func (o *Session) GetActive() bool {
 	if o == nil || o.Active == nil {
@@ -104,6 +140,70 @@ func (o *Session) SetAuthenticatedAt(v time.Time) {
 	o.AuthenticatedAt = v
 }
 
+// GetAuthenticationMethods returns the AuthenticationMethods field value if set, zero value otherwise.
+func (o *Session) GetAuthenticationMethods() []SessionAuthenticationMethod {
+	if o == nil || o.AuthenticationMethods == nil {
+		var ret []SessionAuthenticationMethod
+		return ret
+	}
+	return o.AuthenticationMethods
+}
+
+// GetAuthenticationMethodsOk returns a tuple with the AuthenticationMethods field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Session) GetAuthenticationMethodsOk() ([]SessionAuthenticationMethod, bool) {
+	if o == nil || o.AuthenticationMethods == nil {
+		return nil, false
+	}
+	return o.AuthenticationMethods, true
+}
+
+// HasAuthenticationMethods returns a boolean if a field has been set.
+func (o *Session) HasAuthenticationMethods() bool {
+	if o != nil && o.AuthenticationMethods != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetAuthenticationMethods gets a reference to the given []SessionAuthenticationMethod and assigns it to the AuthenticationMethods field.
+func (o *Session) SetAuthenticationMethods(v []SessionAuthenticationMethod) {
+	o.AuthenticationMethods = v
+}
+
+// GetDevices returns the Devices field value if set, zero value otherwise.
+func (o *Session) GetDevices() []SessionDevice {
+	if o == nil || o.Devices == nil {
+		var ret []SessionDevice
+		return ret
+	}
+	return o.Devices
+}
+
+// GetDevicesOk returns a tuple with the Devices field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Session) GetDevicesOk() ([]SessionDevice, bool) {
+	if o == nil || o.Devices == nil {
+		return nil, false
+	}
+	return o.Devices, true
+}
+
+// HasDevices returns a boolean if a field has been set.
+func (o *Session) HasDevices() bool {
+	if o != nil && o.Devices != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetDevices gets a reference to the given []SessionDevice and assigns it to the Devices field.
+func (o *Session) SetDevices(v []SessionDevice) {
+	o.Devices = v
+}
+
 // GetExpiresAt returns the ExpiresAt field value
 func (o *Session) GetExpiresAt() time.Time {
 	if o == nil {
@@ -202,12 +302,21 @@ func (o *Session) SetIssuedAt(v time.Time) {
 
 func (o Session) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.Aal != nil {
+		toSerialize["aal"] = o.Aal
+	}
 	if o.Active != nil {
 		toSerialize["active"] = o.Active
 	}
 	if true {
 		toSerialize["authenticated_at"] = o.AuthenticatedAt
 	}
+	if o.AuthenticationMethods != nil {
+		toSerialize["authentication_methods"] = o.AuthenticationMethods
+	}
+	if o.Devices != nil {
+		toSerialize["devices"] = o.Devices
+	}
 	if true {
 		toSerialize["expires_at"] = o.ExpiresAt
 	}
@@ -21,6 +21,8 @@ type SubmitSelfServiceRecoveryFlowWithLinkMethod struct {
 	CsrfToken *string `json:"csrf_token,omitempty"`
 	// Email to Recover  Needs to be set when initiating the flow. If the email is a registered recovery email, a recovery link will be sent. If the email is not known, a email with details on what happened will be sent instead.  format: email in: body
 	Email *string `json:"email,omitempty"`
+	// Phone Number to Recover  May be set instead of the email if text messages are enabled. If the phone number is a registered recovery phone number, a recovery link will be sent by text message.  in: body
+	Phone *string `json:"phone,omitempty"`
 }
 
 // NewSubmitSelfServiceRecoveryFlowWithLinkMethod instantiates a new SubmitSelfServiceRecoveryFlowWithLinkMethod object
@@ -104,6 +106,38 @@ func (o *SubmitSelfServiceRecoveryFlowWithLinkMethod) SetEmail(v string) {
 	o.Email = &v
 }
 
+// GetPhone returns the Phone field value if set, zero value otherwise.
+func (o *SubmitSelfServiceRecoveryFlowWithLinkMethod) GetPhone() string {
+	if o == nil || o.Phone == nil {
+		var ret string
+		return ret
+	}
+	return *o.Phone
+}
+
+// GetPhoneOk returns a tuple with the Phone field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SubmitSelfServiceRecoveryFlowWithLinkMethod) GetPhoneOk() (*string, bool) {
+	if o == nil || o.Phone == nil {
+		return nil, false
+	}
+	return o.Phone, true
+}
+
+// HasPhone returns a boolean if a field has been set.
+func (o *SubmitSelfServiceRecoveryFlowWithLinkMethod) HasPhone() bool {
+	if o != nil && o.Phone != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetPhone gets a reference to the given string and assigns it to the Phone field.
+func (o *SubmitSelfServiceRecoveryFlowWithLinkMethod) SetPhone(v string) {
+	o.Phone = &v
+}
+
 func (o SubmitSelfServiceRecoveryFlowWithLinkMethod) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
 	if o.CsrfToken != nil {
@@ -112,6 +146,9 @@ func (o SubmitSelfServiceRecoveryFlowWithLinkMethod) MarshalJSON() ([]byte, erro
 	if o.Email != nil {
 		toSerialize["email"] = o.Email
 	}
+	if o.Phone != nil {
+		toSerialize["phone"] = o.Phone
+	}
 	return json.Marshal(toSerialize)
 }
 
@@ -17,6 +17,10 @@ import (
 
 // UpdateIdentity struct for UpdateIdentity
 type UpdateIdentity struct {
+	// MetadataAdmin contains application data which is only visible through the admin API. If set, replaces the identity's admin metadata. Set it to null to remove the metadata.
+	MetadataAdmin map[string]interface{} `json:"metadata_admin,omitempty"`
+	// MetadataPublic contains application data which is visible to the identity. If set, replaces the identity's public metadata. Set it to null to remove the metadata.
+	MetadataPublic map[string]interface{} `json:"metadata_public,omitempty"`
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits. If set will update the Identity's SchemaID.
 	SchemaId *string `json:"schema_id,omitempty"`
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits in a self-service manner. The input will always be validated against the JSON Schema defined in `schema_id`.
@@ -41,6 +45,70 @@ func NewUpdateIdentityWithDefaults() *UpdateIdentity {
 	return &this
 }
 
+// GetMetadataAdmin returns the MetadataAdmin field value if set, zero value otherwise.
+func (o *UpdateIdentity) GetMetadataAdmin() map[string]interface{} {
+	if o == nil || o.MetadataAdmin == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataAdmin
+}
+
+// GetMetadataAdminOk returns a tuple with the MetadataAdmin field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *UpdateIdentity) GetMetadataAdminOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataAdmin == nil {
+		return nil, false
+	}
+	return o.MetadataAdmin, true
+}
+
+// HasMetadataAdmin returns a boolean if a field has been set.
+func (o *UpdateIdentity) HasMetadataAdmin() bool {
+	if o != nil && o.MetadataAdmin != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataAdmin gets a reference to the given map[string]interface{} and assigns it to the MetadataAdmin field.
+func (o *UpdateIdentity) SetMetadataAdmin(v map[string]interface{}) {
+	o.MetadataAdmin = v
+}
+
+// GetMetadataPublic returns the MetadataPublic field value if set, zero value otherwise.
+func (o *UpdateIdentity) GetMetadataPublic() map[string]interface{} {
+	if o == nil || o.MetadataPublic == nil {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.MetadataPublic
+}
+
+// GetMetadataPublicOk returns a tuple with the MetadataPublic field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *UpdateIdentity) GetMetadataPublicOk() (map[string]interface{}, bool) {
+	if o == nil || o.MetadataPublic == nil {
+		return nil, false
+	}
+	return o.MetadataPublic, true
+}
+
+// HasMetadataPublic returns a boolean if a field has been set.
+func (o *UpdateIdentity) HasMetadataPublic() bool {
+	if o != nil && o.MetadataPublic != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetMetadataPublic gets a reference to the given map[string]interface{} and assigns it to the MetadataPublic field.
+func (o *UpdateIdentity) SetMetadataPublic(v map[string]interface{}) {
+	o.MetadataPublic = v
+}
+
 // GetSchemaId returns the SchemaId field value if set, zero value otherwise.
 func (o *UpdateIdentity) GetSchemaId() string {
 	if o == nil || o.SchemaId == nil {
@@ -99,6 +167,12 @@ func (o *UpdateIdentity) SetTraits(v map[string]interface{}) {
 
 func (o UpdateIdentity) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.MetadataAdmin != nil {
+		toSerialize["metadata_admin"] = o.MetadataAdmin
+	}
+	if o.MetadataPublic != nil {
+		toSerialize["metadata_public"] = o.MetadataPublic
+	}
 	if o.SchemaId != nil {
 		toSerialize["schema_id"] = o.SchemaId
 	}
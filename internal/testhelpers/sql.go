@@ -16,6 +16,7 @@ import (
 	"kratos/selfservice/flow/registration"
 	"kratos/selfservice/flow/settings"
 	"kratos/selfservice/flow/verification"
+	"kratos/selfservice/strategy/code"
 	"kratos/selfservice/strategy/link"
 	"kratos/session"
 )
@@ -32,6 +33,7 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 
 		new(link.RecoveryToken).TableName(ctx),
 		new(link.VerificationToken).TableName(ctx),
+		new(code.Code).TableName(ctx),
 
 		new(recovery.Flow).TableName(ctx),
 
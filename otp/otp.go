@@ -17,3 +17,15 @@ func New() (string, error) {
 	}
 	return string(code), nil
 }
+
+// NumericEntropy sets the number of digits used for generating codes which are typed in by the user,
+// for example in the recovery or verification flow.
+const NumericEntropy = 6
+
+func NewNumeric() (string, error) {
+	code, err := randx.RuneSequence(NumericEntropy, randx.Numeric)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(code), nil
+}
@@ -20,6 +20,7 @@ import (
 	"kratos/selfservice/flow/registration"
 	"kratos/selfservice/flow/settings"
 	"kratos/selfservice/flow/verification"
+	"kratos/selfservice/strategy/code"
 	"kratos/selfservice/strategy/link"
 	"kratos/session"
 )
@@ -41,6 +42,7 @@ type Persister interface {
 	recovery.FlowPersister
 	link.RecoveryTokenPersister
 	link.VerificationTokenPersister
+	code.CodePersister
 
 	Close(context.Context) error
 	Ping() error
@@ -22,6 +22,7 @@ import (
 	"github.com/ory/x/sqlcon/dockertest"
 	"kratos/driver"
 	"kratos/driver/config"
+	"kratos/identity"
 	"kratos/internal/testhelpers"
 	"kratos/selfservice/flow/login"
 	"kratos/selfservice/flow/recovery"
@@ -103,7 +104,7 @@ func TestMigrations(t *testing.T) {
 				)
 
 				t.Run("case=identity", func(t *testing.T) {
-					ids, err := d.PrivilegedIdentityPool().ListIdentities(context.Background(), 0, 1000)
+					ids, err := d.PrivilegedIdentityPool().ListIdentities(context.Background(), identity.ListIdentityParameters{PerPage: 1000})
 					require.NoError(t, err)
 					require.NotEmpty(t, ids)
 
@@ -1,3 +1,3 @@
 #!/bin/bash
 
-go test -tags sqlite,refresh -short .
+go test -tags sqlite,json1,refresh -short .
@@ -0,0 +1 @@
+ALTER TABLE `selfservice_login_flows` ADD COLUMN `internal_context` JSON;
\ No newline at end of file
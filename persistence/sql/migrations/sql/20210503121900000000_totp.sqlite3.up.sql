@@ -0,0 +1 @@
+ALTER TABLE "selfservice_login_flows" ADD COLUMN "internal_context" TEXT;
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT 'a8fdfa8e-0016-4d3c-b1fb-8806e92aeb40', 'totp' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'totp');
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "identity_credential_identifiers" DROP COLUMN "identity_credential_type_id";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "identity_credential_identifiers" DROP CONSTRAINT IF EXISTS "identity_credential_identifiers_type_id_fk_idx";
\ No newline at end of file
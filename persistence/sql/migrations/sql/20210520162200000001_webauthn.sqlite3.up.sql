@@ -0,0 +1 @@
+UPDATE identity_credential_identifiers SET identity_credential_type_id = (SELECT ic.identity_credential_type_id FROM identity_credentials ic WHERE ic.id = identity_credential_identifiers.identity_credential_id);
\ No newline at end of file
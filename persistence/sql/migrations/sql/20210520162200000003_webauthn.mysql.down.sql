@@ -0,0 +1 @@
+DROP INDEX `identity_credential_identifiers_identifier_nid_type_uq_idx` ON `identity_credential_identifiers`;
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT '730f0991-256a-4bd5-917e-c2dc02caaca3', 'webauthn' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'webauthn');
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" DROP COLUMN "aal";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "authentication_methods" json;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `sessions` ADD COLUMN `authentication_methods` JSON;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" DROP COLUMN "authentication_methods";
\ No newline at end of file
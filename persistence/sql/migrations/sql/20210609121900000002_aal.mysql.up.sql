@@ -0,0 +1 @@
+ALTER TABLE `selfservice_login_flows` ADD COLUMN `requested_aal` VARCHAR (4) NOT NULL DEFAULT 'aal1';
\ No newline at end of file
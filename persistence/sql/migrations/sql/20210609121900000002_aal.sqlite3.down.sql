@@ -0,0 +1 @@
+ALTER TABLE "selfservice_login_flows" DROP COLUMN "requested_aal";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "selfservice_login_flows" ADD COLUMN "requested_aal" TEXT NOT NULL DEFAULT 'aal1';
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT 'f6e28014-12f6-469f-9168-2b98b209eee9', 'lookup_secret' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'lookup_secret');
\ No newline at end of file
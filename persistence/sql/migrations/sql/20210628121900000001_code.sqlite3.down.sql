@@ -0,0 +1 @@
+ALTER TABLE "selfservice_recovery_flows" DROP COLUMN "internal_context";
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT '0b5f7a42-7c0f-4e1e-9b3a-7e5c3b2d1f64', 'code' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'code');
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "selfservice_registration_flows" ADD COLUMN "internal_context" jsonb;
\ No newline at end of file
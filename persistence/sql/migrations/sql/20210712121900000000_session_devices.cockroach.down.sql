@@ -0,0 +1 @@
+ALTER TABLE "sessions" DROP COLUMN "devices";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "devices" json;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "devices" jsonb;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "devices" TEXT;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `identities` ADD COLUMN `state` VARCHAR (255) NOT NULL DEFAULT 'active';
\ No newline at end of file
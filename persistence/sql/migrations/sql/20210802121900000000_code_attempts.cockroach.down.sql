@@ -0,0 +1 @@
+DROP TABLE "selfservice_codes";
@@ -0,0 +1,13 @@
+DROP TABLE "selfservice_codes";
+CREATE TABLE "selfservice_codes" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"flow_id" UUID NOT NULL,
+"attempts" integer NOT NULL DEFAULT '0',
+"used_at" timestamp,
+"nid" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "selfservice_codes_networks_id_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade
+);
+CREATE INDEX "selfservice_codes_flow_id_nid_idx" ON "selfservice_codes" (flow_id, nid);
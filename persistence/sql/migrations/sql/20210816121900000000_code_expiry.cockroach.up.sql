@@ -0,0 +1,12 @@
+DROP TABLE "selfservice_codes";
+CREATE TABLE "selfservice_codes" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"flow_id" UUID NOT NULL,
+"attempts" integer NOT NULL DEFAULT '0',
+"expires_at" timestamp NOT NULL,
+"nid" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "selfservice_codes_networks_id_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON DELETE cascade
+);
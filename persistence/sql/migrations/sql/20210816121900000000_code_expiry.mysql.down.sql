@@ -0,0 +1,13 @@
+DROP TABLE `selfservice_codes`;
+CREATE TABLE `selfservice_codes` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`flow_id` char(36) NOT NULL,
+`attempts` INTEGER NOT NULL DEFAULT 0,
+`used_at` DATETIME,
+`nid` char(36) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON DELETE cascade
+) ENGINE=InnoDB;
+CREATE INDEX `selfservice_codes_flow_id_nid_idx` ON `selfservice_codes` (`flow_id`, `nid`);
@@ -0,0 +1 @@
+CREATE INDEX "selfservice_codes_flow_id_nid_idx" ON "selfservice_codes" (flow_id, nid);
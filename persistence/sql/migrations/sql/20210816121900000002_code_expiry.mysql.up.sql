@@ -0,0 +1 @@
+CREATE INDEX `selfservice_codes_nid_expires_at_idx` ON `selfservice_codes` (`nid`, `expires_at`);
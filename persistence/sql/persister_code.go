@@ -0,0 +1,82 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/x/sqlcon"
+
+	"kratos/selfservice/strategy/code"
+)
+
+var _ code.CodePersister = new(Persister)
+
+func (p *Persister) CreateCode(ctx context.Context, c *code.Code) (int, error) {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+
+	/* #nosec G201 TableName is static */
+	if err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE nid = ? AND expires_at < ?", new(code.Code).TableName(ctx)),
+		nid, time.Now().UTC()).Exec(); err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	c.NID = nid
+	if err := p.GetConnection(ctx).Create(c); err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	// Counting after inserting ensures that concurrent requests can not exceed the limit.
+	count, err := p.GetConnection(ctx).Where("flow_id = ? AND nid = ?", c.FlowID, nid).Count(new(code.Code))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	return count, nil
+}
+
+func (p *Persister) AttemptCode(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+
+	/* #nosec G201 TableName is static */
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, updated_at = ? WHERE id = ? AND nid = ? AND attempts < ?", new(code.Code).TableName(ctx)),
+		time.Now().UTC(), id, nid, maxAttempts).ExecWithCount()
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	} else if count == 0 {
+		return 0, errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	var c code.Code
+	if err := p.GetConnection(ctx).Where("id = ? AND nid = ?", id, nid).First(&c); err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	return c.Attempts, nil
+}
+
+func (p *Persister) UseCode(ctx context.Context, id uuid.UUID) error {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		var c code.Code
+		if err := tx.Where("id = ? AND nid = ?", id, nid).First(&c); err != nil {
+			return err
+		}
+
+		// Deleting the code itself first ensures that it can only be used once, even by concurrent requests.
+		/* #nosec G201 TableName is static */
+		if count, err := tx.RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ? AND nid = ?", c.TableName(ctx)), id, nid).ExecWithCount(); err != nil {
+			return err
+		} else if count == 0 {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+
+		/* #nosec G201 TableName is static */
+		return tx.RawQuery(fmt.Sprintf("DELETE FROM %s WHERE flow_id = ? AND nid = ?", c.TableName(ctx)), c.FlowID, nid).Exec()
+	}))
+}
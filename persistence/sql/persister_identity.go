@@ -59,7 +59,7 @@ func (p *Persister) FindByCredentialsIdentifier(ctx context.Context, ct identity
 	}
 
 	// Force case-insensitivity for identifiers
-	if ct == identity.CredentialsTypePassword {
+	if ct == identity.CredentialsTypePassword || ct == identity.CredentialsTypeWebAuthn || ct == identity.CredentialsTypeCodeAuth {
 		match = strings.ToLower(match)
 	}
 
@@ -135,7 +135,7 @@ func (p *Persister) createIdentityCredentials(ctx context.Context, i *identity.I
 
 		for _, ids := range cred.Identifiers {
 			// Force case-insensitivity for identifiers
-			if cred.Type == identity.CredentialsTypePassword {
+			if cred.Type == identity.CredentialsTypePassword || cred.Type == identity.CredentialsTypeWebAuthn {
 				ids = strings.ToLower(ids)
 			}
 
@@ -144,9 +144,10 @@ func (p *Persister) createIdentityCredentials(ctx context.Context, i *identity.I
 			}
 
 			if err := c.Create(&identity.CredentialIdentifier{
-				Identifier:            ids,
-				IdentityCredentialsID: cred.ID,
-				NID:                   corp.ContextualizeNID(ctx, p.nid),
+				Identifier:                ids,
+				IdentityCredentialsID:     cred.ID,
+				IdentityCredentialsTypeID: ct.ID,
+				NID:                       corp.ContextualizeNID(ctx, p.nid),
 			}); err != nil {
 				return sqlcon.HandleError(err)
 			}
@@ -198,14 +199,87 @@ func (p *Persister) findRecoveryAddresses(ctx context.Context, i *identity.Ident
 	return nil
 }
 
-func (p *Persister) CountIdentities(ctx context.Context) (int64, error) {
-	count, err := p.c.WithContext(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).Count(new(identity.Identity))
+func (p *Persister) CountIdentities(ctx context.Context, params identity.ListIdentityParameters) (int64, error) {
+	q, err := p.identitiesQuery(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := q.Count(new(identity.Identity))
 	if err != nil {
 		return 0, sqlcon.HandleError(err)
 	}
 	return int64(count), nil
 }
 
+// identitiesQuery returns a query which applies the filters of the parameters.
+func (p *Persister) identitiesQuery(ctx context.Context, params identity.ListIdentityParameters) (*pop.Query, error) {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	q := p.GetConnection(ctx).Where("nid = ?", nid)
+
+	if params.CredentialsIdentifier != "" || params.CredentialsIdentifierPrefix != "" {
+		match, value := "ici.identifier = ?", params.CredentialsIdentifier
+		if params.CredentialsIdentifier == "" {
+			match, value = `ici.identifier LIKE ? ESCAPE '!'`, likePrefix(params.CredentialsIdentifierPrefix)
+		}
+
+		/* #nosec G201 TableName is static */
+		q = q.Where(fmt.Sprintf(`id IN (SELECT ic.identity_id FROM %s ic INNER JOIN %s ici ON ici.identity_credential_id = ic.id WHERE ic.nid = ? AND ici.nid = ? AND %s)`,
+			new(identity.Credentials).TableName(ctx), new(identity.CredentialIdentifier).TableName(ctx), match), nid, nid, value)
+	}
+
+	if params.SchemaID != "" {
+		q = q.Where("schema_id = ?", params.SchemaID)
+	}
+
+	if params.Verified != nil {
+		exists := "EXISTS"
+		if !*params.Verified {
+			exists = "NOT EXISTS"
+		}
+
+		/* #nosec G201 TableName is static */
+		q = q.Where(fmt.Sprintf("%s (SELECT 1 FROM %s va WHERE va.identity_id = %s.id AND va.nid = ? AND va.verified = ?)",
+			exists, new(identity.VerifiableAddress).TableName(ctx), new(identity.Identity).TableName(ctx)), nid, true)
+	}
+
+	if !params.CreatedAfter.IsZero() {
+		q = q.Where("created_at >= ?", params.CreatedAfter.UTC())
+	}
+
+	if !params.CreatedBefore.IsZero() {
+		q = q.Where("created_at < ?", params.CreatedBefore.UTC())
+	}
+
+	if params.TraitPath != "" {
+		keys, err := params.TraitPathKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		// The keys only contain letters, digits, and underscores which is why they can be embedded in the query.
+		var trait string
+		switch p.GetConnection(ctx).Dialect.Name() {
+		case "sqlite3":
+			trait = fmt.Sprintf("CAST(json_extract(traits, '$.%s') AS TEXT)", strings.Join(keys, "."))
+		case "mysql":
+			trait = fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(traits, '$.%s'))", strings.Join(keys, "."))
+		default:
+			trait = fmt.Sprintf("traits #>> '{%s}'", strings.Join(keys, ","))
+		}
+
+		/* #nosec G201 the trait path is validated */
+		q = q.Where(fmt.Sprintf("%s = ?", trait), params.TraitValue)
+	}
+
+	return q, nil
+}
+
+// likePrefix returns a LIKE pattern matching values which start with prefix.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`).Replace(prefix) + "%"
+}
+
 func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) error {
 	i.NID = corp.ContextualizeNID(ctx, p.nid)
 
@@ -217,6 +291,10 @@ func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) er
 		i.Traits = identity.Traits("{}")
 	}
 
+	if i.State == "" {
+		i.State = identity.StateActive
+	}
+
 	if err := p.injectTraitsSchemaURL(ctx, i); err != nil {
 		return err
 	}
@@ -242,13 +320,22 @@ func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) er
 	})
 }
 
-func (p *Persister) ListIdentities(ctx context.Context, page, perPage int) ([]identity.Identity, error) {
+func (p *Persister) ListIdentities(ctx context.Context, params identity.ListIdentityParameters) ([]identity.Identity, error) {
 	is := make([]identity.Identity, 0)
 
-	/* #nosec G201 TableName is static */
-	if err := sqlcon.HandleError(p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).
-		Paginate(page, perPage).Order("id DESC").
-		All(&is)); err != nil {
+	q, err := p.identitiesQuery(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.PageToken != uuid.Nil {
+		// Keyset pagination does not need to skip rows which keeps it fast for large offsets.
+		q = q.Where("id < ?", params.PageToken).Limit(x.MaxItemsPerPage(params.PerPage))
+	} else {
+		q = q.Paginate(params.Page, params.PerPage)
+	}
+
+	if err := sqlcon.HandleError(q.Order("id DESC").All(&is)); err != nil {
 		return nil, err
 	}
 
@@ -273,11 +360,29 @@ func (p *Persister) ListIdentities(ctx context.Context, page, perPage int) ([]id
 }
 
 func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) error {
+	return p.updateIdentity(ctx, i, nil)
+}
+
+func (p *Persister) UpdateIdentityIfUnmodified(ctx context.Context, i *identity.Identity, updatedAt time.Time) error {
+	return p.updateIdentity(ctx, i, &updatedAt)
+}
+
+func (p *Persister) updateIdentity(ctx context.Context, i *identity.Identity, ifUnmodified *time.Time) error {
 	if err := p.validateIdentity(ctx, i); err != nil {
 		return err
 	}
 
+	if i.State == "" {
+		i.State = identity.StateActive
+	}
+
 	i.NID = corp.ContextualizeNID(ctx, p.nid)
+	i.UpdatedAt = time.Now().UTC()
+	if ifUnmodified != nil && !i.UpdatedAt.Truncate(time.Second).After(*ifUnmodified) {
+		// Some databases only store seconds, so the timestamp has to advance by a second for the change to be detected.
+		i.UpdatedAt = ifUnmodified.Truncate(time.Second).Add(time.Second)
+	}
+
 	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
 		if count, err := tx.Where("id = ? AND nid = ?", i.ID, corp.ContextualizeNID(ctx, p.nid)).Count(i); err != nil {
 			return err
@@ -285,6 +390,18 @@ func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) er
 			return sql.ErrNoRows
 		}
 
+		if ifUnmodified != nil {
+			// Writing to the identity first locks its row until the transaction completes.
+			/* #nosec G201 TableName is static */
+			if count, err := tx.RawQuery(fmt.Sprintf(
+				"UPDATE %s SET updated_at = ? WHERE id = ? AND nid = ? AND updated_at = ?", i.TableName(ctx)),
+				i.UpdatedAt, i.ID, corp.ContextualizeNID(ctx, p.nid), *ifUnmodified).ExecWithCount(); err != nil {
+				return err
+			} else if count == 0 {
+				return errors.WithStack(identity.ErrIdentityModified)
+			}
+		}
+
 		for _, tn := range []string{
 			new(identity.Credentials).TableName(ctx),
 			new(identity.VerifiableAddress).TableName(ctx),
@@ -309,7 +426,71 @@ func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) er
 			return err
 		}
 
-		return p.createIdentityCredentials(ctx, i)
+		if err := p.createIdentityCredentials(ctx, i); err != nil {
+			return err
+		}
+
+		if i.State != identity.StateInactive {
+			return nil
+		}
+
+		_, err := p.RevokeSessionsByIdentity(ctx, i.ID, uuid.Nil)
+		return err
+	}))
+}
+
+func (p *Persister) UpdateIdentityState(ctx context.Context, id uuid.UUID, state identity.State) error {
+	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		/* #nosec G201 TableName is static */
+		if count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET state = ?, updated_at = ? WHERE id = ? AND nid = ?", new(identity.Identity).TableName(ctx)),
+			state, time.Now().UTC(), id, corp.ContextualizeNID(ctx, p.nid)).ExecWithCount(); err != nil {
+			return err
+		} else if count == 0 {
+			return sql.ErrNoRows
+		}
+
+		if state != identity.StateInactive {
+			return nil
+		}
+
+		_, err := p.RevokeSessionsByIdentity(ctx, id, uuid.Nil)
+		return err
+	}))
+}
+
+func (p *Persister) UpdateCredentialsConfig(ctx context.Context, id uuid.UUID, ct identity.CredentialsType, update func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error)) error {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	return sqlcon.HandleError(p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		// Writing to the identity first locks its row until the transaction completes.
+		/* #nosec G201 TableName is static */
+		if count, err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET updated_at = ? WHERE id = ? AND nid = ?", new(identity.Identity).TableName(ctx)),
+			time.Now().UTC(), id, nid).ExecWithCount(); err != nil {
+			return err
+		} else if count == 0 {
+			return sql.ErrNoRows
+		}
+
+		t, err := p.findIdentityCredentialsType(ctx, ct)
+		if err != nil {
+			return err
+		}
+
+		var c identity.Credentials
+		if err := tx.Where("identity_id = ? AND nid = ? AND identity_credential_type_id = ?", id, nid, t.ID).First(&c); err != nil {
+			return err
+		}
+
+		config, err := update(c.Config)
+		if err != nil {
+			return err
+		}
+
+		/* #nosec G201 TableName is static */
+		return tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET config = ?, updated_at = ? WHERE id = ? AND nid = ?", c.TableName(ctx)),
+			config, time.Now().UTC(), c.ID, nid).Exec()
 	}))
 }
 
@@ -3,11 +3,13 @@ package sql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/corp"
 
+	"github.com/gobuffalo/pop/v5"
 	"github.com/gofrs/uuid"
 
 	"github.com/ory/x/sqlcon"
@@ -40,6 +42,49 @@ func (p *Persister) CreateSession(ctx context.Context, s *session.Session) error
 	return p.GetConnection(ctx).Create(s) // This must not be eager or identities will be created / updated
 }
 
+func (p *Persister) UpdateSession(ctx context.Context, s *session.Session) error {
+	s.NID = corp.ContextualizeNID(ctx, p.nid)
+	return p.update(ctx, s)
+}
+
+func (p *Persister) UpdateSessionDevices(ctx context.Context, sID uuid.UUID, devices session.Devices) error {
+	// #nosec G201
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET devices = ? WHERE id = ? AND nid = ?",
+		corp.ContextualizeTableName(ctx, "sessions"),
+	),
+		devices,
+		sID,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) ExtendSession(ctx context.Context, sID uuid.UUID, expiresAt time.Time) error {
+	// #nosec G201
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET expires_at = ? WHERE id = ? AND nid = ? AND active = true",
+		corp.ContextualizeTableName(ctx, "sessions"),
+	),
+		expiresAt,
+		sID,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
 func (p *Persister) DeleteSession(ctx context.Context, sid uuid.UUID) error {
 	return p.delete(ctx, new(session.Session), sid)
 }
@@ -116,3 +161,85 @@ func (p *Persister) RevokeSessionByToken(ctx context.Context, token string) erro
 	}
 	return nil
 }
+
+func (p *Persister) sessionsByIdentityQuery(ctx context.Context, iID uuid.UUID, active *bool, except uuid.UUID) *pop.Query {
+	q := p.GetConnection(ctx).Where("identity_id = ? AND nid = ?", iID, corp.ContextualizeNID(ctx, p.nid))
+	if active != nil {
+		// Expired sessions are not active either, see session.Session.IsActive.
+		if *active {
+			q = q.Where("active = ? AND expires_at > ?", true, time.Now().UTC())
+		} else {
+			q = q.Where("(active = ? OR expires_at <= ?)", false, time.Now().UTC())
+		}
+	}
+	if except != uuid.Nil {
+		q = q.Where("id != ?", except)
+	}
+	return q
+}
+
+func (p *Persister) ListSessionsByIdentity(ctx context.Context, iID uuid.UUID, active *bool, page, perPage int, except uuid.UUID) ([]*session.Session, error) {
+	ss := make([]*session.Session, 0)
+	if err := p.sessionsByIdentityQuery(ctx, iID, active, except).
+		Paginate(page, perPage).Order("created_at DESC").
+		All(&ss); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	if len(ss) == 0 {
+		return ss, nil
+	}
+
+	// All sessions belong to the same identity, so it is fetched only once.
+	i, err := p.GetIdentity(ctx, iID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range ss {
+		s.Identity = i
+	}
+	return ss, nil
+}
+
+func (p *Persister) CountSessionsByIdentity(ctx context.Context, iID uuid.UUID, active *bool, except uuid.UUID) (int64, error) {
+	count, err := p.sessionsByIdentityQuery(ctx, iID, active, except).Count(new(session.Session))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return int64(count), nil
+}
+
+func (p *Persister) RevokeSessionByID(ctx context.Context, sID uuid.UUID) error {
+	// #nosec G201
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET active = false WHERE id = ? AND nid = ?",
+		corp.ContextualizeTableName(ctx, "sessions"),
+	),
+		sID,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+func (p *Persister) RevokeSessionsByIdentity(ctx context.Context, iID uuid.UUID, except uuid.UUID) (int, error) {
+	// #nosec G201
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET active = false WHERE identity_id = ? AND nid = ? AND active = true AND id != ?",
+		corp.ContextualizeTableName(ctx, "sessions"),
+	),
+		iID,
+		corp.ContextualizeNID(ctx, p.nid),
+		except,
+	).ExecWithCount()
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return count, nil
+}
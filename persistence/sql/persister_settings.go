@@ -27,11 +27,14 @@ func (p *Persister) GetSettingsFlow(ctx context.Context, id uuid.UUID) (*setting
 		return nil, sqlcon.HandleError(err)
 	}
 
-	r.Identity, err = p.GetIdentity(ctx, r.IdentityID)
+	i, err := p.GetIdentity(ctx, r.IdentityID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Settings flows are shown to the identity, which must not see the admin metadata.
+	r.Identity = i.Declassify()
+
 	return &r, nil
 }
 
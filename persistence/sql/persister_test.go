@@ -34,6 +34,7 @@ import (
 	registration "kratos/selfservice/flow/registration/test"
 	settings "kratos/selfservice/flow/settings/test"
 	verification "kratos/selfservice/flow/verification/test"
+	code "kratos/selfservice/strategy/code/test"
 	link "kratos/selfservice/strategy/link/test"
 	session "kratos/session/test"
 	"kratos/x"
@@ -191,6 +192,10 @@ func TestPersister(t *testing.T) {
 				pop.SetLogger(pl(t))
 				link.TestPersister(ctx, conf, p)(t)
 			})
+			t.Run("contract=code.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				code.TestPersister(ctx, p)(t)
+			})
 			t.Run("contract=continuity.TestPersister", func(t *testing.T) {
 				pop.SetLogger(pl(t))
 				continuity.TestPersister(ctx, p)(t)
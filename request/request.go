@@ -0,0 +1,57 @@
+package request
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/httpx"
+)
+
+// Auth is the `auth` section of the configuration of a request to an external endpoint, such as a web hook or
+// an SMS provider.
+type Auth struct {
+	Type   string `json:"type"`
+	Config struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+		Name     string `json:"name"`
+		Value    string `json:"value"`
+		In       string `json:"in"`
+	} `json:"config"`
+}
+
+// Apply adds the configured credentials to the request.
+func (a *Auth) Apply(req *http.Request) error {
+	switch a.Type {
+	case "":
+	case "basic_auth":
+		req.SetBasicAuth(a.Config.User, a.Config.Password)
+	case "api_key":
+		if a.Config.In == "cookie" {
+			req.AddCookie(&http.Cookie{Name: a.Config.Name, Value: a.Config.Value})
+		} else {
+			req.Header.Set(a.Config.Name, a.Config.Value)
+		}
+	default:
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unsupported request authentication type: %s", a.Type))
+	}
+	return nil
+}
+
+// NewClient returns a client which retries connection errors and server errors. The waits between retries are
+// kept short as the requests are sent while a user or the courier waits for the response, callers should limit
+// the retries by setting a deadline on the request context.
+func NewClient(timeout time.Duration) *retryablehttp.Client {
+	c := httpx.NewResilientClient(
+		httpx.ResilientClientWithConnectionTimeout(timeout),
+		httpx.ResilientClientWithMinxRetryWait(100*time.Millisecond),
+		httpx.ResilientClientWithMaxRetryWait(time.Second),
+	)
+	// Returns the last response once the retries are used up so that its status code is reported.
+	c.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	return c
+}
@@ -0,0 +1,90 @@
+package request_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kratos/request"
+)
+
+func TestAuth(t *testing.T) {
+	for k, tc := range []struct {
+		config string
+		check  func(t *testing.T, r *http.Request)
+	}{
+		{
+			config: `{}`,
+			check: func(t *testing.T, r *http.Request) {
+				assert.Empty(t, r.Header.Get("Authorization"))
+				assert.Empty(t, r.Cookies())
+			},
+		},
+		{
+			config: `{"type": "basic_auth", "config": {"user": "foo", "password": "bar"}}`,
+			check: func(t *testing.T, r *http.Request) {
+				user, password, ok := r.BasicAuth()
+				require.True(t, ok)
+				assert.Equal(t, "foo", user)
+				assert.Equal(t, "bar", password)
+			},
+		},
+		{
+			config: `{"type": "api_key", "config": {"name": "X-Api-Key", "value": "secret"}}`,
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+			},
+		},
+		{
+			config: `{"type": "api_key", "config": {"name": "api_key", "value": "secret", "in": "cookie"}}`,
+			check: func(t *testing.T, r *http.Request) {
+				c, err := r.Cookie("api_key")
+				require.NoError(t, err)
+				assert.Equal(t, "secret", c.Value)
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			var a request.Auth
+			require.NoError(t, json.Unmarshal([]byte(tc.config), &a))
+
+			r := httptest.NewRequest("POST", "https://www.ory.sh/", nil)
+			require.NoError(t, a.Apply(r))
+			tc.check(t, r)
+		})
+	}
+
+	t.Run("case=fails on an unknown type", func(t *testing.T) {
+		a := request.Auth{Type: "oauth2"}
+		require.Error(t, a.Apply(httptest.NewRequest("POST", "https://www.ory.sh/", nil)))
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(ts.Close)
+
+	req, err := retryablehttp.NewRequest("POST", ts.URL, nil)
+	require.NoError(t, err)
+
+	res, err := request.NewClient(time.Second).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
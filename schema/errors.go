@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -15,6 +16,42 @@ type ValidationError struct {
 	Messages text.Messages
 }
 
+// ValidationListError groups validation errors which may each point to a different field.
+type ValidationListError struct {
+	Validations []*ValidationError
+}
+
+func NewValidationListError() *ValidationListError {
+	return new(ValidationListError)
+}
+
+func (e *ValidationListError) Error() string {
+	reasons := make([]string, len(e.Validations))
+	for k, v := range e.Validations {
+		reasons[k] = v.Error()
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func (e *ValidationListError) Add(v *ValidationError) {
+	e.Validations = append(e.Validations, v)
+}
+
+func (e *ValidationListError) HasErrors() bool {
+	return len(e.Validations) > 0
+}
+
+// NewHookValidationError is returned by hooks rejecting the value at the given instance pointer.
+func NewHookValidationError(instancePtr, message string, messages text.Messages) *ValidationError {
+	return &ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     message,
+			InstancePtr: instancePtr,
+		},
+		Messages: messages,
+	}
+}
+
 func NewMinLengthError(instancePtr string, expected, actual int) error {
 	return errors.WithStack(&ValidationError{
 		ValidationError: &jsonschema.ValidationError{
@@ -59,6 +96,127 @@ func NewTOTPVerifierWrongError(instancePtr string) error {
 	})
 }
 
+func NewTOTPAlreadyUsedError(instancePtr string) error {
+	t := text.NewErrorValidationTOTPAlreadyUsed()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewTOTPTooManyAttemptsError(instancePtr string) error {
+	t := text.NewErrorValidationTOTPTooManyAttempts()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewWebAuthnVerifierWrongError(instancePtr string) error {
+	t := text.NewErrorValidationWebAuthnVerifierWrong()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewWebAuthnClonedError(instancePtr string) error {
+	t := text.NewErrorValidationWebAuthnCloned()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewLookupAlreadyUsedError(instancePtr string) error {
+	t := text.NewErrorValidationLookupAlreadyUsed()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewLookupInvalidError(instancePtr string) error {
+	t := text.NewErrorValidationLookupInvalid()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewCodeInvalidError(instancePtr string) error {
+	t := text.NewErrorValidationCodeInvalid()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewCodeExpiredError(instancePtr string) error {
+	t := text.NewErrorValidationCodeExpired()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewCodeTooManyAttemptsError(instancePtr string) error {
+	t := text.NewErrorValidationCodeTooManyAttempts()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewCodeTooManySentError(instancePtr string) error {
+	t := text.NewErrorValidationCodeTooManySent()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: instancePtr,
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
+func NewIdentityInactiveError() error {
+	t := text.NewErrorValidationIdentityInactive()
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     t.Text,
+			InstancePtr: "#/",
+		},
+		Messages: new(text.Messages).Add(t),
+	})
+}
+
 type ValidationErrorContextPasswordPolicyViolation struct {
 	Reason string
 }
@@ -80,6 +238,26 @@ func NewPasswordPolicyViolationError(instancePtr string, reason string) error {
 	})
 }
 
+// NewPasswordPolicyRulesViolationError reports each violated rule of the password policy as its own message.
+func NewPasswordPolicyRulesViolationError(instancePtr string, messages text.Messages) error {
+	reasons := make([]string, len(messages))
+	for k, m := range messages {
+		reasons[k] = m.Text
+	}
+	reason := strings.Join(reasons, " ")
+
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     fmt.Sprintf("the password does not fulfill the password policy because: %s", reason),
+			InstancePtr: instancePtr,
+			Context: &ValidationErrorContextPasswordPolicyViolation{
+				Reason: reason,
+			},
+		},
+		Messages: messages,
+	})
+}
+
 type ValidationErrorContextInvalidCredentialsError struct{}
 
 func (r *ValidationErrorContextInvalidCredentialsError) AddContext(_, _ string) {}
@@ -25,6 +25,12 @@ type (
 			Password struct {
 				Identifier bool `json:"identifier"`
 			} `json:"password"`
+			WebAuthn struct {
+				Identifier bool `json:"identifier"`
+			} `json:"webauthn"`
+			Code struct {
+				Identifier bool `json:"identifier"`
+			} `json:"code"`
 		} `json:"credentials"`
 		Verification struct {
 			Via string `json:"via"`
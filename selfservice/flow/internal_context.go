@@ -0,0 +1,25 @@
+package flow
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/x/sqlxx"
+)
+
+// SetInternalContext sets the value at the given path of a flow's internal context
+// and returns the updated internal context.
+func SetInternalContext(ic sqlxx.NullJSONRawMessage, path string, value interface{}) (sqlxx.NullJSONRawMessage, error) {
+	raw := []byte(ic)
+	if !gjson.ParseBytes(raw).IsObject() {
+		raw = []byte("{}")
+	}
+
+	raw, err := sjson.SetBytes(raw, path, value)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return raw, nil
+}
@@ -24,6 +24,15 @@ import (
 var (
 	ErrHookAbortFlow   = errors.New("aborted login hook execution")
 	ErrAlreadyLoggedIn = herodot.ErrBadRequest.WithReason("A valid session was detected and thus login is not possible. Did you forget to set `?refresh=true`?")
+
+	// ErrSecondFactorRequired is returned when the identity has to complete a second authentication factor.
+	ErrSecondFactorRequired = errors.New("a second authentication factor is required")
+
+	// ErrSessionRequiredForHigherAAL is returned when a higher authenticator assurance level is requested without a session.
+	ErrSessionRequiredForHigherAAL = herodot.ErrUnauthorized.WithReason("A higher authenticator assurance level can only be requested with a valid session. Please log in first.")
+
+	// ErrNoSecondFactorAvailable is returned when a higher authenticator assurance level is requested but the identity has not set up a second factor.
+	ErrNoSecondFactorAvailable = herodot.ErrBadRequest.WithReason("The identity has not set up a second authentication factor and can thus not complete the requested authenticator assurance level.")
 )
 
 type (
@@ -16,13 +16,21 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/stringsx"
 	"github.com/ory/x/urlx"
+	"github.com/tidwall/gjson"
 
 	"kratos/identity"
 	"kratos/selfservice/flow"
 	"kratos/x"
 )
 
+const (
+	internalContextPendingSecondFactor  = "second_factor.identity_id"
+	internalContextCompletedFirstFactor = "second_factor.completed_first_factor"
+)
+
 // Login Flow
 //
 // This object represents a login flow. A login flow is initiated at the "Initiate Login API / Browser Flow"
@@ -82,6 +90,13 @@ type Flow struct {
 
 	// Forced stores whether this login flow should enforce re-authentication.
 	Forced bool `json:"forced" db:"forced"`
+
+	// RequestedAAL is the authenticator assurance level requested by this flow. If set to "aal2",
+	// an existing session is stepped up by completing a second authentication factor.
+	RequestedAAL identity.AuthenticatorAssuranceLevel `json:"requested_aal" faker:"-" db:"requested_aal"`
+
+	// InternalContext stores internal context used by internals - for example MFA keys.
+	InternalContext sqlxx.NullJSONRawMessage `json:"-" faker:"-" db:"internal_context"`
 }
 
 func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Request, flowType flow.Type) *Flow {
@@ -99,6 +114,10 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 		CSRFToken:  csrf,
 		Type:       flowType,
 		Forced:     r.URL.Query().Get("refresh") == "true",
+
+		RequestedAAL: identity.AuthenticatorAssuranceLevel(stringsx.Coalesce(r.URL.Query().Get("aal"), identity.AuthenticatorAssuranceLevel1.String())),
+
+		InternalContext: []byte("{}"),
 	}
 }
 
@@ -140,3 +159,52 @@ func (f *Flow) AppendTo(src *url.URL) *url.URL {
 func (f Flow) GetNID() uuid.UUID {
 	return f.NID
 }
+
+// PendingSecondFactor returns the ID of the identity which completed the first
+// authentication factor in this flow but still has to complete a second factor.
+// If no second factor is pending, uuid.Nil is returned.
+func (f *Flow) PendingSecondFactor() uuid.UUID {
+	return x.ParseUUID(gjson.GetBytes(f.InternalContext, internalContextPendingSecondFactor).String())
+}
+
+// SetPendingSecondFactor marks the flow as waiting for the given identity to complete
+// a second authentication factor. The method used to complete the first factor in this
+// flow is remembered so that it can be recorded in the session. It is empty if the flow
+// steps up an existing session.
+func (f *Flow) SetPendingSecondFactor(id uuid.UUID, completed identity.CredentialsType) error {
+	ic, err := flow.SetInternalContext(f.InternalContext, internalContextPendingSecondFactor, id.String())
+	if err != nil {
+		return err
+	}
+
+	ic, err = flow.SetInternalContext(ic, internalContextCompletedFirstFactor, completed)
+	if err != nil {
+		return err
+	}
+
+	f.InternalContext = ic
+	return nil
+}
+
+// ResetPendingSecondFactor invalidates the pending second factor so that the first
+// authentication factor has to be completed again.
+func (f *Flow) ResetPendingSecondFactor() error {
+	ic, err := flow.SetInternalContext(f.InternalContext, internalContextPendingSecondFactor, nil)
+	if err != nil {
+		return err
+	}
+
+	ic, err = flow.SetInternalContext(ic, internalContextCompletedFirstFactor, nil)
+	if err != nil {
+		return err
+	}
+
+	f.InternalContext = ic
+	return nil
+}
+
+// CompletedFirstFactor returns the method which was used to complete the first
+// authentication factor in this flow if a second factor is pending.
+func (f *Flow) CompletedFirstFactor() identity.CredentialsType {
+	return identity.CredentialsType(gjson.GetBytes(f.InternalContext, internalContextCompletedFirstFactor).String())
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 
+	"github.com/ory/herodot"
+
 	"github.com/ory/x/urlx"
 
 	"kratos/driver/config"
@@ -76,14 +78,33 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 func (h *Handler) NewLoginFlow(w http.ResponseWriter, r *http.Request, flow flow.Type) (*Flow, error) {
 	conf := h.d.Config(r.Context())
 	f := NewFlow(conf, conf.SelfServiceFlowLoginRequestLifespan(), h.d.GenerateCSRFToken(r), r, flow)
-	for _, s := range h.d.LoginStrategies(r.Context()) {
-		if err := s.PopulateLoginMethod(r, f); err != nil {
+	switch f.RequestedAAL {
+	case identity.AuthenticatorAssuranceLevel1:
+		for _, s := range h.d.LoginStrategies(r.Context()) {
+			if err := s.PopulateLoginMethod(r, f); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := sortNodes(f.UI.Nodes); err != nil {
 			return nil, err
 		}
-	}
+	case identity.AuthenticatorAssuranceLevel2:
+		sess, err := h.d.SessionManager().FetchFromRequest(r.Context(), r)
+		if err != nil {
+			return nil, errors.WithStack(ErrSessionRequiredForHigherAAL)
+		}
 
-	if err := sortNodes(f.UI.Nodes); err != nil {
-		return nil, err
+		// If the session satisfies the requested level already, the caller responds accordingly.
+		if sess.AuthenticatorAssuranceLevel.Rank() < f.RequestedAAL.Rank() {
+			if available, err := h.d.LoginHookExecutor().populateSecondFactor(r, f, sess.IdentityID, ""); err != nil {
+				return nil, err
+			} else if !available {
+				return nil, errors.WithStack(ErrNoSecondFactorAvailable)
+			}
+		}
+	default:
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse the requested authenticator assurance level (AAL): %s", f.RequestedAAL))
 	}
 
 	if err := h.d.LoginHookExecutor().PreLoginHook(w, r, f); err != nil {
@@ -107,6 +128,14 @@ type initializeSelfServiceBrowserLoginFlow struct {
 	//
 	// in: query
 	Refresh bool `json:"refresh"`
+
+	// Request a Specific Authenticator Assurance Level
+	//
+	// Set to "aal2" to step up an existing session by completing a second authentication factor.
+	// Requires a valid session. Defaults to "aal1".
+	//
+	// in: query
+	RequestedAAL string `json:"aal"`
 }
 
 // swagger:route GET /self-service/login/api public initializeSelfServiceLoginViaAPIFlow
@@ -116,7 +145,8 @@ type initializeSelfServiceBrowserLoginFlow struct {
 // This endpoint initiates a login flow for API clients such as mobile devices, smart TVs, and so on.
 //
 // If a valid provided session cookie or session token is provided, a 400 Bad Request error
-// will be returned unless the URL query parameter `?refresh=true` is set.
+// will be returned unless the URL query parameter `?refresh=true` is set, or `?aal=aal2` is set
+// and the session has not yet completed a second authentication factor.
 //
 // To fetch an existing login flow call `/self-service/login/flows?flow=<flow_id>`.
 //
@@ -146,7 +176,13 @@ func (h *Handler) initAPIFlow(w http.ResponseWriter, r *http.Request, _ httprout
 	}
 
 	// we assume an error means the user has no session
-	if _, err := h.d.SessionManager().FetchFromRequest(r.Context(), r); err != nil {
+	sess, err := h.d.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.d.Writer().Write(w, r, a)
+		return
+	}
+
+	if a.RequestedAAL.Rank() > sess.AuthenticatorAssuranceLevel.Rank() {
 		h.d.Writer().Write(w, r, a)
 		return
 	}
@@ -170,7 +206,7 @@ func (h *Handler) initAPIFlow(w http.ResponseWriter, r *http.Request, _ httprout
 // This endpoint initializes a browser-based user login flow. Once initialized, the browser will be redirected to
 // `selfservice.flows.login.ui_url` with the flow ID set as the query parameter `?flow=`. If a valid user session
 // exists already, the browser will be redirected to `urls.default_redirect_url` unless the query parameter
-// `?refresh=true` was set.
+// `?refresh=true` was set, or `?aal=aal2` was set and the session has not yet completed a second authentication factor.
 //
 // This endpoint is NOT INTENDED for API clients and only works with browsers (Chrome, Firefox, ...).
 //
@@ -189,7 +225,13 @@ func (h *Handler) initBrowserFlow(w http.ResponseWriter, r *http.Request, ps htt
 	}
 
 	// we assume an error means the user has no session
-	if _, err := h.d.SessionManager().FetchFromRequest(r.Context(), r); err != nil {
+	sess, err := h.d.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		http.Redirect(w, r, a.AppendTo(h.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusFound)
+		return
+	}
+
+	if a.RequestedAAL.Rank() > sess.AuthenticatorAssuranceLevel.Rank() {
 		http.Redirect(w, r, a.AppendTo(h.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusFound)
 		return
 	}
@@ -338,7 +380,7 @@ func (h *Handler) submitFlow(w http.ResponseWriter, r *http.Request, _ httproute
 		return
 	}
 
-	if _, err := h.d.SessionManager().FetchFromRequest(r.Context(), r); err == nil && !f.Forced {
+	if sess, err := h.d.SessionManager().FetchFromRequest(r.Context(), r); err == nil && !f.Forced && f.RequestedAAL.Rank() <= sess.AuthenticatorAssuranceLevel.Rank() {
 		if f.Type == flow.TypeBrowser {
 			http.Redirect(w, r, h.d.Config(r.Context()).SelfServiceBrowserDefaultReturnTo().String(), http.StatusFound)
 			return
@@ -376,7 +418,10 @@ func (h *Handler) submitFlow(w http.ResponseWriter, r *http.Request, _ httproute
 		return
 	}
 
-	// TODO Handle n+1 authentication factor
+	if !i.IsActive() {
+		h.d.LoginFlowErrorHandler().WriteFlowError(w, r, f, node.DefaultGroup, schema.NewIdentityInactiveError())
+		return
+	}
 
 	if err := h.d.LoginHookExecutor().PostLoginHook(w, r, s, f, i); err != nil {
 		h.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
@@ -6,12 +6,16 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
 	"kratos/driver/config"
 	"kratos/identity"
+	"kratos/schema"
 	"kratos/selfservice/flow"
 	"kratos/session"
+	"kratos/text"
+	"kratos/ui/node"
 	"kratos/x"
 )
 
@@ -33,12 +37,15 @@ type (
 type (
 	executorDependencies interface {
 		config.Provider
+		identity.PrivilegedPoolProvider
 		session.ManagementProvider
 		session.PersistenceProvider
 		x.WriterProvider
 		x.LoggingProvider
 
+		FlowPersistenceProvider
 		HooksProvider
+		StrategyProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -61,7 +68,21 @@ func NewHookExecutor(d executorDependencies) *HookExecutor {
 }
 
 func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity) error {
-	s := session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC()).Declassify()
+	// Strategies which complete the login themselves, such as OpenID Connect, call this method directly.
+	if !i.IsActive() {
+		return schema.NewIdentityInactiveError()
+	}
+
+	if err := e.requireSecondFactor(w, r, ct, a, i); errors.Is(err, ErrSecondFactorRequired) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	s, stepUp, err := e.sessionFor(r, ct, a, i)
+	if err != nil {
+		return err
+	}
 
 	e.d.Logger().
 		WithRequest(r).
@@ -94,33 +115,163 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, ct
 			Debug("ExecuteLoginPostHook completed successfully.")
 	}
 
-	if a.Type == flow.TypeAPI {
-		if err := e.d.SessionPersister().CreateSession(r.Context(), s); err != nil {
+	if stepUp {
+		if err := e.d.SessionPersister().UpdateSession(r.Context(), s); err != nil {
 			return errors.WithStack(err)
 		}
+	}
+
+	if a.Type == flow.TypeAPI {
+		if !stepUp {
+			if err := e.d.SessionPersister().CreateSession(r.Context(), s); err != nil {
+				return errors.WithStack(err)
+			}
+		}
 		e.d.Audit().
 			WithRequest(r).
 			WithField("session_id", s.ID).
 			WithField("identity_id", i.ID).
+			WithField("aal", s.AuthenticatorAssuranceLevel).
 			Info("Identity authenticated successfully and was issued an Ory Kratos Session Token.")
 
 		e.d.Writer().Write(w, r, &APIFlowResponse{Session: s, Token: s.Token})
 		return nil
 	}
 
-	if err := e.d.SessionManager().CreateAndIssueCookie(r.Context(), w, r, s); err != nil {
-		return errors.WithStack(err)
+	if !stepUp {
+		if err := e.d.SessionManager().CreateAndIssueCookie(r.Context(), w, r, s); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 
 	e.d.Audit().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
 		WithField("session_id", s.ID).
+		WithField("aal", s.AuthenticatorAssuranceLevel).
 		Info("Identity authenticated successfully and was issued an Ory Kratos Session Cookie.")
 	return x.SecureContentNegotiationRedirection(w, r, s.Declassify(), a.RequestURL,
 		e.d.Writer(), e.d.Config(r.Context()), x.SecureRedirectOverrideDefaultReturnTo(e.d.Config(r.Context()).SelfServiceFlowLoginReturnTo(ct.String())))
 }
 
+// sessionFor returns the session which records the completed authentication method. If the flow steps up
+// the identity's existing session, that session is returned and stepUp is true. Otherwise a new session is issued.
+func (e *HookExecutor) sessionFor(r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity) (s *session.Session, stepUp bool, err error) {
+	aal := identity.AuthenticatorAssuranceLevel1
+	if a.PendingSecondFactor() != uuid.Nil {
+		aal = identity.AuthenticatorAssuranceLevel2
+	}
+
+	first := a.CompletedFirstFactor()
+	if aal == identity.AuthenticatorAssuranceLevel2 && len(first) == 0 {
+		// The second factor was requested to step up an existing session.
+		current, err := e.d.SessionManager().FetchFromRequest(r.Context(), r)
+		if err != nil || current.IdentityID != i.ID {
+			return nil, false, errors.WithStack(ErrSessionRequiredForHigherAAL)
+		}
+
+		current.CompletedLoginFor(ct, aal)
+		return current.Declassify(), true, nil
+	}
+
+	s = session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC())
+	s.SeenFrom(r, e.d.Config(r.Context()), s.IssuedAt)
+	if len(first) > 0 {
+		s.CompletedLoginFor(first, identity.AuthenticatorAssuranceLevel1)
+	}
+	s.CompletedLoginFor(ct, aal)
+	return s.Declassify(), false, nil
+}
+
+// requireSecondFactor returns ErrSecondFactorRequired and updates the flow with the second factor
+// challenge if the identity has set up a second factor which was not yet completed in this flow.
+func (e *HookExecutor) requireSecondFactor(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity) error {
+	for _, s := range e.d.LoginStrategies(r.Context()) {
+		if sf, ok := s.(SecondFactorStrategy); ok && sf.ID() == ct {
+			// The identity has just completed this second factor.
+			return nil
+		}
+	}
+
+	if required, err := e.populateSecondFactor(r, a, i.ID, ct); err != nil {
+		return err
+	} else if !required {
+		return nil
+	}
+
+	a.Active = ct
+	if err := e.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), a); err != nil {
+		return err
+	}
+
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		WithField("flow_method", ct).
+		Debug("Identity completed the first authentication factor and needs to complete a second factor.")
+
+	if a.Type == flow.TypeAPI {
+		e.d.Writer().Write(w, r, a)
+	} else {
+		http.Redirect(w, r, a.AppendTo(e.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusFound)
+	}
+
+	return errors.WithStack(ErrSecondFactorRequired)
+}
+
+// populateSecondFactor replaces the flow's nodes with the challenges of all second factors the identity
+// has set up and marks the second factor as pending. The completed method is empty if an existing session
+// is stepped up. It returns false if the identity has not set up any second factor.
+func (e *HookExecutor) populateSecondFactor(r *http.Request, a *Flow, id uuid.UUID, completed identity.CredentialsType) (bool, error) {
+	var strategies []SecondFactorStrategy
+	for _, s := range e.d.LoginStrategies(r.Context()) {
+		if sf, ok := s.(SecondFactorStrategy); ok {
+			strategies = append(strategies, sf)
+		}
+	}
+
+	if len(strategies) == 0 {
+		return false, nil
+	}
+
+	ci, err := e.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id)
+	if err != nil {
+		return false, err
+	}
+
+	var required bool
+	for _, s := range strategies {
+		if !s.HasSecondFactor(r.Context(), ci) {
+			continue
+		}
+
+		if !required {
+			a.UI.Nodes = node.Nodes{}
+			a.UI.ResetMessages()
+			required = true
+		}
+
+		if err := s.PopulateSecondFactorMethod(r, ci, a); err != nil {
+			return false, err
+		}
+	}
+
+	if !required {
+		return false, nil
+	}
+
+	if err := a.SetPendingSecondFactor(id, completed); err != nil {
+		return false, err
+	}
+
+	a.UI.Messages.Add(text.NewInfoLoginMFA())
+	if err := sortNodes(a.UI.Nodes); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (e *HookExecutor) PreLoginHook(w http.ResponseWriter, r *http.Request, a *Flow) error {
 	for _, executor := range e.d.PreLoginHooks(r.Context()) {
 		if err := executor.ExecuteLoginPreHook(w, r, a); err != nil {
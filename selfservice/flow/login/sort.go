@@ -8,6 +8,10 @@ func sortNodes(n node.Nodes) error {
 			node.DefaultGroup,
 			node.OpenIDConnectGroup,
 			node.PasswordGroup,
+			node.TOTPGroup,
+			node.WebAuthnGroup,
+			node.LookupGroup,
+			node.CodeGroup,
 		}),
 		node.SortUseOrder([]string{
 			"password_identifier",
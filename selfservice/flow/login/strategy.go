@@ -19,6 +19,18 @@ type Strategy interface {
 	Login(w http.ResponseWriter, r *http.Request, f *Flow) (i *identity.Identity, err error)
 }
 
+// SecondFactorStrategy is implemented by login strategies which challenge an identity for an
+// additional authentication factor once the identity has been identified by a first factor.
+type SecondFactorStrategy interface {
+	Strategy
+
+	// HasSecondFactor returns true if the identity has set up this second factor.
+	HasSecondFactor(ctx context.Context, i *identity.Identity) bool
+
+	// PopulateSecondFactorMethod adds the nodes needed to complete the second factor to the flow.
+	PopulateSecondFactorMethod(r *http.Request, i *identity.Identity, f *Flow) error
+}
+
 type Strategies []Strategy
 
 func (s Strategies) Strategy(id identity.CredentialsType) (Strategy, error) {
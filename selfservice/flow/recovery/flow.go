@@ -75,6 +75,9 @@ type Flow struct {
 	// CSRFToken contains the anti-csrf token associated with this request.
 	CSRFToken string `json:"-" db:"csrf_token"`
 
+	// InternalContext stores internal context used by internals - for example the state of one-time codes.
+	InternalContext sqlxx.NullJSONRawMessage `json:"-" faker:"-" db:"internal_context"`
+
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
 
@@ -97,9 +100,10 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r), RouteSubmitFlow), id).String(),
 		},
-		State:     StateChooseMethod,
-		CSRFToken: csrf,
-		Type:      ft,
+		State:           StateChooseMethod,
+		CSRFToken:       csrf,
+		Type:            ft,
+		InternalContext: []byte("{}"),
 	}
 
 	for _, strategy := range strategies {
@@ -0,0 +1,100 @@
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"kratos/driver/config"
+	"kratos/session"
+	"kratos/x"
+)
+
+type (
+	PreHookExecutor interface {
+		ExecuteRecoveryPreHook(w http.ResponseWriter, r *http.Request, a *Flow) error
+	}
+	PreHookExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow) error
+
+	PostHookExecutor interface {
+		ExecutePostRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error
+	}
+	PostHookExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error
+
+	HooksProvider interface {
+		PreRecoveryHooks(ctx context.Context) []PreHookExecutor
+		PostRecoveryHooks(ctx context.Context) []PostHookExecutor
+	}
+)
+
+func PostHookExecutorNames(e []PostHookExecutor) []string {
+	names := make([]string, len(e))
+	for k, ee := range e {
+		names[k] = fmt.Sprintf("%T", ee)
+	}
+	return names
+}
+
+func (f PreHookExecutorFunc) ExecuteRecoveryPreHook(w http.ResponseWriter, r *http.Request, a *Flow) error {
+	return f(w, r, a)
+}
+
+func (f PostHookExecutorFunc) ExecutePostRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error {
+	return f(w, r, a, s)
+}
+
+type (
+	executorDependencies interface {
+		config.Provider
+		x.LoggingProvider
+
+		HooksProvider
+	}
+	HookExecutor struct {
+		d executorDependencies
+	}
+	HookExecutorProvider interface {
+		RecoveryExecutor() *HookExecutor
+	}
+)
+
+func NewHookExecutor(d executorDependencies) *HookExecutor {
+	return &HookExecutor{d: d}
+}
+
+func (e *HookExecutor) PreRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow) error {
+	for _, executor := range e.d.PreRecoveryHooks(r.Context()) {
+		if err := executor.ExecuteRecoveryPreHook(w, r, a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *HookExecutor) PostRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error {
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", s.Identity.ID).
+		Debug("Running ExecutePostRecoveryHooks.")
+	for k, executor := range e.d.PostRecoveryHooks(r.Context()) {
+		if err := executor.ExecutePostRecoveryHook(w, r, a, s); err != nil {
+			e.d.Logger().
+				WithRequest(r).
+				WithField("executor", fmt.Sprintf("%T", executor)).
+				WithField("executor_position", k).
+				WithField("executors", PostHookExecutorNames(e.d.PostRecoveryHooks(r.Context()))).
+				WithField("identity_id", s.Identity.ID).
+				WithError(err).
+				Debug("A ExecutePostRecoveryHook hook failed.")
+			return err
+		}
+	}
+
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", s.Identity.ID).
+		Debug("Post recovery execution hooks completed successfully.")
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package recovery
+
+import "kratos/session"
+
+// The Response for Recovery Flows via API
+//
+// swagger:model recoveryViaApiResponse
+type APIFlowResponse struct {
+	// The Session Token
+	//
+	// A session token is equivalent to a session cookie, but it can be sent in the HTTP Authorization
+	// Header:
+	//
+	// 		Authorization: bearer ${session-token}
+	//
+	// The session token is only issued for API flows, not for Browser flows!
+	//
+	// required: true
+	Token string `json:"session_token"`
+
+	// The Session
+	//
+	// The session of the recovered identity. Use it to initialize a settings flow in which the
+	// identity sets up a new way to sign in.
+	//
+	// required: true
+	Session *session.Session `json:"session"`
+}
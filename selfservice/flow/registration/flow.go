@@ -14,6 +14,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
 	"kratos/identity"
@@ -65,8 +66,12 @@ type Flow struct {
 	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
 
 	// CSRFToken contains the anti-csrf token associated with this flow. Only set for browser flows.
-	CSRFToken string    `json:"-" db:"csrf_token"`
-	NID       uuid.UUID `json:"-"  faker:"-" db:"nid"`
+	CSRFToken string `json:"-" db:"csrf_token"`
+
+	// InternalContext stores internal context used by internals - for example the state of one-time codes.
+	InternalContext sqlxx.NullJSONRawMessage `json:"-" faker:"-" db:"internal_context"`
+
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
 }
 
 func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Request, ft flow.Type) *Flow {
@@ -81,8 +86,9 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r), RouteSubmitFlow), id).String(),
 		},
-		CSRFToken: csrf,
-		Type:      ft,
+		CSRFToken:       csrf,
+		Type:            ft,
+		InternalContext: []byte("{}"),
 	}
 }
 
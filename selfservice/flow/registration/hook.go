@@ -130,6 +130,8 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		Info("A new identity has registered using self-service registration.")
 
 	s := session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC())
+	s.SeenFrom(r, e.d.Config(r.Context()), s.IssuedAt)
+	s.CompletedLoginFor(ct, identity.AuthenticatorAssuranceLevel1)
 	e.d.Logger().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
@@ -167,7 +169,7 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		Debug("Post registration execution hooks completed successfully.")
 
 	if a.Type == flow.TypeAPI {
-		e.d.Writer().Write(w, r, &APIFlowResponse{Identity: i})
+		e.d.Writer().Write(w, r, &APIFlowResponse{Identity: i.Declassify()})
 		return nil
 	}
 
@@ -9,6 +9,7 @@ func SortNodes(n node.Nodes, schemaRef string) error {
 			node.DefaultGroup,
 			node.OpenIDConnectGroup,
 			node.PasswordGroup,
+			node.CodeGroup,
 		}),
 		node.SortUpdateOrder(node.PasswordLoginOrder),
 	)
@@ -86,6 +86,9 @@ type Flow struct {
 	// required: true
 	State State `json:"state" faker:"-" db:"state"`
 
+	// InternalContext stores internal context used by internals - for example MFA keys.
+	InternalContext sqlxx.NullJSONRawMessage `json:"-" faker:"-" db:"internal_context"`
+
 	// IdentityID is a helper struct field for gobuffalo.pop.
 	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
 	// CreatedAt is a helper struct field for gobuffalo.pop.
@@ -124,6 +127,8 @@ func NewFlow(conf *config.Config, exp time.Duration, r *http.Request, i *identit
 		Identity:   i,
 		Type:       ft,
 		State:      StateShowForm,
+
+		InternalContext: []byte("{}"),
 		UI: &container.Container{
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r), RouteSubmitFlow), id).String(),
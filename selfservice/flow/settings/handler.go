@@ -111,6 +111,10 @@ func (h *Handler) NewFlow(w http.ResponseWriter, r *http.Request, i *identity.Id
 		return nil, err
 	}
 
+	if err := h.d.SettingsHookExecutor().PreSettingsHook(w, r, f); err != nil {
+		return nil, err
+	}
+
 	if err := h.d.SettingsFlowPersister().CreateSettingsFlow(r.Context(), f); err != nil {
 		return nil, err
 	}
@@ -10,6 +10,9 @@ func sortNodes(n node.Nodes, schemaRef string) error {
 			node.ProfileGroup,
 			node.PasswordGroup,
 			node.OpenIDConnectGroup,
+			node.TOTPGroup,
+			node.WebAuthnGroup,
+			node.LookupGroup,
 		}),
 	)
 }
@@ -71,6 +71,9 @@ type Flow struct {
 	// CSRFToken contains the anti-csrf token associated with this request.
 	CSRFToken string `json:"-" db:"csrf_token"`
 
+	// InternalContext stores internal context used by internals - for example the state of one-time codes.
+	InternalContext sqlxx.NullJSONRawMessage `json:"-" faker:"-" db:"internal_context"`
+
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
 	// UpdatedAt is a helper struct field for gobuffalo.pop.
@@ -101,9 +104,10 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(r), RouteSubmitFlow), id).String(),
 		},
-		CSRFToken: csrf,
-		State:     StateChooseMethod,
-		Type:      ft,
+		CSRFToken:       csrf,
+		State:           StateChooseMethod,
+		Type:            ft,
+		InternalContext: []byte("{}"),
 	}
 
 	for _, strategy := range strategies {
@@ -0,0 +1,100 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/x"
+)
+
+type (
+	PreHookExecutor interface {
+		ExecuteVerificationPreHook(w http.ResponseWriter, r *http.Request, a *Flow) error
+	}
+	PreHookExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow) error
+
+	PostHookExecutor interface {
+		ExecutePostVerificationHook(w http.ResponseWriter, r *http.Request, a *Flow, i *identity.Identity) error
+	}
+	PostHookExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, i *identity.Identity) error
+
+	HooksProvider interface {
+		PreVerificationHooks(ctx context.Context) []PreHookExecutor
+		PostVerificationHooks(ctx context.Context) []PostHookExecutor
+	}
+)
+
+func PostHookExecutorNames(e []PostHookExecutor) []string {
+	names := make([]string, len(e))
+	for k, ee := range e {
+		names[k] = fmt.Sprintf("%T", ee)
+	}
+	return names
+}
+
+func (f PreHookExecutorFunc) ExecuteVerificationPreHook(w http.ResponseWriter, r *http.Request, a *Flow) error {
+	return f(w, r, a)
+}
+
+func (f PostHookExecutorFunc) ExecutePostVerificationHook(w http.ResponseWriter, r *http.Request, a *Flow, i *identity.Identity) error {
+	return f(w, r, a, i)
+}
+
+type (
+	executorDependencies interface {
+		config.Provider
+		x.LoggingProvider
+
+		HooksProvider
+	}
+	HookExecutor struct {
+		d executorDependencies
+	}
+	HookExecutorProvider interface {
+		VerificationExecutor() *HookExecutor
+	}
+)
+
+func NewHookExecutor(d executorDependencies) *HookExecutor {
+	return &HookExecutor{d: d}
+}
+
+func (e *HookExecutor) PreVerificationHook(w http.ResponseWriter, r *http.Request, a *Flow) error {
+	for _, executor := range e.d.PreVerificationHooks(r.Context()) {
+		if err := executor.ExecuteVerificationPreHook(w, r, a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *HookExecutor) PostVerificationHook(w http.ResponseWriter, r *http.Request, a *Flow, i *identity.Identity) error {
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		Debug("Running ExecutePostVerificationHooks.")
+	for k, executor := range e.d.PostVerificationHooks(r.Context()) {
+		if err := executor.ExecutePostVerificationHook(w, r, a, i); err != nil {
+			e.d.Logger().
+				WithRequest(r).
+				WithField("executor", fmt.Sprintf("%T", executor)).
+				WithField("executor_position", k).
+				WithField("executors", PostHookExecutorNames(e.d.PostVerificationHooks(r.Context()))).
+				WithField("identity_id", i.ID).
+				WithError(err).
+				Debug("A ExecutePostVerificationHook hook failed.")
+			return err
+		}
+	}
+
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", i.ID).
+		Debug("Post verification execution hooks completed successfully.")
+
+	return nil
+}
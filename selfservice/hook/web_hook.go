@@ -0,0 +1,343 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-jsonnet"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"kratos/identity"
+	"kratos/request"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/login"
+	"kratos/selfservice/flow/recovery"
+	"kratos/selfservice/flow/registration"
+	"kratos/selfservice/flow/settings"
+	"kratos/selfservice/flow/verification"
+	"kratos/session"
+	"kratos/text"
+	"kratos/x"
+)
+
+var (
+	_ registration.PreHookExecutor             = new(WebHook)
+	_ registration.PostHookPrePersistExecutor  = new(WebHook)
+	_ registration.PostHookPostPersistExecutor = new(WebHook)
+
+	_ login.PreHookExecutor  = new(WebHook)
+	_ login.PostHookExecutor = new(WebHook)
+
+	_ settings.PreHookExecutor             = new(WebHook)
+	_ settings.PostHookPrePersistExecutor  = new(WebHook)
+	_ settings.PostHookPostPersistExecutor = new(WebHook)
+
+	_ recovery.PreHookExecutor  = new(WebHook)
+	_ recovery.PostHookExecutor = new(WebHook)
+
+	_ verification.PreHookExecutor  = new(WebHook)
+	_ verification.PostHookExecutor = new(WebHook)
+)
+
+const (
+	defaultWebHookTimeout = 10 * time.Second
+
+	// maxWebHookResponseSize limits how much of an interrupting web hook's response is parsed.
+	maxWebHookResponseSize = 1 << 20
+)
+
+// These request headers are never passed on to the web hook as they carry the user's credentials.
+var webHookIgnoredRequestHeaders = []string{"Authorization", "Cookie"}
+
+type (
+	webHookDependencies interface {
+		x.LoggingProvider
+	}
+
+	webHookConfig struct {
+		URL          string       `json:"url"`
+		Method       string       `json:"method"`
+		Body         string       `json:"body"`
+		Timeout      string       `json:"timeout"`
+		CanInterrupt bool         `json:"can_interrupt"`
+		Auth         request.Auth `json:"auth"`
+	}
+
+	// templateContext is available as `std.extVar('ctx')` in the Jsonnet body template.
+	templateContext struct {
+		Flow           flow.Flow          `json:"flow"`
+		Identity       *identity.Identity `json:"identity,omitempty"`
+		RequestHeaders http.Header        `json:"request_headers"`
+		RequestMethod  string             `json:"request_method"`
+		RequestURL     string             `json:"request_url"`
+	}
+
+	// webHookResponse is the body an interrupting web hook responds with to reject a flow.
+	webHookResponse struct {
+		Messages []struct {
+			InstancePtr string        `json:"instance_ptr"`
+			Messages    text.Messages `json:"messages"`
+		} `json:"messages"`
+	}
+
+	// WebHook calls an external HTTP endpoint when a self-service flow is initialized or completed.
+	WebHook struct {
+		r webHookDependencies
+		c json.RawMessage
+	}
+)
+
+func NewWebHook(r webHookDependencies, c json.RawMessage) *WebHook {
+	return &WebHook{r: r, c: c}
+}
+
+func (e *WebHook) ExecuteLoginPreHook(_ http.ResponseWriter, r *http.Request, f *login.Flow) error {
+	return e.executeBeforeFlow(r, f, nil)
+}
+
+func (e *WebHook) ExecuteLoginPostHook(_ http.ResponseWriter, r *http.Request, f *login.Flow, s *session.Session) error {
+	return e.execute(r, f, s.Identity)
+}
+
+func (e *WebHook) ExecuteRegistrationPreHook(_ http.ResponseWriter, r *http.Request, f *registration.Flow) error {
+	return e.executeBeforeFlow(r, f, nil)
+}
+
+// ExecutePostRegistrationPrePersistHook only calls interrupting web hooks as they must be able to reject the
+// identity before it is stored.
+func (e *WebHook) ExecutePostRegistrationPrePersistHook(_ http.ResponseWriter, r *http.Request, f *registration.Flow, i *identity.Identity) error {
+	return e.executeIf(true, r, f, i)
+}
+
+func (e *WebHook) ExecutePostRegistrationPostPersistHook(_ http.ResponseWriter, r *http.Request, f *registration.Flow, s *session.Session) error {
+	return e.executeIf(false, r, f, s.Identity)
+}
+
+func (e *WebHook) ExecuteSettingsPreHook(_ http.ResponseWriter, r *http.Request, f *settings.Flow) error {
+	return e.executeBeforeFlow(r, f, f.Identity)
+}
+
+// ExecuteSettingsPrePersistHook only calls interrupting web hooks as they must be able to reject the
+// changes before they are stored.
+func (e *WebHook) ExecuteSettingsPrePersistHook(_ http.ResponseWriter, r *http.Request, f *settings.Flow, i *identity.Identity) error {
+	return e.executeIf(true, r, f, i)
+}
+
+func (e *WebHook) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, r *http.Request, f *settings.Flow, i *identity.Identity) error {
+	return e.executeIf(false, r, f, i)
+}
+
+func (e *WebHook) ExecuteRecoveryPreHook(_ http.ResponseWriter, r *http.Request, f *recovery.Flow) error {
+	return e.executeBeforeFlow(r, f, nil)
+}
+
+func (e *WebHook) ExecutePostRecoveryHook(_ http.ResponseWriter, r *http.Request, f *recovery.Flow, s *session.Session) error {
+	return e.execute(r, f, s.Identity)
+}
+
+func (e *WebHook) ExecuteVerificationPreHook(_ http.ResponseWriter, r *http.Request, f *verification.Flow) error {
+	return e.executeBeforeFlow(r, f, nil)
+}
+
+func (e *WebHook) ExecutePostVerificationHook(_ http.ResponseWriter, r *http.Request, f *verification.Flow, i *identity.Identity) error {
+	return e.execute(r, f, i)
+}
+
+func (e *WebHook) config() (*webHookConfig, error) {
+	var c webHookConfig
+	if err := json.Unmarshal(e.c, &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the web hook configuration: %s", err))
+	}
+
+	if c.Method == "" {
+		c.Method = "POST"
+	}
+	return &c, nil
+}
+
+// executeIf calls the web hook only if its can_interrupt setting matches. This ensures that a web hook which
+// is registered for both the pre and post persist hooks of a flow is called exactly once.
+func (e *WebHook) executeIf(canInterrupt bool, r *http.Request, f flow.Flow, i *identity.Identity) error {
+	c, err := e.config()
+	if err != nil {
+		return err
+	}
+
+	if c.CanInterrupt != canInterrupt {
+		return nil
+	}
+	return e.execute(r, f, i)
+}
+
+// executeBeforeFlow calls the web hook when a flow is initialized. As the flow has no form yet, messages of an
+// interrupting web hook are returned as a bad request.
+func (e *WebHook) executeBeforeFlow(r *http.Request, f flow.Flow, i *identity.Identity) error {
+	err := e.execute(r, f, i)
+	if ve := new(schema.ValidationListError); errors.As(err, &ve) {
+		var reasons []string
+		for _, v := range ve.Validations {
+			for _, m := range v.Messages {
+				reasons = append(reasons, m.Text)
+			}
+		}
+		return errors.WithStack(herodot.ErrBadRequest.WithReason(strings.Join(reasons, " ")))
+	}
+	return err
+}
+
+func (e *WebHook) execute(r *http.Request, f flow.Flow, i *identity.Identity) error {
+	c, err := e.config()
+	if err != nil {
+		return err
+	}
+
+	timeout := defaultWebHookTimeout
+	if c.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the web hook timeout: %s", err))
+		}
+	}
+
+	body, err := e.renderBody(r, c, f, i)
+	if err != nil {
+		return err
+	}
+
+	// The timeout also limits the retries of the resilient client.
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	req, err := retryablehttp.NewRequest(c.Method, c.URL, body)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to create the web hook request: %s", err))
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if err := c.Auth.Apply(req.Request); err != nil {
+		return err
+	}
+
+	logger := e.r.Logger().
+		WithRequest(r).
+		WithField("web_hook_url", c.URL).
+		WithField("web_hook_method", c.Method)
+
+	res, err := request.NewClient(timeout).Do(req.WithContext(ctx))
+	if err != nil {
+		logger.WithError(err).Error("Unable to call the web hook.")
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to call the web hook: %s", err))
+	}
+	defer res.Body.Close()
+
+	if c.CanInterrupt && res.StatusCode >= 400 && res.StatusCode < 500 {
+		if err := parseWebHookResponse(res.Body); err != nil {
+			logger.WithField("web_hook_status_code", res.StatusCode).WithError(err).Debug("The web hook interrupted the flow.")
+			return err
+		}
+	}
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		logger.WithField("web_hook_status_code", res.StatusCode).Error("The web hook responded with an unexpected status code.")
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The web hook responded with unexpected status code %d.", res.StatusCode))
+	}
+
+	logger.Debug("The web hook completed successfully.")
+	return nil
+}
+
+func (e *WebHook) renderBody(r *http.Request, c *webHookConfig, f flow.Flow, i *identity.Identity) (io.Reader, error) {
+	if c.Body == "" {
+		return nil, nil
+	}
+
+	template, err := fetcher.NewFetcher().Fetch(c.Body)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to fetch the web hook body template: %s", err))
+	}
+
+	headers := r.Header.Clone()
+	for _, h := range webHookIgnoredRequestHeaders {
+		headers.Del(h)
+	}
+
+	// The web hook is a third party, so it must not receive the credentials or the admin metadata.
+	if i != nil {
+		i = i.Declassify()
+	}
+
+	ctx, err := json.Marshal(&templateContext{
+		Flow:           f,
+		Identity:       i,
+		RequestHeaders: headers,
+		RequestMethod:  r.Method,
+		RequestURL:     x.RequestURL(r).String(),
+	})
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode the web hook template context: %s", err))
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.ExtCode("ctx", string(ctx))
+	evaluated, err := vm.EvaluateSnippet(c.Body, template.String())
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to render the web hook body template: %s", strings.TrimSpace(err.Error())))
+	}
+
+	return bytes.NewBufferString(evaluated), nil
+}
+
+// parseWebHookResponse returns the messages of an interrupting web hook as a validation error. Returns nil if
+// the response does not contain any messages.
+func parseWebHookResponse(body io.Reader) error {
+	var res webHookResponse
+	if err := json.NewDecoder(io.LimitReader(body, maxWebHookResponseSize)).Decode(&res); err != nil {
+		return nil
+	}
+
+	ve := schema.NewValidationListError()
+	for _, m := range res.Messages {
+		if len(m.Messages) == 0 {
+			continue
+		}
+
+		messages := make(text.Messages, len(m.Messages))
+		reasons := make([]string, len(m.Messages))
+		for k, message := range m.Messages {
+			if message.ID == 0 {
+				message.ID = text.ErrorValidationGeneric
+			}
+			if message.Type == "" {
+				message.Type = text.Error
+			}
+			messages[k] = message
+			reasons[k] = message.Text
+		}
+
+		instancePtr := m.InstancePtr
+		if instancePtr == "" {
+			instancePtr = "#/"
+		}
+		ve.Add(schema.NewHookValidationError(instancePtr, strings.Join(reasons, " "), messages))
+	}
+
+	if !ve.HasErrors() {
+		return nil
+	}
+	return errors.WithStack(ve)
+}
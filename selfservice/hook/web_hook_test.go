@@ -0,0 +1,347 @@
+package hook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/login"
+	"kratos/selfservice/flow/recovery"
+	"kratos/selfservice/flow/registration"
+	"kratos/selfservice/flow/settings"
+	"kratos/selfservice/flow/verification"
+	"kratos/selfservice/hook"
+	"kratos/session"
+	"kratos/text"
+	"kratos/x"
+)
+
+func TestWebHook(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+
+	type request struct {
+		Method  string
+		Headers http.Header
+		Body    string
+	}
+
+	var (
+		requests = make(chan request, 1)
+		status   = http.StatusOK
+		response string
+		delay    time.Duration
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		select {
+		case requests <- request{Method: r.Method, Headers: r.Header, Body: string(body)}:
+		default:
+			// Retries are not recorded.
+		}
+		time.Sleep(delay)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(ts.Close)
+
+	newHook := func(t *testing.T, c string) *hook.WebHook {
+		return hook.NewWebHook(reg, json.RawMessage(fmt.Sprintf(c, ts.URL)))
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		r := httptest.NewRequest("POST", "https://www.ory.sh/self-service/login", nil)
+		r.Header.Set("Accept-Language", "de")
+		r.Header.Set("Cookie", "ory_kratos_session=secret")
+		r.Header.Set("Authorization", "Bearer secret")
+		return r
+	}
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.ID = x.NewUUID()
+	s := &session.Session{ID: x.NewUUID(), Identity: i}
+
+	for _, tc := range []struct {
+		name     string
+		identity bool
+		execute  func(h *hook.WebHook, r *http.Request, f flow.Flow) error
+		flow     flow.Flow
+	}{
+		{
+			name: "login pre hook",
+			flow: &login.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteLoginPreHook(nil, r, f.(*login.Flow))
+			},
+		},
+		{
+			name:     "login post hook",
+			identity: true,
+			flow:     &login.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteLoginPostHook(nil, r, f.(*login.Flow), s)
+			},
+		},
+		{
+			name: "registration pre hook",
+			flow: &registration.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteRegistrationPreHook(nil, r, f.(*registration.Flow))
+			},
+		},
+		{
+			name:     "registration post hook",
+			identity: true,
+			flow:     &registration.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecutePostRegistrationPostPersistHook(nil, r, f.(*registration.Flow), s)
+			},
+		},
+		{
+			name:     "settings pre hook",
+			identity: true,
+			flow:     &settings.Flow{ID: x.NewUUID(), Identity: i},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteSettingsPreHook(nil, r, f.(*settings.Flow))
+			},
+		},
+		{
+			name:     "settings post hook",
+			identity: true,
+			flow:     &settings.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteSettingsPostPersistHook(nil, r, f.(*settings.Flow), i)
+			},
+		},
+		{
+			name: "recovery pre hook",
+			flow: &recovery.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteRecoveryPreHook(nil, r, f.(*recovery.Flow))
+			},
+		},
+		{
+			name:     "recovery post hook",
+			identity: true,
+			flow:     &recovery.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecutePostRecoveryHook(nil, r, f.(*recovery.Flow), s)
+			},
+		},
+		{
+			name: "verification pre hook",
+			flow: &verification.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecuteVerificationPreHook(nil, r, f.(*verification.Flow))
+			},
+		},
+		{
+			name:     "verification post hook",
+			identity: true,
+			flow:     &verification.Flow{ID: x.NewUUID()},
+			execute: func(h *hook.WebHook, r *http.Request, f flow.Flow) error {
+				return h.ExecutePostVerificationHook(nil, r, f.(*verification.Flow), i)
+			},
+		},
+	} {
+		t.Run("hook="+tc.name, func(t *testing.T) {
+			h := newHook(t, `{"url": "%s", "body": "file://./stub/test_body.jsonnet"}`)
+			require.NoError(t, tc.execute(h, newRequest(t), tc.flow))
+
+			req := <-requests
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "application/json", req.Headers.Get("Content-Type"))
+			assert.Equal(t, tc.flow.GetID().String(), gjson.Get(req.Body, "flow_id").String(), req.Body)
+			assert.Equal(t, "POST", gjson.Get(req.Body, "method").String(), req.Body)
+			assert.Equal(t, "https://www.ory.sh/self-service/login", gjson.Get(req.Body, "url").String(), req.Body)
+			assert.Equal(t, "de", gjson.Get(req.Body, "headers.Accept-Language.0").String(), req.Body)
+			assert.False(t, gjson.Get(req.Body, "headers.Cookie").Exists(), req.Body)
+			assert.False(t, gjson.Get(req.Body, "headers.Authorization").Exists(), req.Body)
+			if tc.identity {
+				assert.Equal(t, i.ID.String(), gjson.Get(req.Body, "identity_id").String(), req.Body)
+			} else {
+				assert.Equal(t, gjson.Null, gjson.Get(req.Body, "identity_id").Type, req.Body)
+			}
+		})
+	}
+
+	f := &login.Flow{ID: x.NewUUID(), Type: flow.TypeBrowser}
+
+	t.Run("case=does not send the credentials or admin metadata of the identity", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.MetadataAdmin = []byte(`{"secret": true}`)
+		i.MetadataPublic = []byte(`{"public": true}`)
+		i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{Identifiers: []string{"foo"}, Config: []byte(`{"hashed_password": "secret"}`)})
+
+		h := newHook(t, `{"url": "%s", "body": "file://./stub/identity_body.jsonnet", "can_interrupt": true}`)
+		require.NoError(t, h.ExecuteSettingsPrePersistHook(nil, newRequest(t), &settings.Flow{ID: x.NewUUID()}, i))
+
+		req := <-requests
+		assert.Equal(t, i.ID.String(), gjson.Get(req.Body, "identity.id").String(), req.Body)
+		assert.True(t, gjson.Get(req.Body, "identity.metadata_public.public").Bool(), req.Body)
+		assert.False(t, gjson.Get(req.Body, "identity.credentials").Exists(), req.Body)
+		assert.False(t, gjson.Get(req.Body, "identity.metadata_admin").Exists(), req.Body)
+		assert.NotContains(t, req.Body, "hashed_password", req.Body)
+		require.True(t, i.MetadataAdmin != nil, "the identity passed to the hook must not be modified")
+	})
+
+	t.Run("case=uses the configured method without body", func(t *testing.T) {
+		require.NoError(t, newHook(t, `{"url": "%s", "method": "GET"}`).ExecuteLoginPreHook(nil, newRequest(t), f))
+
+		req := <-requests
+		assert.Equal(t, "GET", req.Method)
+		assert.Empty(t, req.Body)
+	})
+
+	t.Run("case=authenticates with basic auth", func(t *testing.T) {
+		require.NoError(t, newHook(t, `{"url": "%s", "auth": {"type": "basic_auth", "config": {"user": "foo", "password": "bar"}}}`).ExecuteLoginPreHook(nil, newRequest(t), f))
+
+		r := http.Request{Header: (<-requests).Headers}
+		user, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "foo", user)
+		assert.Equal(t, "bar", password)
+	})
+
+	t.Run("case=authenticates with an api key header", func(t *testing.T) {
+		require.NoError(t, newHook(t, `{"url": "%s", "auth": {"type": "api_key", "config": {"name": "X-Api-Key", "value": "secret"}}}`).ExecuteLoginPreHook(nil, newRequest(t), f))
+		assert.Equal(t, "secret", (<-requests).Headers.Get("X-Api-Key"))
+	})
+
+	t.Run("case=authenticates with an api key cookie", func(t *testing.T) {
+		require.NoError(t, newHook(t, `{"url": "%s", "auth": {"type": "api_key", "config": {"name": "api_key", "value": "secret", "in": "cookie"}}}`).ExecuteLoginPreHook(nil, newRequest(t), f))
+
+		r := http.Request{Header: (<-requests).Headers}
+		c, err := r.Cookie("api_key")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", c.Value)
+	})
+
+	t.Run("case=fails if the web hook responds with an error", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		t.Cleanup(func() {
+			status = http.StatusOK
+		})
+
+		err := newHook(t, `{"url": "%s"}`).ExecuteLoginPreHook(nil, newRequest(t), f)
+		<-requests
+		require.Error(t, err)
+		assert.Contains(t, fmt.Sprintf("%+v", err), "500")
+	})
+
+	t.Run("case=retries if the web hook responds with a server error", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(ts.Close)
+
+		require.NoError(t, hook.NewWebHook(reg, json.RawMessage(fmt.Sprintf(`{"url": "%s"}`, ts.URL))).ExecuteLoginPreHook(nil, newRequest(t), f))
+		assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("case=fails if the web hook times out", func(t *testing.T) {
+		delay = 50 * time.Millisecond
+		t.Cleanup(func() {
+			delay = 0
+		})
+
+		err := newHook(t, `{"url": "%s", "timeout": "10ms"}`).ExecuteLoginPreHook(nil, newRequest(t), f)
+		<-requests
+		require.Error(t, err)
+	})
+
+	t.Run("case=fails on an invalid body template", func(t *testing.T) {
+		err := newHook(t, `{"url": "%s", "body": "base64://ewo="}`).ExecuteLoginPreHook(nil, newRequest(t), f)
+		require.Error(t, err)
+	})
+	t.Run("case=can interrupt", func(t *testing.T) {
+		status = http.StatusBadRequest
+		response = `{"messages":[{"instance_ptr":"#/traits/email","messages":[{"id":1234,"text":"invite only","type":"error","context":{"domain":"ory.sh"}}]},{"messages":[{"text":"rejected"}]}]}`
+		t.Cleanup(func() {
+			status = http.StatusOK
+			response = ""
+		})
+
+		interrupting := newHook(t, `{"url": "%s", "can_interrupt": true}`)
+		regular := newHook(t, `{"url": "%s"}`)
+
+		t.Run("case=returns the messages as validation errors", func(t *testing.T) {
+			err := interrupting.ExecuteLoginPostHook(nil, newRequest(t), f, s)
+			<-requests
+
+			ve := new(schema.ValidationListError)
+			require.True(t, errors.As(err, &ve), "%+v", err)
+			require.Len(t, ve.Validations, 2)
+
+			assert.Equal(t, "#/traits/email", ve.Validations[0].InstancePtr)
+			assert.Equal(t, text.Messages{{ID: 1234, Text: "invite only", Type: text.Error, Context: json.RawMessage(`{"domain":"ory.sh"}`)}}, ve.Validations[0].Messages)
+
+			assert.Equal(t, "#/", ve.Validations[1].InstancePtr)
+			assert.Equal(t, text.Messages{{ID: text.ErrorValidationGeneric, Text: "rejected", Type: text.Error}}, ve.Validations[1].Messages)
+		})
+
+		t.Run("case=returns a bad request when initializing a flow", func(t *testing.T) {
+			err := interrupting.ExecuteRegistrationPreHook(nil, newRequest(t), &registration.Flow{ID: x.NewUUID()})
+			<-requests
+
+			var he *herodot.DefaultError
+			require.True(t, errors.As(err, &he), "%+v", err)
+			assert.Equal(t, http.StatusBadRequest, he.StatusCode())
+			assert.Equal(t, "invite only rejected", he.Reason())
+		})
+
+		t.Run("case=ignores the response if the web hook can not interrupt", func(t *testing.T) {
+			err := regular.ExecuteLoginPostHook(nil, newRequest(t), f, s)
+			<-requests
+
+			require.Error(t, err)
+			assert.False(t, errors.As(err, new(*schema.ValidationListError)), "%+v", err)
+		})
+
+		t.Run("case=ignores unstructured responses", func(t *testing.T) {
+			response = "not json"
+			err := interrupting.ExecuteLoginPostHook(nil, newRequest(t), f, s)
+			<-requests
+
+			require.Error(t, err)
+			assert.False(t, errors.As(err, new(*schema.ValidationListError)), "%+v", err)
+		})
+
+		t.Run("case=runs before the identity is persisted", func(t *testing.T) {
+			status = http.StatusOK
+			rf, sf := &registration.Flow{ID: x.NewUUID()}, &settings.Flow{ID: x.NewUUID()}
+
+			require.NoError(t, interrupting.ExecutePostRegistrationPostPersistHook(nil, newRequest(t), rf, s))
+			require.NoError(t, interrupting.ExecuteSettingsPostPersistHook(nil, newRequest(t), sf, i))
+			require.NoError(t, regular.ExecutePostRegistrationPrePersistHook(nil, newRequest(t), rf, i))
+			require.NoError(t, regular.ExecuteSettingsPrePersistHook(nil, newRequest(t), sf, i))
+			assert.Len(t, requests, 0)
+
+			require.NoError(t, interrupting.ExecutePostRegistrationPrePersistHook(nil, newRequest(t), rf, i))
+			<-requests
+			require.NoError(t, interrupting.ExecuteSettingsPrePersistHook(nil, newRequest(t), sf, i))
+			<-requests
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package code
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+	"kratos/identity"
+	"kratos/otp"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/text"
+	"kratos/x"
+)
+
+const internalContextKeyCode = "code"
+
+// codeState is stored in the flow's internal context after a code has been sent. The code itself
+// is never stored, only its HMAC. Attempts are tracked by the CodePersister using the ID.
+type codeState struct {
+	ID         uuid.UUID                      `json:"id"`
+	HMAC       string                         `json:"hmac"`
+	Via        identity.VerifiableAddressType `json:"via"`
+	AddressID  uuid.UUID                      `json:"address_id"`
+	IdentityID uuid.UUID                      `json:"identity_id"`
+	ExpiresAt  time.Time                      `json:"expires_at"`
+
+	// Traits are only set during registration and hold the traits the code was sent for.
+	Traits json.RawMessage `json:"traits,omitempty"`
+}
+
+func (c *codeState) expired() bool {
+	return c.ExpiresAt.Before(time.Now().UTC())
+}
+
+// issueCode records that a code is about to be sent for the flow and returns the ID the code's state has to use.
+// Sending more codes than allowed per flow fails, so that resending does not reset the attempts without limit.
+// The record is kept until the flow expires.
+func (s *Strategy) issueCode(ctx context.Context, flowID uuid.UUID, expiresAt time.Time) (uuid.UUID, error) {
+	c := &Code{ID: x.NewUUID(), FlowID: flowID, ExpiresAt: expiresAt}
+	sent, err := s.d.CodePersister().CreateCode(ctx, c)
+	if err != nil {
+		return uuid.Nil, err
+	} else if sent > s.d.Config(ctx).SelfServiceCodeMaxSent() {
+		return uuid.Nil, schema.NewCodeTooManySentError("#/code")
+	}
+	return c.ID, nil
+}
+
+// newCode generates a new code and returns it together with the state which has to be stored in the flow.
+func (s *Strategy) newCode(ctx context.Context, id uuid.UUID, via identity.VerifiableAddressType, addressID, identityID uuid.UUID) (string, *codeState, error) {
+	code, err := otp.NewNumeric()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return code, &codeState{
+		ID:         id,
+		HMAC:       hmacCodeWithSecret(code, s.d.Config(ctx).SecretsSession()[0]),
+		Via:        via,
+		AddressID:  addressID,
+		IdentityID: identityID,
+		ExpiresAt:  time.Now().UTC().Add(s.d.Config(ctx).SelfServiceCodeLifespan()),
+	}, nil
+}
+
+func (s *Strategy) compareCode(ctx context.Context, code string, state *codeState) bool {
+	for _, secret := range s.d.Config(ctx).SecretsSession() {
+		if subtle.ConstantTimeCompare([]byte(hmacCodeWithSecret(code, secret)), []byte(state.HMAC)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func hmacCodeWithSecret(code string, secret []byte) string {
+	h := hmac.New(sha512.New512_256, secret)
+	_, _ = h.Write([]byte(code))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// codeStateFromInternalContext returns nil if no code has been sent or the code has already been used.
+func codeStateFromInternalContext(ic sqlxx.NullJSONRawMessage) (*codeState, error) {
+	raw := gjson.GetBytes(ic, internalContextKeyCode)
+	if !raw.IsObject() {
+		return nil, nil
+	}
+
+	var state codeState
+	if err := json.Unmarshal([]byte(raw.Raw), &state); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &state, nil
+}
+
+// setCodeState stores the state in the internal context. Passing nil invalidates the code.
+func setCodeState(ic sqlxx.NullJSONRawMessage, state *codeState) (sqlxx.NullJSONRawMessage, error) {
+	return flow.SetInternalContext(ic, internalContextKeyCode, state)
+}
+
+// useCode checks the code against the state stored in the internal context and returns the updated internal
+// context, which has to be persisted regardless of the outcome. The code is invalidated once it was used, has
+// expired, or was entered incorrectly too many times. Attempts are counted by the CodePersister, which makes
+// concurrent submissions and stale copies of the internal context unable to reset them.
+func (s *Strategy) useCode(ctx context.Context, ic sqlxx.NullJSONRawMessage, code string) (*codeState, sqlxx.NullJSONRawMessage, error) {
+	state, err := codeStateFromInternalContext(ic)
+	if err != nil {
+		return nil, ic, err
+	} else if state == nil {
+		return nil, ic, schema.NewCodeInvalidError("#/code")
+	}
+
+	if state.expired() {
+		ic, err = setCodeState(ic, nil)
+		if err != nil {
+			return nil, ic, err
+		}
+		return nil, ic, schema.NewCodeExpiredError("#/code")
+	}
+
+	maxAttempts := s.d.Config(ctx).SelfServiceCodeMaxAttempts()
+	attempts, err := s.d.CodePersister().AttemptCode(ctx, state.ID, maxAttempts)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		// The code was used by a concurrent request or all attempts have been used up.
+		attempts = maxAttempts + 1
+	} else if err != nil {
+		return nil, ic, err
+	}
+
+	if attempts > maxAttempts || !s.compareCode(ctx, code, state) {
+		if attempts >= maxAttempts {
+			ic, err = setCodeState(ic, nil)
+			if err != nil {
+				return nil, ic, err
+			}
+			return nil, ic, schema.NewCodeTooManyAttemptsError("#/code")
+		}
+		return nil, ic, schema.NewCodeInvalidError("#/code")
+	}
+
+	ic, err = setCodeState(ic, nil)
+	if err != nil {
+		return nil, ic, err
+	}
+
+	if err := s.d.CodePersister().UseCode(ctx, state.ID); errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, ic, schema.NewCodeInvalidError("#/code")
+	} else if err != nil {
+		return nil, ic, err
+	}
+	return state, ic, nil
+}
+
+// codeErrorID returns the message ID of a validation error returned by useCode.
+func codeErrorID(err error) text.ID {
+	var ve *schema.ValidationError
+	if errors.As(err, &ve) && len(ve.Messages) > 0 {
+		return ve.Messages[0].ID
+	}
+	return 0
+}
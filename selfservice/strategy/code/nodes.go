@@ -0,0 +1,19 @@
+package code
+
+import (
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+// NewCodeNode returns the field in which the user enters the code after it has been sent. It is not required
+// because the form may also be submitted without a code to request a new one.
+func NewCodeNode(group node.Group) *node.Node {
+	return node.NewInputField("code", nil, group, node.InputAttributeTypeText).
+		WithMetaLabel(text.NewInfoNodeLabelVerifyOTP())
+}
+
+// NewMethodNode returns the button which sends the code or submits the entered code.
+func NewMethodNode(group node.Group) *node.Node {
+	return node.NewInputField("method", string(group), group, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoNodeLabelSubmit())
+}
@@ -0,0 +1,56 @@
+package code
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/corp"
+)
+
+type (
+	// Code records a code which was sent for a flow. The code itself and its state live in the flow's internal
+	// context, the attempts are tracked here so that they can be counted atomically. Codes are deleted once one
+	// of them was used or their flow has expired.
+	Code struct {
+		ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+		// FlowID is the ID of the login, registration, recovery, or verification flow the code was sent for.
+		FlowID uuid.UUID `json:"flow_id" db:"flow_id"`
+
+		// Attempts counts how often the code was entered.
+		Attempts int `json:"attempts" db:"attempts"`
+
+		// ExpiresAt is the expiry of the flow the code was sent for.
+		ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+		NID       uuid.UUID `json:"-"  faker:"-" db:"nid"`
+	}
+
+	CodePersister interface {
+		// CreateCode stores the code and returns how many codes have been sent for its flow, including this one.
+		// Codes of expired flows are deleted along the way.
+		CreateCode(ctx context.Context, code *Code) (int, error)
+
+		// AttemptCode counts an attempt to enter the code and returns the number of attempts so far. It returns
+		// sqlcon.ErrNoRows if the code does not exist (anymore) or if maxAttempts has been reached.
+		AttemptCode(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error)
+
+		// UseCode deletes the code together with all other codes of its flow. It returns sqlcon.ErrNoRows if the
+		// code has already been used.
+		UseCode(ctx context.Context, id uuid.UUID) error
+	}
+
+	CodePersistenceProvider interface {
+		CodePersister() CodePersister
+	}
+)
+
+func (Code) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_codes")
+}
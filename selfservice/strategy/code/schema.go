@@ -0,0 +1,17 @@
+package code
+
+import (
+	_ "embed"
+)
+
+//go:embed .schema/recovery.schema.json
+var recoveryMethodSchema []byte
+
+//go:embed .schema/verification.schema.json
+var verificationMethodSchema []byte
+
+//go:embed .schema/login.schema.json
+var loginMethodSchema []byte
+
+//go:embed .schema/registration.schema.json
+var registrationMethodSchema []byte
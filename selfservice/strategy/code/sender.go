@@ -0,0 +1,166 @@
+package code
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/errorsx"
+	"github.com/ory/x/sqlcon"
+
+	"kratos/courier"
+	templates "kratos/courier/template"
+	"kratos/identity"
+)
+
+// sendRecoveryCode sends a recovery code to the specified address and returns the state which has to be stored
+// in the flow. If the address does not exist in the store, an email is still being sent to prevent account
+// enumeration attacks. In that case, the returned state is nil. No text message is sent to unknown phone numbers.
+func (s *Strategy) sendRecoveryCode(ctx context.Context, id uuid.UUID, via identity.VerifiableAddressType, to string) (*codeState, error) {
+	s.d.Logger().
+		WithField("via", via).
+		WithSensitiveField("address", to).
+		Debug("Preparing recovery code.")
+
+	address, err := s.d.IdentityPool().FindRecoveryAddressByValue(ctx, identity.RecoveryAddressType(via), to)
+	if err != nil {
+		if via == identity.VerifiableAddressTypePhone {
+			return nil, nil
+		}
+		return nil, s.send(ctx, string(via), templates.NewRecoveryInvalid(s.d.Config(ctx), &templates.RecoveryInvalidModel{To: to}))
+	}
+
+	code, state, err := s.newCode(ctx, id, via, address.ID, address.IdentityID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.d.Audit().
+		WithField("via", address.Via).
+		WithField("identity_id", address.IdentityID).
+		WithSensitiveField("recovery_address", address.Value).
+		Info("Sending out recovery code.")
+	if err := s.send(ctx, string(address.Via), templates.NewRecoveryCodeValid(s.d.Config(ctx),
+		&templates.RecoveryCodeValidModel{To: address.Value, RecoveryCode: code})); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// sendVerificationCode sends a verification code to the specified address and returns the state which has to be
+// stored in the flow. If the address does not exist in the store, an email is still being sent to prevent account
+// enumeration attacks. In that case, the returned state is nil. No text message is sent to unknown phone numbers.
+func (s *Strategy) sendVerificationCode(ctx context.Context, id uuid.UUID, via identity.VerifiableAddressType, to string) (*codeState, error) {
+	s.d.Logger().
+		WithField("via", via).
+		WithSensitiveField("address", to).
+		Debug("Preparing verification code.")
+
+	address, err := s.d.IdentityPool().FindVerifiableAddressByValue(ctx, via, to)
+	if err != nil {
+		if errorsx.Cause(err) != sqlcon.ErrNoRows {
+			return nil, err
+		}
+		if via == identity.VerifiableAddressTypePhone {
+			return nil, nil
+		}
+		s.d.Audit().
+			WithField("via", via).
+			WithSensitiveField("email_address", to).
+			Info("Sending out invalid verification email because address is unknown.")
+		return nil, s.send(ctx, string(via), templates.NewVerificationInvalid(s.d.Config(ctx), &templates.VerificationInvalidModel{To: to}))
+	}
+
+	code, state, err := s.newCode(ctx, id, via, address.ID, address.IdentityID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.d.Audit().
+		WithField("via", address.Via).
+		WithField("identity_id", address.IdentityID).
+		WithSensitiveField("verifiable_address", address.Value).
+		Info("Sending out verification code.")
+	if err := s.send(ctx, string(address.Via), templates.NewVerificationCodeValid(s.d.Config(ctx),
+		&templates.VerificationCodeValidModel{To: address.Value, VerificationCode: code})); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// sendLoginCode sends a login code to the identity with the specified identifier and returns the state which has to
+// be stored in the flow. If no identity exists for the identifier, no email is sent and the returned state is nil so
+// that the response does not reveal whether an account exists.
+func (s *Strategy) sendLoginCode(ctx context.Context, id uuid.UUID, identifier string) (*codeState, error) {
+	s.d.Logger().
+		WithSensitiveField("identifier", identifier).
+		Debug("Preparing login code.")
+
+	i, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(ctx, s.ID(), identifier)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Identifiers are stored in lower case.
+	to := strings.ToLower(identifier)
+
+	code, state, err := s.newCode(ctx, id, identity.VerifiableAddressTypeEmail, uuid.Nil, i.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.d.Audit().
+		WithField("identity_id", i.ID).
+		WithSensitiveField("email_address", to).
+		Info("Sending out login code.")
+	if err := s.send(ctx, identity.AddressTypeEmail, templates.NewLoginCodeValid(s.d.Config(ctx),
+		&templates.LoginCodeValidModel{To: to, LoginCode: code})); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// sendRegistrationCode sends a registration code to the specified address and returns the state which has to be
+// stored in the flow.
+func (s *Strategy) sendRegistrationCode(ctx context.Context, id uuid.UUID, to string, traits json.RawMessage) (*codeState, error) {
+	code, state, err := s.newCode(ctx, id, identity.VerifiableAddressTypeEmail, uuid.Nil, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	state.Traits = traits
+
+	s.d.Audit().
+		WithSensitiveField("email_address", to).
+		Info("Sending out registration code.")
+	if err := s.send(ctx, identity.AddressTypeEmail, templates.NewRegistrationCodeValid(s.d.Config(ctx),
+		&templates.RegistrationCodeValidModel{To: to, RegistrationCode: code})); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (s *Strategy) send(ctx context.Context, via string, t courier.EmailTemplate) error {
+	switch via {
+	case identity.AddressTypeEmail:
+		_, err := s.d.Courier(ctx).QueueEmail(ctx, t)
+		return err
+	case identity.AddressTypePhone:
+		st, ok := t.(courier.SMSTemplate)
+		if !ok {
+			return errors.Errorf("template %T can not be sent as a text message", t)
+		}
+		_, err := s.d.Courier(ctx).QueueSMS(ctx, st)
+		return err
+	default:
+		return errors.Errorf("received unexpected via type: %s", via)
+	}
+}
@@ -0,0 +1,88 @@
+package code
+
+import (
+	"github.com/ory/x/decoderx"
+	"kratos/courier"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/selfservice/flow/login"
+	"kratos/selfservice/flow/recovery"
+	"kratos/selfservice/flow/registration"
+	"kratos/selfservice/flow/settings"
+	"kratos/selfservice/flow/verification"
+	"kratos/session"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+var _ recovery.Strategy = new(Strategy)
+var _ verification.Strategy = new(Strategy)
+var _ login.Strategy = new(Strategy)
+var _ registration.Strategy = new(Strategy)
+
+type (
+	strategyDependencies interface {
+		x.CSRFProvider
+		x.CSRFTokenGeneratorProvider
+		x.WriterProvider
+		x.LoggingProvider
+
+		config.Provider
+
+		session.ManagementProvider
+		session.PersistenceProvider
+		settings.HandlerProvider
+		settings.FlowPersistenceProvider
+
+		identity.PoolProvider
+		identity.PrivilegedPoolProvider
+		identity.ValidationProvider
+
+		courier.Provider
+
+		recovery.ErrorHandlerProvider
+		recovery.HookExecutorProvider
+		recovery.FlowPersistenceProvider
+		recovery.StrategyProvider
+
+		verification.ErrorHandlerProvider
+		verification.HookExecutorProvider
+		verification.FlowPersistenceProvider
+		verification.StrategyProvider
+
+		login.FlowPersistenceProvider
+
+		registration.FlowPersistenceProvider
+
+		CodePersistenceProvider
+	}
+
+	// Strategy sends a short numeric code to the user which has to be entered in the
+	// recovery or verification flow. Contrary to the link strategy, the flow can be completed
+	// on a different device than the one which received the message. If passwordless is enabled,
+	// the code can also be used to sign up and sign in with only an email address.
+	Strategy struct {
+		d  strategyDependencies
+		dx *decoderx.HTTP
+	}
+)
+
+func NewStrategy(d strategyDependencies) *Strategy {
+	return &Strategy{d: d, dx: decoderx.NewHTTP()}
+}
+
+func (s *Strategy) RecoveryNodeGroup() node.Group {
+	return node.RecoveryCodeGroup
+}
+
+func (s *Strategy) VerificationNodeGroup() node.Group {
+	return node.VerificationCodeGroup
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeCodeAuth
+}
+
+func (s *Strategy) NodeGroup() node.Group {
+	return node.CodeGroup
+}
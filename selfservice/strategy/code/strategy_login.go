@@ -0,0 +1,171 @@
+package code
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/decoderx"
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/login"
+	"kratos/text"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+func (s *Strategy) RegisterLoginRoutes(_ *x.RouterPublic) {
+}
+
+// PopulateLoginMethod only adds nodes if passwordless login is enabled. Otherwise, codes are only used for
+// account recovery and verification.
+func (s *Strategy) PopulateLoginMethod(r *http.Request, f *login.Flow) error {
+	if !s.d.Config(r.Context()).SelfServiceCodePasswordless() {
+		return nil
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.SetNode(node.NewInputField("identifier", nil, node.CodeGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeLabelID()))
+	f.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.CodeGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLoginCode()))
+
+	return nil
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceLoginFlowWithCodeMethod
+type submitSelfServiceLoginFlowWithCodeMethodParameters struct {
+	// The Flow ID
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	Body submitSelfServiceLoginFlowWithCodeMethod
+}
+
+// swagger:model submitSelfServiceLoginFlowWithCodeMethod
+type submitSelfServiceLoginFlowWithCodeMethod struct {
+	// Method should be set to "code" when logging in using the code strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// Identifier is the email address of the identity which signs in. A code is sent
+	// to this address if the code is not set.
+	Identifier string `json:"identifier"`
+
+	// Code is the code which was sent to the email address.
+	Code string `json:"code"`
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, p *submitSelfServiceLoginFlowWithCodeMethod, err error) error {
+	if f != nil {
+		f.UI.Nodes.ResetNodes("code")
+		if p != nil {
+			f.UI.Nodes.SetValueAttribute("identifier", p.Identifier)
+		}
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow) (i *identity.Identity, err error) {
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	if !s.d.Config(r.Context()).SelfServiceCodePasswordless() || f.PendingSecondFactor() != uuid.Nil {
+		// Codes can only be used as the first factor and only if passwordless login is enabled.
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	var p submitSelfServiceLoginFlowWithCodeMethod
+	if err := s.dx.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginMethodSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, &p, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, &p, err)
+	}
+
+	if len(p.Code) == 0 {
+		return nil, s.loginSendCode(w, r, f, &p)
+	}
+
+	return s.loginUseCode(r, f, &p)
+}
+
+// loginSendCode sends a code to the identifier and asks the user to enter it.
+func (s *Strategy) loginSendCode(w http.ResponseWriter, r *http.Request, f *login.Flow, p *submitSelfServiceLoginFlowWithCodeMethod) error {
+	if len(p.Identifier) == 0 {
+		return s.handleLoginError(r, f, p, schema.NewRequiredError("#/identifier", "identifier"))
+	}
+
+	id, err := s.issueCode(r.Context(), f.ID, f.ExpiresAt)
+	if err != nil {
+		return s.handleLoginError(r, f, p, err)
+	}
+
+	// A nil state (unknown identifier) invalidates any code which was sent previously.
+	state, err := s.sendLoginCode(r.Context(), id, p.Identifier)
+	if err != nil {
+		return s.handleLoginError(r, f, p, err)
+	}
+
+	f.InternalContext, err = setCodeState(f.InternalContext, state)
+	if err != nil {
+		return s.handleLoginError(r, f, p, err)
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.Nodes.SetValueAttribute("identifier", p.Identifier)
+	f.UI.Nodes.Upsert(NewCodeNode(node.CodeGroup))
+
+	f.Active = s.ID()
+	f.UI.Messages.Set(text.NewInfoLoginCodeSent())
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return s.handleLoginError(r, f, p, err)
+	}
+
+	if f.Type == flow.TypeAPI {
+		s.d.Writer().Write(w, r, f)
+	} else {
+		http.Redirect(w, r, f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusFound)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+// loginUseCode checks the code and returns the identity it was sent to.
+func (s *Strategy) loginUseCode(r *http.Request, f *login.Flow, p *submitSelfServiceLoginFlowWithCodeMethod) (*identity.Identity, error) {
+	// The updated state is persisted by the error handler if the code is wrong.
+	state, ic, err := s.useCode(r.Context(), f.InternalContext, p.Code)
+	f.InternalContext = ic
+	if err != nil {
+		return nil, s.handleLoginError(r, f, p, err)
+	}
+
+	// The code may only be used once.
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return nil, s.handleLoginError(r, f, p, err)
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentity(r.Context(), state.IdentityID)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, p, err)
+	}
+
+	return i, nil
+}
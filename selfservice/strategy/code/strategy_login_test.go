@@ -0,0 +1,204 @@
+package code_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/assertx"
+	"github.com/ory/x/ioutilx"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/login"
+	"kratos/text"
+	"kratos/x"
+)
+
+func TestLogin(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	initViper(t, conf)
+	conf.MustSet(config.ViperKeyCodePasswordless, true)
+
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	public, _ := testhelpers.NewKratosServer(t, reg)
+
+	var createIdentity = func(t *testing.T) string {
+		email := x.NewUUID().String() + "@ory.sh"
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), &identity.Identity{
+			Traits:   identity.Traits(`{"email":"` + email + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}, identity.ManagerAllowWriteProtectedTraits))
+		return email
+	}
+
+	var newClient = func(t *testing.T, isAPI bool) *http.Client {
+		if isAPI {
+			return testhelpers.NewDebugClient(t)
+		}
+		return testhelpers.NewClientWithCookies(t)
+	}
+
+	var requestCode = func(t *testing.T, isAPI bool, hc *http.Client, email string) string {
+		return testhelpers.SubmitLoginForm(t, isAPI, hc, public, func(v url.Values) {
+			v.Set("method", "code")
+			v.Set("identifier", email)
+		}, identity.CredentialsTypeCodeAuth, false, http.StatusOK, testhelpers.ExpectURL(isAPI, public.URL+login.RouteSubmitFlow, conf.SelfServiceFlowLoginUI().String()))
+	}
+
+	t.Run("description=should sign in with a code", func(t *testing.T) {
+		var check = func(t *testing.T, isAPI bool) {
+			email := createIdentity(t)
+			hc := newClient(t, isAPI)
+
+			f := requestCode(t, isAPI, hc, email)
+			assertx.EqualAsJSON(t, text.NewInfoLoginCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+			assert.EqualValues(t, identity.CredentialsTypeCodeAuth, gjson.Get(f, "active").String(), "%s", f)
+			code := expectCode(t, reg, email, "Use this code to sign in")
+
+			actual, res := submitCode(t, isAPI, hc, f, code)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+			if isAPI {
+				assert.NotEmpty(t, gjson.Get(actual, "session_token").String(), "%s", actual)
+				assert.Equal(t, email, gjson.Get(actual, "session.identity.traits.email").String(), "%s", actual)
+				assert.NotEmpty(t, gjson.Get(actual, "session.devices.0.user_agent").String(), "the device must be recorded when the session is issued: %s", actual)
+			} else {
+				assert.Contains(t, res.Request.URL.String(), "return-ts")
+				assert.Equal(t, email, gjson.Get(actual, "identity.traits.email").String(), "%s", actual)
+			}
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should not sign in with a wrong code", func(t *testing.T) {
+		email := createIdentity(t)
+		hc := newClient(t, true)
+
+		f := requestCode(t, true, hc, email)
+		code := expectCode(t, reg, email, "Use this code to sign in")
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		actual, res := submitCode(t, true, hc, f, wrong)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", actual)
+		assert.Empty(t, gjson.Get(actual, "session_token").String(), "%s", actual)
+	})
+
+	t.Run("description=should not sign in if the identity is inactive", func(t *testing.T) {
+		email := createIdentity(t)
+		i, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeCodeAuth, email)
+		require.NoError(t, err)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentityState(context.Background(), i.ID, identity.StateInactive))
+
+		hc := newClient(t, true)
+		f := requestCode(t, true, hc, email)
+		code := expectCode(t, reg, email, "Use this code to sign in")
+
+		actual, res := submitCode(t, true, hc, f, code)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.ErrorValidationIdentityInactive, gjson.Get(actual, "ui.messages.0.id").Int(), "%s", actual)
+		assert.Empty(t, gjson.Get(actual, "session_token").String(), "%s", actual)
+	})
+
+	t.Run("description=should invalidate the code after too many attempts", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCodeMaxAttempts, 1)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCodeMaxAttempts, 5)
+		})
+
+		email := createIdentity(t)
+		hc := newClient(t, true)
+
+		f := requestCode(t, true, hc, email)
+		code := expectCode(t, reg, email, "Use this code to sign in")
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		actual, res := submitCode(t, true, hc, f, wrong)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.ErrorValidationCodeTooManyAttempts, gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", actual)
+
+		actual, res = submitCode(t, true, hc, f, code)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.Empty(t, gjson.Get(actual, "session_token").String(), "%s", actual)
+	})
+
+	t.Run("description=should not send more codes than allowed per flow", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCodeMaxSent, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCodeMaxSent, 5)
+		})
+
+		email := createIdentity(t)
+		hc := newClient(t, true)
+
+		var resendCode = func(f string) (string, *http.Response) {
+			values := url.Values{"method": {"code"}, "identifier": {email}}
+			res, err := hc.Do(testhelpers.NewRequest(t, true, "POST", gjson.Get(f, "ui.action").String(),
+				bytes.NewBufferString(testhelpers.EncodeFormAsJSON(t, true, values))))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			return string(ioutilx.MustReadAll(res.Body)), res
+		}
+
+		f := requestCode(t, true, hc, email)
+		expectCode(t, reg, email, "Use this code to sign in")
+
+		actual, res := resendCode(f)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+		code := expectCode(t, reg, email, "Use this code to sign in")
+
+		actual, res = resendCode(f)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.ErrorValidationCodeTooManySent, gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", actual)
+
+		actual, res = submitCode(t, true, hc, f, code)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "the previous code must remain usable: %s", actual)
+	})
+
+	t.Run("description=should not reveal whether an account exists", func(t *testing.T) {
+		hc := newClient(t, true)
+
+		f := requestCode(t, true, hc, x.NewUUID().String()+"@ory.sh")
+		assertx.EqualAsJSON(t, text.NewInfoLoginCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+
+		actual, res := submitCode(t, true, hc, f, "123456")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", actual)
+	})
+
+	t.Run("description=should not show the method if passwordless is disabled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCodePasswordless, false)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCodePasswordless, true)
+		})
+
+		f := testhelpers.InitializeLoginFlowViaAPI(t, newClient(t, true), public, false)
+		for _, n := range f.Ui.Nodes {
+			assert.NotEqual(t, "code", n.Group)
+		}
+	})
+}
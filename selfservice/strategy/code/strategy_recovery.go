@@ -0,0 +1,318 @@
+package code
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/recovery"
+	"kratos/session"
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+func (s *Strategy) RecoveryStrategyID() string {
+	return recovery.StrategyRecoveryCodeName
+}
+
+func (s *Strategy) PopulateRecoveryMethod(r *http.Request, f *recovery.Flow) error {
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, n := range s.addressNodes(r.Context(), node.RecoveryCodeGroup, nil, nil) {
+		f.UI.GetNodes().Upsert(n)
+	}
+	f.UI.GetNodes().Append(NewMethodNode(node.RecoveryCodeGroup))
+
+	return nil
+}
+
+// swagger:model submitSelfServiceRecoveryFlowWithCodeMethod
+// nolint:deadcode,unused
+type submitSelfServiceRecoveryFlowWithCodeMethod struct {
+	// Email to Recover
+	//
+	// Needs to be set when requesting a code. If the email is a registered
+	// recovery email, a recovery code will be sent. If the email is not known,
+	// a email with details on what happened will be sent instead.
+	//
+	// format: email
+	// in: body
+	Email string `json:"email" form:"email"`
+
+	// Phone Number to Recover
+	//
+	// May be set instead of the email if text messages are enabled. If the phone
+	// number is a registered recovery phone number, a recovery code will be sent
+	// by text message.
+	//
+	// in: body
+	Phone string `json:"phone" form:"phone"`
+
+	// Recovery Code
+	//
+	// The code which was sent to the email address or phone number. If set,
+	// the code is checked instead of sending a new one.
+	//
+	// in: body
+	Code string `json:"code" form:"code"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `form:"csrf_token" json:"csrf_token"`
+}
+
+// swagger:route POST /self-service/recovery/methods/code public submitSelfServiceRecoveryFlowWithCodeMethod
+//
+// Complete Recovery Flow with Code Method
+//
+// Use this endpoint to complete a recovery flow using the code method. This endpoint
+// behaves differently for API and browser flows and has several states:
+//
+// - `choose_method` expects `flow` (in the URL query) and `email` or `phone` (in the body) to be sent
+//   and works with API- and Browser-initiated flows. A short numeric code is sent to the address.
+//	 - For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
+//     and a HTTP 302 Found redirect with a fresh recovery flow if the flow was otherwise invalid (e.g. expired).
+//	 - For Browser clients it returns a HTTP 302 Found redirect to the Recovery UI URL with the Recovery Flow ID appended.
+// - `sent_email` expects the `code` to be sent in the body. If the code is valid, API clients receive a session
+//   token and Browser clients are redirected to the Settings UI URL and instructed to update their password. If the
+//   code is invalid, HTTP 400 is returned. Once the code expired or was entered incorrectly too many times, a new
+//   recovery flow is initiated. Submitting the form without a code sends a new code.
+//
+// More information can be found at [Ory Kratos Account Recovery Documentation](../self-service/flows/account-recovery.mdx).
+//
+//     Consumes:
+//     - application/json
+//     - application/x-www-form-urlencoded
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: recoveryViaApiResponse
+//       400: recoveryFlow
+//       302: emptyResponse
+//       500: genericError
+func (s *Strategy) Recover(w http.ResponseWriter, r *http.Request, f *recovery.Flow) (err error) {
+	body, err := s.decodeRecovery(r)
+	if err != nil {
+		return s.handleRecoveryError(w, r, nil, body, err)
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.RecoveryStrategyID(), body.Method, s.d); err != nil {
+		return s.handleRecoveryError(w, r, nil, body, err)
+	}
+
+	if err := f.Valid(); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	switch f.State {
+	case recovery.StateChooseMethod:
+		fallthrough
+	case recovery.StateEmailSent:
+		if len(body.Code) > 0 {
+			return s.recoveryUseCode(w, r, f, body)
+		}
+		return s.recoveryHandleFormSubmission(w, r, f, body)
+	case recovery.StatePassedChallenge:
+		// was already handled, do not allow retry
+		return s.retryRecoveryFlowWithMessage(w, r, f.Type, text.NewErrorValidationRecoveryRetrySuccess())
+	default:
+		return s.retryRecoveryFlowWithMessage(w, r, f.Type, text.NewErrorValidationRecoveryStateFailure())
+	}
+}
+
+func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload) error {
+	via, to, err := s.addressFromPayload(r.Context(), body.Email, body.Phone)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	id, err := s.issueCode(r.Context(), f.ID, f.ExpiresAt)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	// A nil state (unknown address) invalidates any code which was sent previously.
+	state, err := s.sendRecoveryCode(r.Context(), id, via, to)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	f.InternalContext, err = setCodeState(f.InternalContext, state)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	f.UI.Reset("email", "phone")
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, n := range s.addressNodes(r.Context(), node.RecoveryCodeGroup, body.Email, body.Phone) {
+		f.UI.GetNodes().Upsert(n)
+	}
+	f.UI.GetNodes().Upsert(NewCodeNode(node.RecoveryCodeGroup))
+
+	f.Active = sqlxx.NullString(s.RecoveryNodeGroup())
+	f.State = recovery.StateEmailSent
+	f.UI.Messages.Set(text.NewRecoveryCodeSent())
+	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) recoveryUseCode(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload) error {
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	state, ic, err := s.useCode(r.Context(), f.InternalContext, body.Code)
+	f.InternalContext = ic
+	if err != nil {
+		if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
+			return s.handleRecoveryError(w, r, f, body, err)
+		}
+
+		switch codeErrorID(err) {
+		case text.ErrorValidationCodeExpired:
+			return s.retryRecoveryFlowWithMessage(w, r, f.Type, text.NewErrorValidationRecoveryCodeExpired())
+		case text.ErrorValidationCodeTooManyAttempts:
+			return s.retryRecoveryFlowWithMessage(w, r, f.Type, text.NewErrorValidationRecoveryCodeTooManyAttempts())
+		}
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	return s.recoveryIssueSession(w, r, f, state.IdentityID)
+}
+
+func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request, f *recovery.Flow, recoveredID uuid.UUID) error {
+	recovered, err := s.d.IdentityPool().GetIdentity(r.Context(), recoveredID)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
+	f.UI.Messages.Clear()
+	f.State = recovery.StatePassedChallenge
+	f.RecoveredIdentityID = uuid.NullUUID{
+		UUID:  recoveredID,
+		Valid: true,
+	}
+	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
+	sess := session.NewActiveSession(recovered, s.d.Config(r.Context()), time.Now().UTC())
+	sess.SeenFrom(r, s.d.Config(r.Context()), sess.IssuedAt)
+
+	// The hooks run before the session is stored so that a failing hook does not leave a session behind.
+	if err := s.d.RecoveryExecutor().PostRecoveryHook(w, r, f, sess); err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
+	if f.Type == flow.TypeAPI {
+		if err := s.d.SessionPersister().CreateSession(r.Context(), sess); err != nil {
+			return s.handleRecoveryError(w, r, f, nil, err)
+		}
+
+		s.d.Writer().Write(w, r, &recovery.APIFlowResponse{Session: sess.Declassify(), Token: sess.Token})
+		return errors.WithStack(flow.ErrCompletedByStrategy)
+	}
+
+	if err := s.d.SessionManager().CreateAndIssueCookie(r.Context(), w, r, sess); err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
+	sf, err := s.d.SettingsHandler().NewFlow(w, r, sess.Identity, flow.TypeBrowser)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
+	sf.UI.Messages.Set(text.NewRecoverySuccessful(time.Now().Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge())))
+	if err := s.d.SettingsFlowPersister().UpdateSettingsFlow(r.Context(), sf); err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
+	http.Redirect(w, r, sf.AppendTo(s.d.Config(r.Context()).SelfServiceFlowSettingsUI()).String(), http.StatusFound)
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) retryRecoveryFlowWithMessage(w http.ResponseWriter, r *http.Request, ft flow.Type, message *text.Message) error {
+	s.d.Logger().WithRequest(r).WithField("message", message).Debug("A recovery flow is being retried because a validation error occurred.")
+
+	req, err := recovery.NewFlow(s.d.Config(r.Context()), s.d.Config(r.Context()).SelfServiceFlowRecoveryRequestLifespan(), s.d.GenerateCSRFToken(r), r, s.d.RecoveryStrategies(r.Context()), ft)
+	if err != nil {
+		return err
+	}
+
+	req.UI.Messages.Add(message)
+	if err := s.d.RecoveryFlowPersister().CreateRecoveryFlow(r.Context(), req); err != nil {
+		return err
+	}
+
+	if ft == flow.TypeBrowser {
+		http.Redirect(w, r, req.AppendTo(s.d.Config(r.Context()).SelfServiceFlowRecoveryUI()).String(), http.StatusFound)
+	} else {
+		http.Redirect(w, r, urlx.CopyWithQuery(urlx.AppendPaths(s.d.Config(r.Context()).SelfPublicURL(r),
+			recovery.RouteGetFlow), url.Values{"id": {req.ID.String()}}).String(), http.StatusFound)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) handleRecoveryError(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload, err error) error {
+	if f != nil {
+		email, phone := "", ""
+		if body != nil {
+			email, phone = body.Email, body.Phone
+		}
+
+		f.UI.Reset("email", "phone")
+		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		for _, n := range s.addressNodes(r.Context(), node.RecoveryCodeGroup, email, phone) {
+			f.UI.GetNodes().Upsert(n)
+		}
+	}
+
+	return err
+}
+
+type recoverySubmitPayload struct {
+	Method    string `json:"method" form:"method"`
+	Code      string `json:"code" form:"code"`
+	CSRFToken string `json:"csrf_token" form:"csrf_token"`
+	Flow      string `json:"flow" form:"flow"`
+	Email     string `json:"email" form:"email"`
+	Phone     string `json:"phone" form:"phone"`
+}
+
+func (s *Strategy) decodeRecovery(r *http.Request) (*recoverySubmitPayload, error) {
+	var body recoverySubmitPayload
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(recoveryMethodSchema)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := s.dx.Decode(r, &body, compiler,
+		decoderx.HTTPDecoderUseQueryAndBody(),
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &body, nil
+}
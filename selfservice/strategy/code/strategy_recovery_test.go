@@ -0,0 +1,309 @@
+package code_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/assertx"
+	"github.com/ory/x/sqlxx"
+	"kratos/courier"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/recovery"
+	"kratos/text"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+func TestRecovery(t *testing.T) {
+	var identityToRecover = &identity.Identity{
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			"password": {Type: "password", Identifiers: []string{"recoverme@ory.sh"}, Config: sqlxx.JSONRawMessage(`{"hashed_password":"foo"}`)}},
+		Traits:   identity.Traits(`{"email":"recoverme@ory.sh"}`),
+		SchemaID: config.DefaultIdentityTraitsSchemaID,
+	}
+	var recoveryEmail = gjson.GetBytes(identityToRecover.Traits, "email").String()
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	initViper(t, conf)
+
+	_ = testhelpers.NewRecoveryUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	public, _ := testhelpers.NewKratosServer(t, reg)
+
+	require.NoError(t, reg.IdentityManager().Create(context.Background(), identityToRecover,
+		identity.ManagerAllowWriteProtectedTraits))
+
+	var newClient = func(t *testing.T, isAPI bool) *http.Client {
+		if isAPI {
+			return testhelpers.NewDebugClient(t)
+		}
+		return testhelpers.NewClientWithCookies(t)
+	}
+
+	var requestCode = func(t *testing.T, isAPI bool, hc *http.Client, email string) string {
+		return testhelpers.SubmitRecoveryForm(t, isAPI, hc, public, func(v url.Values) {
+			v.Set("email", email)
+		}, http.StatusOK, testhelpers.ExpectURL(isAPI, public.URL+recovery.RouteSubmitFlow, conf.SelfServiceFlowRecoveryUI().String()))
+	}
+
+	var expectInvalidCode = func(t *testing.T, isAPI bool, actual string, res *http.Response) {
+		assert.EqualValues(t, testhelpers.ExpectStatusCode(isAPI, http.StatusBadRequest, http.StatusOK), res.StatusCode, "%s", actual)
+		assert.Contains(t, res.Request.URL.String(), testhelpers.ExpectURL(isAPI, public.URL+recovery.RouteSubmitFlow, conf.SelfServiceFlowRecoveryUI().String()))
+		assert.EqualValues(t, text.NewErrorValidationCodeInvalid().Text,
+			gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.text").String(), "%s", actual)
+	}
+
+	var expectRetriedFlow = func(t *testing.T, isAPI bool, actual string, res *http.Response, message *text.Message) {
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, "%s", actual)
+		assert.Contains(t, res.Request.URL.String(), testhelpers.ExpectURL(isAPI, public.URL+recovery.RouteGetFlow, conf.SelfServiceFlowRecoveryUI().String()))
+		assert.EqualValues(t, message.ID, gjson.Get(actual, "ui.messages.0.id").Int(), "%s", actual)
+	}
+
+	t.Run("description=should set all the correct recovery payloads", func(t *testing.T) {
+		c := testhelpers.NewClientWithCookies(t)
+		rs := testhelpers.GetRecoveryFlow(t, c, public)
+
+		assertx.EqualAsJSON(t, json.RawMessage(`[
+  {
+    "attributes": {
+      "disabled": false,
+      "name": "csrf_token",
+      "required": true,
+      "type": "hidden",
+      "value": "`+x.FakeCSRFToken+`"
+    },
+    "group": "default",
+    "messages": null,
+    "meta": {},
+    "type": "input"
+  },
+  {
+    "attributes": {
+      "disabled": false,
+      "name": "email",
+      "required": true,
+      "type": "email"
+    },
+    "group": "code",
+    "messages": null,
+    "meta": {},
+    "type": "input"
+  },
+  {
+    "attributes": {
+      "disabled": false,
+      "name": "method",
+      "type": "submit",
+      "value": "code"
+    },
+    "group": "code",
+    "messages": null,
+    "meta": {
+      "label": {
+        "id": 1070005,
+        "text": "Submit",
+        "type": "info"
+      }
+    },
+    "type": "input"
+  }
+]`), rs.Ui.Nodes)
+		assert.Empty(t, rs.Ui.Messages)
+	})
+
+	t.Run("description=should send a code to an unknown email address but not accept any code", func(t *testing.T) {
+		var check = func(t *testing.T, isAPI bool) {
+			email := x.NewUUID().String() + "@ory.sh"
+			hc := newClient(t, isAPI)
+
+			f := requestCode(t, isAPI, hc, email)
+			assert.EqualValues(t, node.RecoveryCodeGroup, gjson.Get(f, "active").String(), "%s", f)
+			assertx.EqualAsJSON(t, text.NewRecoveryCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+			assert.True(t, gjson.Get(f, "ui.nodes.#(attributes.name==code)").Exists(), "%s", f)
+
+			message := testhelpers.CourierExpectMessage(t, reg, email, "Account access attempted")
+			assert.Contains(t, message.Body, "If this was you, check if you signed up using a different address.")
+
+			actual, res := submitCode(t, isAPI, hc, f, "123456")
+			expectInvalidCode(t, isAPI, actual, res)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should recover an account", func(t *testing.T) {
+		t.Run("type=browser", func(t *testing.T) {
+			hc := newClient(t, false)
+			f := requestCode(t, false, hc, recoveryEmail)
+			assertx.EqualAsJSON(t, text.NewRecoveryCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+			code := expectCode(t, reg, recoveryEmail, "Recover access to your account")
+
+			actual, res := submitCode(t, false, hc, f, code)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowSettingsUI().String())
+			assert.Equal(t, text.NewRecoverySuccessful(time.Now().Add(time.Hour)).Text,
+				gjson.Get(actual, "ui.messages.0.text").String(), "%s", actual)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			hc := newClient(t, true)
+			f := requestCode(t, true, hc, recoveryEmail)
+			code := expectCode(t, reg, recoveryEmail, "Recover access to your account")
+
+			actual, res := submitCode(t, true, hc, f, code)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+			assert.NotEmpty(t, gjson.Get(actual, "session_token").String(), "%s", actual)
+			assert.Equal(t, identityToRecover.ID.String(), gjson.Get(actual, "session.identity.id").String(), "%s", actual)
+
+			sess, err := reg.SessionPersister().GetSessionByToken(context.Background(), gjson.Get(actual, "session_token").String())
+			require.NoError(t, err)
+			assert.Equal(t, identityToRecover.ID, sess.IdentityID)
+		})
+	})
+
+	t.Run("description=should not accept a code twice", func(t *testing.T) {
+		hc := newClient(t, true)
+		f := requestCode(t, true, hc, recoveryEmail)
+		code := expectCode(t, reg, recoveryEmail, "Recover access to your account")
+
+		_, res := submitCode(t, true, hc, f, code)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		actual, res := submitCode(t, true, hc, f, code)
+		expectRetriedFlow(t, true, actual, res, text.NewErrorValidationRecoveryRetrySuccess())
+	})
+
+	t.Run("description=should reject a wrong code and keep the correct one valid", func(t *testing.T) {
+		var check = func(t *testing.T, isAPI bool) {
+			hc := newClient(t, isAPI)
+			f := requestCode(t, isAPI, hc, recoveryEmail)
+			code := expectCode(t, reg, recoveryEmail, "Recover access to your account")
+
+			wrong := "000000"
+			if code == wrong {
+				wrong = "111111"
+			}
+			actual, res := submitCode(t, isAPI, hc, f, wrong)
+			expectInvalidCode(t, isAPI, actual, res)
+
+			_, res = submitCode(t, isAPI, hc, f, code)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Request.URL.String(), testhelpers.ExpectURL(isAPI, public.URL+recovery.RouteSubmitFlow, conf.SelfServiceFlowSettingsUI().String()))
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should invalidate the code after too many attempts", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCodeMaxAttempts, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCodeMaxAttempts, 5)
+		})
+
+		var check = func(t *testing.T, isAPI bool) {
+			hc := newClient(t, isAPI)
+			f := requestCode(t, isAPI, hc, recoveryEmail)
+			code := expectCode(t, reg, recoveryEmail, "Recover access to your account")
+
+			wrong := "000000"
+			if code == wrong {
+				wrong = "111111"
+			}
+			actual, res := submitCode(t, isAPI, hc, f, wrong)
+			expectInvalidCode(t, isAPI, actual, res)
+
+			actual, res = submitCode(t, isAPI, hc, f, wrong)
+			expectRetriedFlow(t, isAPI, actual, res, text.NewErrorValidationRecoveryCodeTooManyAttempts())
+
+			// The correct code is no longer accepted either.
+			actual, res = submitCode(t, isAPI, hc, f, code)
+			expectInvalidCode(t, isAPI, actual, res)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should not accept an expired code", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCodeLifespan, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCodeLifespan, "15m")
+		})
+
+		var check = func(t *testing.T, isAPI bool) {
+			hc := newClient(t, isAPI)
+			f := requestCode(t, isAPI, hc, recoveryEmail)
+			code := expectCode(t, reg, recoveryEmail, "Recover access to your account")
+
+			actual, res := submitCode(t, isAPI, hc, f, code)
+			expectRetriedFlow(t, isAPI, actual, res, text.NewErrorValidationRecoveryCodeExpired())
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should recover an account using a phone number", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+		})
+
+		phone := "+12065550152"
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), &identity.Identity{
+			Traits:   identity.Traits(`{"email":"recovermyphonewithcode@ory.sh","phone":"` + phone + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}, identity.ManagerAllowWriteProtectedTraits))
+
+		hc := newClient(t, false)
+		f := testhelpers.SubmitRecoveryForm(t, false, hc, public, func(v url.Values) {
+			v.Del("email")
+			v.Set("phone", phone)
+		}, http.StatusOK, conf.SelfServiceFlowRecoveryUI().String())
+		assertx.EqualAsJSON(t, text.NewRecoveryCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+
+		message := testhelpers.CourierExpectMessage(t, reg, phone, "")
+		assert.Equal(t, courier.MessageTypeSMS, message.Type)
+		code := expectCode(t, reg, phone, "")
+
+		_, res := submitCode(t, false, hc, f, code)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowSettingsUI().String())
+	})
+}
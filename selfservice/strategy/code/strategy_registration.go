@@ -0,0 +1,206 @@
+package code
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlxx"
+	"kratos/identity"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/registration"
+	"kratos/text"
+	"kratos/ui/container"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+func (s *Strategy) RegisterRegistrationRoutes(_ *x.RouterPublic) {
+}
+
+// PopulateRegistrationMethod only adds nodes if passwordless registration is enabled. Traits which were already
+// added by another method are shared instead of being moved to the code group.
+func (s *Strategy) PopulateRegistrationMethod(r *http.Request, f *registration.Flow) error {
+	if !s.d.Config(r.Context()).SelfServiceCodePasswordless() {
+		return nil
+	}
+
+	nodes, err := container.NodesFromJSONSchema(node.CodeGroup, s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String(), "", nil)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		if f.UI.Nodes.Find(n.ID()) == nil {
+			f.UI.SetNode(n)
+		}
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.Nodes.Append(node.NewInputField("method", s.ID(), node.CodeGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoRegistrationCode()))
+
+	return nil
+}
+
+// swagger:model submitSelfServiceRegistrationFlowWithCodeMethod
+// nolint:deadcode,unused
+type submitSelfServiceRegistrationFlowWithCodeMethod struct {
+	// Method should be set to "code" when signing up using the code strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// The identity's traits. A code is sent to the email address contained in the traits
+	// if the code is not set.
+	Traits json.RawMessage `json:"traits"`
+
+	// Code is the code which was sent to the email address. Once it is set, the traits
+	// the code was sent for are used.
+	Code string `json:"code"`
+
+	// Sending the anti-csrf token is only required for browser registration flows.
+	CSRFToken string `json:"csrf_token"`
+}
+
+type registrationSubmitPayload struct {
+	Method    string          `json:"method"`
+	Traits    json.RawMessage `json:"traits"`
+	Code      string          `json:"code"`
+	CSRFToken string          `json:"csrf_token"`
+}
+
+func (s *Strategy) handleRegistrationError(r *http.Request, f *registration.Flow, p *registrationSubmitPayload, err error) error {
+	if f != nil {
+		if p != nil {
+			for _, n := range container.NewFromJSON("", node.CodeGroup, p.Traits, "traits").Nodes {
+				// we only set the value and not the whole field because we want to keep types from the initial form generation
+				f.UI.Nodes.SetValueAttribute(n.ID(), n.Attributes.GetValue())
+			}
+		}
+
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+func (s *Strategy) decodeRegistration(r *http.Request, p *registrationSubmitPayload) error {
+	raw, err := sjson.SetBytes(registrationMethodSchema,
+		"properties.traits.$ref", s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String()+"#/properties/traits")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(raw)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The traits are validated by the identity validator before the code is sent. Once the code is
+	// entered, the traits stored in the flow are used.
+	return s.dx.Decode(r, p, compiler, decoderx.HTTPDecoderSetValidatePayloads(false), decoderx.HTTPDecoderJSONFollowsFormFormat())
+}
+
+func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registration.Flow, i *identity.Identity) (err error) {
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return err
+	}
+
+	if !s.d.Config(r.Context()).SelfServiceCodePasswordless() {
+		return errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	var p registrationSubmitPayload
+	if err := s.decodeRegistration(r, &p); err != nil {
+		return s.handleRegistrationError(r, f, &p, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return s.handleRegistrationError(r, f, &p, err)
+	}
+
+	if len(p.Code) == 0 {
+		return s.registrationSendCode(w, r, f, i, &p)
+	}
+
+	return s.registrationUseCode(r, f, i, &p)
+}
+
+// registrationSendCode validates the traits and sends a code to the email address they contain.
+func (s *Strategy) registrationSendCode(w http.ResponseWriter, r *http.Request, f *registration.Flow, i *identity.Identity, p *registrationSubmitPayload) error {
+	if len(p.Traits) == 0 {
+		p.Traits = json.RawMessage("{}")
+	}
+
+	i.Traits = identity.Traits(p.Traits)
+	if err := s.d.IdentityValidator().Validate(r.Context(), i); err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	c, ok := i.GetCredentials(s.ID())
+	if !ok || len(c.Identifiers) == 0 {
+		return s.handleRegistrationError(r, f, p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("No email address was marked as a code identifier. Contact an administrator, the identity schema is misconfigured.")))
+	}
+
+	id, err := s.issueCode(r.Context(), f.ID, f.ExpiresAt)
+	if err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	state, err := s.sendRegistrationCode(r.Context(), id, c.Identifiers[0], p.Traits)
+	if err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	f.InternalContext, err = setCodeState(f.InternalContext, state)
+	if err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	for _, n := range container.NewFromJSON("", node.CodeGroup, p.Traits, "traits").Nodes {
+		f.UI.Nodes.SetValueAttribute(n.ID(), n.Attributes.GetValue())
+	}
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.Nodes.Upsert(NewCodeNode(node.CodeGroup))
+
+	f.Active = s.ID()
+	f.UI.Messages.Set(text.NewInfoRegistrationCodeSent())
+	if err := s.d.RegistrationFlowPersister().UpdateRegistrationFlow(r.Context(), f); err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	if f.Type == flow.TypeAPI {
+		s.d.Writer().Write(w, r, f)
+	} else {
+		http.Redirect(w, r, f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowRegistrationUI()).String(), http.StatusFound)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+// registrationUseCode checks the code and sets the traits it was sent for. The identity is created by the
+// post registration hook afterwards.
+func (s *Strategy) registrationUseCode(r *http.Request, f *registration.Flow, i *identity.Identity, p *registrationSubmitPayload) error {
+	// The updated state is persisted by the error handler if the code is wrong.
+	state, ic, err := s.useCode(r.Context(), f.InternalContext, p.Code)
+	f.InternalContext = ic
+	if err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	// The code may only be used once.
+	if err := s.d.RegistrationFlowPersister().UpdateRegistrationFlow(r.Context(), f); err != nil {
+		return s.handleRegistrationError(r, f, p, err)
+	}
+
+	i.Traits = identity.Traits(state.Traits)
+	i.SetCredentials(s.ID(), identity.Credentials{Type: s.ID(), Identifiers: []string{}, Config: sqlxx.JSONRawMessage("{}")})
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package code_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/assertx"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/registration"
+	"kratos/text"
+	"kratos/x"
+)
+
+func TestRegistration(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	initViper(t, conf)
+	conf.MustSet(config.ViperKeyCodePasswordless, true)
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypeCodeAuth.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	_ = testhelpers.NewRegistrationUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewRedirSessionEchoTS(t, reg)
+
+	public, _ := testhelpers.NewKratosServer(t, reg)
+
+	var newClient = func(t *testing.T, isAPI bool) *http.Client {
+		if isAPI {
+			return testhelpers.NewDebugClient(t)
+		}
+		return testhelpers.NewClientWithCookies(t)
+	}
+
+	var requestCode = func(t *testing.T, isAPI bool, hc *http.Client, email string, expectedStatusCode int) string {
+		return testhelpers.SubmitRegistrationForm(t, isAPI, hc, public, func(v url.Values) {
+			v.Set("method", "code")
+			v.Set("traits.email", email)
+			v.Del("traits.phone")
+		}, identity.CredentialsTypeCodeAuth, expectedStatusCode, testhelpers.ExpectURL(isAPI, public.URL+registration.RouteSubmitFlow, conf.SelfServiceFlowRegistrationUI().String()))
+	}
+
+	var expectIdentity = func(t *testing.T, email string, exists bool) {
+		_, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypeCodeAuth, email)
+		if exists {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+
+	t.Run("description=should sign up with a code", func(t *testing.T) {
+		var check = func(t *testing.T, isAPI bool) {
+			email := x.NewUUID().String() + "@ory.sh"
+			hc := newClient(t, isAPI)
+
+			f := requestCode(t, isAPI, hc, email, http.StatusOK)
+			assertx.EqualAsJSON(t, text.NewInfoRegistrationCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+			assert.Equal(t, email, gjson.Get(f, `ui.nodes.#(attributes.name=="traits.email").attributes.value`).String(), "%s", f)
+			code := expectCode(t, reg, email, "Complete your account registration")
+			expectIdentity(t, email, false)
+
+			actual, res := submitCode(t, isAPI, hc, f, code)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+			if isAPI {
+				assert.NotEmpty(t, gjson.Get(actual, "session_token").String(), "%s", actual)
+				assert.Equal(t, email, gjson.Get(actual, "identity.traits.email").String(), "%s", actual)
+			} else {
+				assert.Contains(t, res.Request.URL.String(), "return-ts")
+				assert.Equal(t, email, gjson.Get(actual, "identity.traits.email").String(), "%s", actual)
+			}
+			expectIdentity(t, email, true)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should not sign up with a wrong code", func(t *testing.T) {
+		email := x.NewUUID().String() + "@ory.sh"
+		hc := newClient(t, true)
+
+		f := requestCode(t, true, hc, email, http.StatusOK)
+		code := expectCode(t, reg, email, "Complete your account registration")
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		actual, res := submitCode(t, true, hc, f, wrong)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.ErrorValidationCodeInvalid, gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.id").Int(), "%s", actual)
+		expectIdentity(t, email, false)
+	})
+
+	t.Run("description=should not send a code if the traits are invalid", func(t *testing.T) {
+		f := requestCode(t, true, newClient(t, true), "not-an-email", http.StatusBadRequest)
+		assert.NotEmpty(t, gjson.Get(f, `ui.nodes.#(attributes.name=="traits.email").messages.0.text`).String(), "%s", f)
+		assert.False(t, gjson.Get(f, "ui.nodes.#(attributes.name==code)").Exists(), "%s", f)
+	})
+}
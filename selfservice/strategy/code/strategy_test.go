@@ -0,0 +1,49 @@
+package code_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+	"kratos/courier"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/recovery"
+	"kratos/x"
+)
+
+func initViper(t *testing.T, c *config.Config) {
+	c.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/default.schema.json")
+	c.MustSet(config.ViperKeySelfServiceBrowserDefaultReturnTo, "https://www.ory.sh")
+	c.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+identity.CredentialsTypePassword.String()+".enabled", true)
+	c.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+recovery.StrategyRecoveryLinkName+".enabled", false)
+	c.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+recovery.StrategyRecoveryCodeName+".enabled", true)
+	c.MustSet(config.ViperKeySelfServiceRecoveryEnabled, true)
+	c.MustSet(config.ViperKeySelfServiceVerificationEnabled, true)
+}
+
+// expectCode returns the code contained in the latest message sent to the given address.
+func expectCode(t *testing.T, reg courier.PersistenceProvider, to, subject string) string {
+	message := testhelpers.CourierExpectMessage(t, reg, to, subject)
+	code := regexp.MustCompile(`[0-9]{6}`).FindString(message.Body)
+	require.NotEmpty(t, code, "%s", message.Body)
+	return code
+}
+
+// submitCode submits the code to the flow given as JSON.
+func submitCode(t *testing.T, isAPI bool, hc *http.Client, f, code string) (string, *http.Response) {
+	values := url.Values{"method": {"code"}, "code": {code}, "csrf_token": {x.FakeCSRFToken}}
+	res, err := hc.Do(testhelpers.NewRequest(t, isAPI, "POST", gjson.Get(f, "ui.action").String(),
+		bytes.NewBufferString(testhelpers.EncodeFormAsJSON(t, isAPI, values))))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	return string(ioutilx.MustReadAll(res.Body)), res
+}
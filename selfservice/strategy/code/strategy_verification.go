@@ -0,0 +1,300 @@
+package code
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlxx"
+	"github.com/ory/x/urlx"
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/verification"
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+func (s *Strategy) VerificationStrategyID() string {
+	return verification.StrategyVerificationCodeName
+}
+
+func (s *Strategy) PopulateVerificationMethod(r *http.Request, f *verification.Flow) error {
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, n := range s.addressNodes(r.Context(), node.VerificationCodeGroup, nil, nil) {
+		f.UI.GetNodes().Upsert(n)
+	}
+	f.UI.GetNodes().Append(NewMethodNode(node.VerificationCodeGroup))
+
+	return nil
+}
+
+// swagger:model submitSelfServiceVerificationFlowWithCodeMethod
+// nolint:deadcode,unused
+type submitSelfServiceVerificationFlowWithCodeMethod struct {
+	// Email to Verify
+	//
+	// Needs to be set when requesting a code. If the email is a registered
+	// verification email, a verification code will be sent. If the email is not known,
+	// a email with details on what happened will be sent instead.
+	//
+	// format: email
+	// in: body
+	Email string `json:"email" form:"email"`
+
+	// Phone Number to Verify
+	//
+	// May be set instead of the email if text messages are enabled. If the phone
+	// number is a registered phone number, a verification code will be sent
+	// by text message.
+	//
+	// in: body
+	Phone string `json:"phone" form:"phone"`
+
+	// Verification Code
+	//
+	// The code which was sent to the email address or phone number. If set,
+	// the code is checked instead of sending a new one.
+	//
+	// in: body
+	Code string `json:"code" form:"code"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `form:"csrf_token" json:"csrf_token"`
+}
+
+// swagger:route POST /self-service/verification/methods/code public submitSelfServiceVerificationFlowWithCodeMethod
+//
+// Complete Verification Flow with Code Method
+//
+// Use this endpoint to complete a verification flow using the code method. This endpoint
+// behaves differently for API and browser flows and has several states:
+//
+// - `choose_method` expects `flow` (in the URL query) and `email` or `phone` (in the body) to be sent
+//   and works with API- and Browser-initiated flows. A short numeric code is sent to the address.
+//	 - For API clients it either returns a HTTP 200 OK when the form is valid and HTTP 400 OK when the form is invalid
+//     and a HTTP 302 Found redirect with a fresh verification flow if the flow was otherwise invalid (e.g. expired).
+//	 - For Browser clients it returns a HTTP 302 Found redirect to the Verification UI URL with the Verification Flow ID appended.
+// - `sent_email` expects the `code` to be sent in the body. If the code is valid, the address is marked as verified
+//   and the flow transitions to `passed_challenge`. If the code is invalid, HTTP 400 is returned. Once the code expired
+//   or was entered incorrectly too many times, a new verification flow is initiated. Submitting the form without
+//   a code sends a new code.
+//
+// More information can be found at [Ory Kratos Email and Phone Verification Documentation](https://www.ory.sh/docs/kratos/selfservice/flows/verify-email-account-activation).
+//
+//     Consumes:
+//     - application/json
+//     - application/x-www-form-urlencoded
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       400: verificationFlow
+//       302: emptyResponse
+//       500: genericError
+func (s *Strategy) Verify(w http.ResponseWriter, r *http.Request, f *verification.Flow) (err error) {
+	body, err := s.decodeVerification(r)
+	if err != nil {
+		return s.handleVerificationError(w, r, nil, body, err)
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.VerificationStrategyID(), body.Method, s.d); err != nil {
+		return s.handleVerificationError(w, r, nil, body, err)
+	}
+
+	if err := f.Valid(); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	switch f.State {
+	case verification.StateChooseMethod:
+		fallthrough
+	case verification.StateEmailSent:
+		if len(body.Code) > 0 {
+			return s.verificationUseCode(w, r, f, body)
+		}
+		return s.verificationHandleFormSubmission(w, r, f, body)
+	case verification.StatePassedChallenge:
+		return s.retryVerificationFlowWithMessage(w, r, f.Type, text.NewErrorValidationVerificationRetrySuccess())
+	default:
+		return s.retryVerificationFlowWithMessage(w, r, f.Type, text.NewErrorValidationVerificationStateFailure())
+	}
+}
+
+func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *http.Request, f *verification.Flow, body *verificationSubmitPayload) error {
+	via, to, err := s.addressFromPayload(r.Context(), body.Email, body.Phone)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	id, err := s.issueCode(r.Context(), f.ID, f.ExpiresAt)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	// A nil state (unknown address) invalidates any code which was sent previously.
+	state, err := s.sendVerificationCode(r.Context(), id, via, to)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	f.InternalContext, err = setCodeState(f.InternalContext, state)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	f.UI.Reset("email", "phone")
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	for _, n := range s.addressNodes(r.Context(), node.VerificationCodeGroup, body.Email, body.Phone) {
+		f.UI.GetNodes().Upsert(n)
+	}
+	f.UI.GetNodes().Upsert(NewCodeNode(node.VerificationCodeGroup))
+
+	f.Active = sqlxx.NullString(s.VerificationNodeGroup())
+	f.State = verification.StateEmailSent
+	f.UI.Messages.Set(text.NewVerificationCodeSent())
+	if err := s.d.VerificationFlowPersister().UpdateVerificationFlow(r.Context(), f); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) verificationUseCode(w http.ResponseWriter, r *http.Request, f *verification.Flow, body *verificationSubmitPayload) error {
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	state, ic, err := s.useCode(r.Context(), f.InternalContext, body.Code)
+	f.InternalContext = ic
+	if err != nil {
+		if err := s.d.VerificationFlowPersister().UpdateVerificationFlow(r.Context(), f); err != nil {
+			return s.handleVerificationError(w, r, f, body, err)
+		}
+
+		switch codeErrorID(err) {
+		case text.ErrorValidationCodeExpired:
+			return s.retryVerificationFlowWithMessage(w, r, f.Type, text.NewErrorValidationVerificationCodeExpired())
+		case text.ErrorValidationCodeTooManyAttempts:
+			return s.retryVerificationFlowWithMessage(w, r, f.Type, text.NewErrorValidationVerificationCodeTooManyAttempts())
+		}
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentity(r.Context(), state.IdentityID)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	var address *identity.VerifiableAddress
+	for k := range i.VerifiableAddresses {
+		if i.VerifiableAddresses[k].ID == state.AddressID {
+			address = &i.VerifiableAddresses[k]
+		}
+	}
+	if address == nil {
+		// The address was removed after the code had been sent.
+		return s.handleVerificationError(w, r, f, body, schema.NewCodeInvalidError("#/code"))
+	}
+
+	f.UI.Messages.Clear()
+	f.UI.GetNodes().Remove("code")
+	f.State = verification.StatePassedChallenge
+	if err := s.d.VerificationFlowPersister().UpdateVerificationFlow(r.Context(), f); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	address.Verified = true
+	address.VerifiedAt = sqlxx.NullTime(time.Now().UTC())
+	address.Status = identity.VerifiableAddressStatusCompleted
+	if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(r.Context(), address); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := s.d.VerificationExecutor().PostVerificationHook(w, r, f, i); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) retryVerificationFlowWithMessage(w http.ResponseWriter, r *http.Request, ft flow.Type, message *text.Message) error {
+	s.d.Logger().WithRequest(r).WithField("message", message).Debug("A verification flow is being retried because a validation error occurred.")
+
+	f, err := verification.NewFlow(s.d.Config(r.Context()),
+		s.d.Config(r.Context()).SelfServiceFlowVerificationRequestLifespan(), s.d.GenerateCSRFToken(r), r, s.d.VerificationStrategies(r.Context()), ft)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, nil, err)
+	}
+
+	f.UI.Messages.Add(message)
+	if err := s.d.VerificationFlowPersister().CreateVerificationFlow(r.Context(), f); err != nil {
+		return s.handleVerificationError(w, r, f, nil, err)
+	}
+
+	if ft == flow.TypeBrowser {
+		http.Redirect(w, r, f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowVerificationUI()).String(), http.StatusFound)
+	} else {
+		http.Redirect(w, r, urlx.CopyWithQuery(urlx.AppendPaths(s.d.Config(r.Context()).SelfPublicURL(r),
+			verification.RouteGetFlow), url.Values{"id": {f.ID.String()}}).String(), http.StatusFound)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) handleVerificationError(w http.ResponseWriter, r *http.Request, f *verification.Flow, body *verificationSubmitPayload, err error) error {
+	if f != nil {
+		email, phone := "", ""
+		if body != nil {
+			email, phone = body.Email, body.Phone
+		}
+
+		f.UI.Reset("email", "phone")
+		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		for _, n := range s.addressNodes(r.Context(), node.VerificationCodeGroup, email, phone) {
+			f.UI.GetNodes().Upsert(n)
+		}
+	}
+
+	return err
+}
+
+type verificationSubmitPayload struct {
+	Method    string `json:"method" form:"method"`
+	Code      string `json:"code" form:"code"`
+	CSRFToken string `json:"csrf_token" form:"csrf_token"`
+	Flow      string `json:"flow" form:"flow"`
+	Email     string `json:"email" form:"email"`
+	Phone     string `json:"phone" form:"phone"`
+}
+
+func (s *Strategy) decodeVerification(r *http.Request) (*verificationSubmitPayload, error) {
+	var body verificationSubmitPayload
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(verificationMethodSchema)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := s.dx.Decode(r, &body, compiler,
+		decoderx.HTTPDecoderUseQueryAndBody(),
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &body, nil
+}
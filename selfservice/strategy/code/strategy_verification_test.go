@@ -0,0 +1,141 @@
+package code_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/assertx"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/verification"
+	"kratos/text"
+	"kratos/x"
+)
+
+func TestVerification(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	initViper(t, conf)
+
+	_ = testhelpers.NewVerificationUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	public, _ := testhelpers.NewKratosServer(t, reg)
+
+	var createIdentity = func(t *testing.T) string {
+		email := x.NewUUID().String() + "@ory.sh"
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), &identity.Identity{
+			Traits:   identity.Traits(`{"email":"` + email + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}, identity.ManagerAllowWriteProtectedTraits))
+		return email
+	}
+
+	var newClient = func(t *testing.T, isAPI bool) *http.Client {
+		if isAPI {
+			return testhelpers.NewDebugClient(t)
+		}
+		return testhelpers.NewClientWithCookies(t)
+	}
+
+	var requestCode = func(t *testing.T, isAPI bool, hc *http.Client, email string) string {
+		return testhelpers.SubmitVerificationForm(t, isAPI, hc, public, func(v url.Values) {
+			v.Set("email", email)
+		}, http.StatusOK, testhelpers.ExpectURL(isAPI, public.URL+verification.RouteSubmitFlow, conf.SelfServiceFlowVerificationUI().String()))
+	}
+
+	var expectVerified = func(t *testing.T, email string, verified bool) {
+		address, err := reg.IdentityPool().FindVerifiableAddressByValue(context.Background(), identity.VerifiableAddressTypeEmail, email)
+		require.NoError(t, err)
+		assert.Equal(t, verified, address.Verified)
+	}
+
+	t.Run("description=should verify an email address", func(t *testing.T) {
+		var check = func(t *testing.T, isAPI bool) {
+			email := createIdentity(t)
+			hc := newClient(t, isAPI)
+
+			f := requestCode(t, isAPI, hc, email)
+			assertx.EqualAsJSON(t, text.NewVerificationCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+			code := expectCode(t, reg, email, "Please verify your email address")
+			expectVerified(t, email, false)
+
+			actual, res := submitCode(t, isAPI, hc, f, code)
+			assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+			assert.Contains(t, res.Request.URL.String(), testhelpers.ExpectURL(isAPI, public.URL+verification.RouteSubmitFlow, conf.SelfServiceFlowVerificationUI().String()))
+			assert.EqualValues(t, verification.StatePassedChallenge, gjson.Get(actual, "state").String(), "%s", actual)
+			assert.False(t, gjson.Get(actual, "ui.nodes.#(attributes.name==code)").Exists(), "%s", actual)
+			expectVerified(t, email, true)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, false)
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, true)
+		})
+	})
+
+	t.Run("description=should not verify an email address with a wrong code", func(t *testing.T) {
+		email := createIdentity(t)
+		hc := newClient(t, true)
+
+		f := requestCode(t, true, hc, email)
+		code := expectCode(t, reg, email, "Please verify your email address")
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		actual, res := submitCode(t, true, hc, f, wrong)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", actual)
+		assert.EqualValues(t, text.NewErrorValidationCodeInvalid().Text,
+			gjson.Get(actual, "ui.nodes.#(attributes.name==code).messages.0.text").String(), "%s", actual)
+		expectVerified(t, email, false)
+	})
+
+	t.Run("description=should invalidate the code after too many attempts", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCodeMaxAttempts, 1)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCodeMaxAttempts, 5)
+		})
+
+		email := createIdentity(t)
+		hc := newClient(t, false)
+
+		f := requestCode(t, false, hc, email)
+		code := expectCode(t, reg, email, "Please verify your email address")
+
+		wrong := "000000"
+		if code == wrong {
+			wrong = "111111"
+		}
+		actual, res := submitCode(t, false, hc, f, wrong)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+		assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowVerificationUI().String())
+		assert.EqualValues(t, text.ErrorValidationVerificationCodeTooManyAttempts, gjson.Get(actual, "ui.messages.0.id").Int(), "%s", actual)
+
+		_, _ = submitCode(t, false, hc, f, code)
+		expectVerified(t, email, false)
+	})
+
+	t.Run("description=should send an email to an unknown address", func(t *testing.T) {
+		email := x.NewUUID().String() + "@ory.sh"
+		f := requestCode(t, false, newClient(t, false), email)
+		assertx.EqualAsJSON(t, text.NewVerificationCodeSent(), json.RawMessage(gjson.Get(f, "ui.messages.0").Raw))
+
+		message := testhelpers.CourierExpectMessage(t, reg, email, "Someone tried to verify this email address")
+		assert.Contains(t, message.Body, "If this was you, check if you signed up using a different address.")
+	})
+}
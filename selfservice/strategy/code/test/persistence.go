@@ -0,0 +1,132 @@
+package code
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"kratos/internal/testhelpers"
+	"kratos/persistence"
+	"kratos/selfservice/strategy/code"
+	"kratos/x"
+)
+
+func TestPersister(ctx context.Context, p interface {
+	persistence.Persister
+}) func(t *testing.T) {
+	return func(t *testing.T) {
+		nid, p := testhelpers.NewNetworkUnlessExisting(t, ctx, p)
+		expiresAt := time.Now().UTC().Add(time.Hour)
+
+		t.Run("case=should count the codes sent for a flow", func(t *testing.T) {
+			flowID := x.NewUUID()
+			for k := 1; k <= 3; k++ {
+				c := &code.Code{ID: x.NewUUID(), FlowID: flowID, ExpiresAt: expiresAt}
+				sent, err := p.CreateCode(ctx, c)
+				require.NoError(t, err)
+				assert.Equal(t, k, sent)
+				assert.Equal(t, nid, c.NID)
+			}
+
+			sent, err := p.CreateCode(ctx, &code.Code{ID: x.NewUUID(), FlowID: x.NewUUID(), ExpiresAt: expiresAt})
+			require.NoError(t, err)
+			assert.Equal(t, 1, sent)
+		})
+
+		t.Run("case=should limit the attempts", func(t *testing.T) {
+			c := &code.Code{ID: x.NewUUID(), FlowID: x.NewUUID(), ExpiresAt: expiresAt}
+			_, err := p.CreateCode(ctx, c)
+			require.NoError(t, err)
+
+			t.Run("not work on another network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				_, err := p.AttemptCode(ctx, c.ID, 3)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			for k := 1; k <= 3; k++ {
+				attempts, err := p.AttemptCode(ctx, c.ID, 3)
+				require.NoError(t, err)
+				assert.Equal(t, k, attempts)
+			}
+
+			_, err = p.AttemptCode(ctx, c.ID, 3)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=should not exceed the attempts when used concurrently", func(t *testing.T) {
+			c := &code.Code{ID: x.NewUUID(), FlowID: x.NewUUID(), ExpiresAt: expiresAt}
+			_, err := p.CreateCode(ctx, c)
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			var succeeded int
+			for k := 0; k < 10; k++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := p.AttemptCode(ctx, c.ID, 3); err == nil {
+						mu.Lock()
+						succeeded++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.LessOrEqual(t, succeeded, 3)
+		})
+
+		t.Run("case=should use a code only once", func(t *testing.T) {
+			c := &code.Code{ID: x.NewUUID(), FlowID: x.NewUUID(), ExpiresAt: expiresAt}
+			_, err := p.CreateCode(ctx, c)
+			require.NoError(t, err)
+
+			_, err = p.AttemptCode(ctx, c.ID, 3)
+			require.NoError(t, err)
+
+			require.NoError(t, p.UseCode(ctx, c.ID))
+			require.ErrorIs(t, p.UseCode(ctx, c.ID), sqlcon.ErrNoRows)
+
+			_, err = p.AttemptCode(ctx, c.ID, 3)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=should delete the other codes of the flow once a code was used", func(t *testing.T) {
+			flowID := x.NewUUID()
+			previous := &code.Code{ID: x.NewUUID(), FlowID: flowID, ExpiresAt: expiresAt}
+			_, err := p.CreateCode(ctx, previous)
+			require.NoError(t, err)
+
+			c := &code.Code{ID: x.NewUUID(), FlowID: flowID, ExpiresAt: expiresAt}
+			_, err = p.CreateCode(ctx, c)
+			require.NoError(t, err)
+
+			require.NoError(t, p.UseCode(ctx, c.ID))
+			require.ErrorIs(t, p.UseCode(ctx, previous.ID), sqlcon.ErrNoRows)
+
+			sent, err := p.CreateCode(ctx, &code.Code{ID: x.NewUUID(), FlowID: flowID, ExpiresAt: expiresAt})
+			require.NoError(t, err)
+			assert.Equal(t, 1, sent)
+		})
+
+		t.Run("case=should delete the codes of expired flows", func(t *testing.T) {
+			expired := &code.Code{ID: x.NewUUID(), FlowID: x.NewUUID(), ExpiresAt: time.Now().UTC().Add(-time.Minute)}
+			_, err := p.CreateCode(ctx, expired)
+			require.NoError(t, err)
+
+			_, err = p.CreateCode(ctx, &code.Code{ID: x.NewUUID(), FlowID: x.NewUUID(), ExpiresAt: expiresAt})
+			require.NoError(t, err)
+
+			_, err = p.AttemptCode(ctx, expired.ID, 3)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package link
+
+import (
+	"context"
+
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/ui/node"
+)
+
+// addressNodes returns the fields for the address the link is sent to. If the courier is able to send text
+// messages, a phone number may be entered instead of an email address.
+func (s *Strategy) addressNodes(ctx context.Context, group node.Group, email, phone interface{}) []*node.Node {
+	if !s.d.Config(ctx).CourierSMSEnabled() {
+		// v0.5: form.Field{Name: "email", Type: "email", Required: true}
+		return []*node.Node{node.NewInputField("email", email, group, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute)}
+	}
+
+	return []*node.Node{
+		node.NewInputField("email", email, group, node.InputAttributeTypeEmail),
+		node.NewInputField("phone", phone, group, node.InputAttributeTypeTel),
+	}
+}
+
+// addressFromPayload returns the address type and value the user submitted. The phone number is only
+// considered if the courier is able to send text messages.
+func (s *Strategy) addressFromPayload(ctx context.Context, email, phone string) (identity.VerifiableAddressType, string, error) {
+	if len(phone) > 0 && s.d.Config(ctx).CourierSMSEnabled() {
+		if !identity.IsPhoneNumber(phone) {
+			return "", "", schema.NewInvalidFormatError("#/phone", "tel", phone)
+		}
+		return identity.VerifiableAddressTypePhone, phone, nil
+	}
+
+	if len(email) == 0 {
+		return "", "", schema.NewRequiredError("#/email", "email")
+	}
+	return identity.VerifiableAddressTypeEmail, email, nil
+}
@@ -49,15 +49,18 @@ func NewSender(r senderDependencies) *Sender {
 
 // SendRecoveryLink sends a recovery link to the specified address. If the address does not exist in the store, an email is
 // still being sent to prevent account enumeration attacks. In that case, this function returns the ErrUnknownAddress
-// error.
+// error. No text message is sent to unknown phone numbers.
 func (s *Sender) SendRecoveryLink(ctx context.Context, r *http.Request, f *recovery.Flow, via identity.VerifiableAddressType, to string) error {
 	s.r.Logger().
 		WithField("via", via).
 		WithSensitiveField("address", to).
 		Debug("Preparing verification code.")
 
-	address, err := s.r.IdentityPool().FindRecoveryAddressByValue(ctx, identity.RecoveryAddressTypeEmail, to)
+	address, err := s.r.IdentityPool().FindRecoveryAddressByValue(ctx, identity.RecoveryAddressType(via), to)
 	if err != nil {
+		if via == identity.VerifiableAddressTypePhone {
+			return errors.Cause(ErrUnknownAddress)
+		}
 		if err := s.send(ctx, string(via), templates.NewRecoveryInvalid(s.r.Config(ctx), &templates.RecoveryInvalidModel{To: to})); err != nil {
 			return err
 		}
@@ -78,7 +81,7 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, r *http.Request, f *recov
 
 // SendVerificationLink sends a verification link to the specified address. If the address does not exist in the store, an email is
 // still being sent to prevent account enumeration attacks. In that case, this function returns the ErrUnknownAddress
-// error.
+// error. No text message is sent to unknown phone numbers.
 func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow, via identity.VerifiableAddressType, to string) error {
 	s.r.Logger().
 		WithField("via", via).
@@ -88,6 +91,9 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 	address, err := s.r.IdentityPool().FindVerifiableAddressByValue(ctx, via, to)
 	if err != nil {
 		if errorsx.Cause(err) == sqlcon.ErrNoRows {
+			if via == identity.VerifiableAddressTypePhone {
+				return errors.Cause(ErrUnknownAddress)
+			}
 			s.r.Audit().
 				WithField("via", via).
 				WithSensitiveField("email_address", address).
@@ -151,6 +157,13 @@ func (s *Sender) send(ctx context.Context, via string, t courier.EmailTemplate)
 	case identity.AddressTypeEmail:
 		_, err := s.r.Courier(ctx).QueueEmail(ctx, t)
 		return err
+	case identity.AddressTypePhone:
+		st, ok := t.(courier.SMSTemplate)
+		if !ok {
+			return errors.Errorf("template %T can not be sent as a text message", t)
+		}
+		_, err := s.r.Courier(ctx).QueueSMS(ctx, st)
+		return err
 	default:
 		return errors.Errorf("received unexpected via type: %s", via)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ory/x/urlx"
+	"kratos/courier"
 	"kratos/driver/config"
 	"kratos/identity"
 	"kratos/internal"
@@ -28,7 +29,7 @@ func TestManager(t *testing.T) {
 	u := &http.Request{URL: urlx.ParseOrPanic("https://www.ory.sh/")}
 
 	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
-	i.Traits = identity.Traits(`{"email": "tracked@ory.sh"}`)
+	i.Traits = identity.Traits(`{"email": "tracked@ory.sh", "phone": "+12065550100"}`)
 	require.NoError(t, reg.IdentityManager().Create(context.Background(), i))
 
 	t.Run("method=SendRecoveryLink", func(t *testing.T) {
@@ -80,4 +81,37 @@ func TestManager(t *testing.T) {
 		assert.Contains(t, messages[1].Subject, "tried to verify")
 		assert.NotContains(t, messages[1].Body, urlx.AppendPaths(conf.SelfPublicURL(nil), verification.RouteSubmitFlow).String()+"?")
 	})
+	t.Run("case=sends links to phone numbers as text messages", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+		})
+
+		rf, err := recovery.NewFlow(conf, time.Hour, "", u, reg.RecoveryStrategies(context.Background()), flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(context.Background(), rf))
+
+		vf, err := verification.NewFlow(conf, time.Hour, "", u, reg.VerificationStrategies(context.Background()), flow.TypeBrowser)
+		require.NoError(t, err)
+		require.NoError(t, reg.VerificationFlowPersister().CreateVerificationFlow(context.Background(), vf))
+
+		require.NoError(t, reg.LinkSender().SendRecoveryLink(context.Background(), nil, rf, identity.VerifiableAddressTypePhone, "+12065550100"))
+		require.NoError(t, reg.LinkSender().SendVerificationLink(context.Background(), vf, identity.VerifiableAddressTypePhone, "+12065550100"))
+
+		// Unknown phone numbers do not receive a text message.
+		require.EqualError(t, reg.LinkSender().SendRecoveryLink(context.Background(), nil, rf, identity.VerifiableAddressTypePhone, "+12065550199"), link.ErrUnknownAddress.Error())
+		require.EqualError(t, reg.LinkSender().SendVerificationLink(context.Background(), vf, identity.VerifiableAddressTypePhone, "+12065550199"), link.ErrUnknownAddress.Error())
+
+		messages, err := reg.CourierPersister().NextMessages(context.Background(), 12)
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+
+		assert.Equal(t, courier.MessageTypeSMS, messages[0].Type)
+		assert.EqualValues(t, "+12065550100", messages[0].Recipient)
+		assert.Contains(t, messages[0].Body, urlx.AppendPaths(conf.SelfPublicURL(nil), recovery.RouteSubmitFlow).String()+"?")
+
+		assert.Equal(t, courier.MessageTypeSMS, messages[1].Type)
+		assert.EqualValues(t, "+12065550100", messages[1].Recipient)
+		assert.Contains(t, messages[1].Body, urlx.AppendPaths(conf.SelfPublicURL(nil), verification.RouteSubmitFlow).String()+"?")
+	})
 }
@@ -53,10 +53,12 @@ type (
 		errorx.ManagementProvider
 
 		recovery.ErrorHandlerProvider
+		recovery.HookExecutorProvider
 		recovery.FlowPersistenceProvider
 		recovery.StrategyProvider
 
 		verification.ErrorHandlerProvider
+		verification.HookExecutorProvider
 		verification.FlowPersistenceProvider
 		verification.StrategyProvider
 
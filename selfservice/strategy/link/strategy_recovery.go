@@ -15,7 +15,6 @@ import (
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 	"kratos/identity"
-	"kratos/schema"
 	"kratos/selfservice/flow"
 	"kratos/selfservice/flow/recovery"
 	"kratos/selfservice/strategy"
@@ -43,10 +42,9 @@ func (s *Strategy) RegisterAdminRecoveryRoutes(admin *x.RouterAdmin) {
 
 func (s *Strategy) PopulateRecoveryMethod(r *http.Request, f *recovery.Flow) error {
 	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	f.UI.GetNodes().Upsert(
-		// v0.5: form.Field{Name: "email", Type: "email", Required: true},
-		node.NewInputField("email", nil, node.RecoveryLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-	)
+	for _, n := range s.addressNodes(r.Context(), node.RecoveryLinkGroup, nil, nil) {
+		f.UI.GetNodes().Upsert(n)
+	}
 	f.UI.GetNodes().Append(node.NewInputField("method", s.RecoveryStrategyID(), node.RecoveryLinkGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSubmit()))
 
 	return nil
@@ -229,6 +227,15 @@ type submitSelfServiceRecoveryFlowWithLinkMethod struct {
 	// in: body
 	Email string `json:"email" form:"email"`
 
+	// Phone Number to Recover
+	//
+	// May be set instead of the email if text messages are enabled. If the phone
+	// number is a registered recovery phone number, a recovery link will be sent
+	// by text message.
+	//
+	// in: body
+	Phone string `json:"phone" form:"phone"`
+
 	// Sending the anti-csrf token is only required for browser login flows.
 	CSRFToken string `form:"csrf_token" json:"csrf_token"`
 }
@@ -324,6 +331,12 @@ func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request,
 	}
 
 	sess := session.NewActiveSession(recovered, s.d.Config(r.Context()), time.Now().UTC())
+	sess.SeenFrom(r, s.d.Config(r.Context()), sess.IssuedAt)
+	// A failing hook must not leave an active session behind.
+	if err := s.d.RecoveryExecutor().PostRecoveryHook(w, r, f, sess); err != nil {
+		return s.handleRecoveryError(w, r, f, nil, err)
+	}
+
 	if err := s.d.SessionManager().CreateAndIssueCookie(r.Context(), w, r, sess); err != nil {
 		return s.handleRecoveryError(w, r, f, nil, err)
 	}
@@ -406,31 +419,35 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 		return s.handleRecoveryError(w, r, req, body, err)
 	}
 
-	if len(body.Email) == 0 {
-		return s.handleRecoveryError(w, r, req, body, schema.NewRequiredError("#/email", "email"))
+	via, to, err := s.addressFromPayload(r.Context(), body.Email, body.Phone)
+	if err != nil {
+		return s.handleRecoveryError(w, r, req, body, err)
 	}
 
 	if err := flow.EnsureCSRF(r, req.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
 		return s.handleRecoveryError(w, r, req, body, err)
 	}
 
-	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), r, req, identity.VerifiableAddressTypeEmail, body.Email); err != nil {
+	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), r, req, via, to); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			return s.handleRecoveryError(w, r, req, body, err)
 		}
 		// Continue execution
 	}
 
-	req.UI.Reset("email")
+	req.UI.Reset("email", "phone")
 	req.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	req.UI.GetNodes().Upsert(
-		// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-		node.NewInputField("email", body.Email, node.RecoveryLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-	)
+	for _, n := range s.addressNodes(r.Context(), node.RecoveryLinkGroup, body.Email, body.Phone) {
+		req.UI.GetNodes().Upsert(n)
+	}
 
 	req.Active = sqlxx.NullString(s.RecoveryNodeGroup())
 	req.State = recovery.StateEmailSent
-	req.UI.Messages.Set(text.NewRecoveryEmailSent())
+	if via == identity.VerifiableAddressTypePhone {
+		req.UI.Messages.Set(text.NewRecoverySMSSent())
+	} else {
+		req.UI.Messages.Set(text.NewRecoveryEmailSent())
+	}
 	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), req); err != nil {
 		return s.handleRecoveryError(w, r, req, body, err)
 	}
@@ -440,12 +457,11 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 
 func (s *Strategy) handleRecoveryError(w http.ResponseWriter, r *http.Request, req *recovery.Flow, body *recoverySubmitPayload, err error) error {
 	if req != nil {
-		req.UI.Reset("email")
+		req.UI.Reset("email", "phone")
 		req.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-		req.UI.GetNodes().Upsert(
-			// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-			node.NewInputField("email", body.Email, node.RecoveryLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-		)
+		for _, n := range s.addressNodes(r.Context(), node.RecoveryLinkGroup, body.Email, body.Phone) {
+			req.UI.GetNodes().Upsert(n)
+		}
 	}
 
 	return err
@@ -457,6 +473,7 @@ type recoverySubmitPayload struct {
 	CSRFToken string `json:"csrf_token" form:"csrf_token"`
 	Flow      string `json:"flow" form:"flow"`
 	Email     string `json:"email" form:"email"`
+	Phone     string `json:"phone" form:"phone"`
 }
 
 func (s *Strategy) decodeRecovery(r *http.Request) (*recoverySubmitPayload, error) {
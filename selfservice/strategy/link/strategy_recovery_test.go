@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -20,6 +21,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	"github.com/gofrs/uuid"
+
 	"github.com/ory/x/urlx"
 
 	"github.com/ory/x/sqlxx"
@@ -28,6 +31,7 @@ import (
 
 	"github.com/ory/x/pointerx"
 
+	"kratos/courier"
 	"kratos/driver/config"
 	"kratos/identity"
 	"kratos/internal"
@@ -310,6 +314,105 @@ func TestRecovery(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should not issue a session if a post recovery hook fails", func(t *testing.T) {
+		hookTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		t.Cleanup(hookTS.Close)
+
+		conf.MustSet(config.ViperKeySelfServiceRecoveryAfterHooks, []map[string]interface{}{
+			{"hook": "web_hook", "config": map[string]interface{}{"url": hookTS.URL, "timeout": "500ms"}},
+		})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceRecoveryAfterHooks, nil)
+		})
+
+		before, err := reg.SessionPersister().ListSessionsByIdentity(context.Background(), identityToRecover.ID, nil, 0, 100, uuid.Nil)
+		require.NoError(t, err)
+
+		expectSuccess(t, false, func(v url.Values) {
+			v.Set("email", recoveryEmail)
+		})
+
+		message := testhelpers.CourierExpectMessage(t, reg, recoveryEmail, "Recover access to your account")
+		recoveryLink := testhelpers.CourierExpectLinkInMessage(t, message, 1)
+
+		cl := testhelpers.NewClientWithCookies(t)
+		res, err := cl.Get(recoveryLink)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		assert.NotContains(t, res.Request.URL.String(), conf.SelfServiceFlowSettingsUI().String())
+
+		after, err := reg.SessionPersister().ListSessionsByIdentity(context.Background(), identityToRecover.ID, nil, 0, 100, uuid.Nil)
+		require.NoError(t, err)
+		assert.Len(t, after, len(before))
+	})
+
+	t.Run("description=should recover an account using a phone number", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+		})
+
+		phone := "+12065550142"
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), &identity.Identity{
+			Traits:   identity.Traits(`{"email":"recovermyphone@ory.sh","phone":"` + phone + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}, identity.ManagerAllowWriteProtectedTraits))
+
+		var check = func(t *testing.T, actual string) {
+			assert.EqualValues(t, phone, gjson.Get(actual, "ui.nodes.#(attributes.name==phone).attributes.value").String(), "%s", actual)
+			assertx.EqualAsJSON(t, text.NewRecoverySMSSent(), json.RawMessage(gjson.Get(actual, "ui.messages.0").Raw))
+
+			message := testhelpers.CourierExpectMessage(t, reg, phone, "")
+			assert.Equal(t, courier.MessageTypeSMS, message.Type)
+
+			recoveryLink := testhelpers.CourierExpectLinkInMessage(t, message, 1)
+			assert.Contains(t, recoveryLink, public.URL+recovery.RouteSubmitFlow)
+
+			res, err := testhelpers.NewClientWithCookies(t).Get(recoveryLink)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowSettingsUI().String())
+		}
+
+		var values = func(v url.Values) {
+			v.Del("email")
+			v.Set("phone", phone)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, expectSuccess(t, false, values))
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, expectSuccess(t, true, values))
+		})
+	})
+
+	t.Run("description=should not send a text message to an unknown phone number", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+		})
+
+		before, err := reg.CourierPersister().LatestQueuedMessage(context.Background())
+		require.NoError(t, err)
+
+		actual := expectSuccess(t, false, func(v url.Values) {
+			v.Del("email")
+			v.Set("phone", "+12065550143")
+		})
+		assertx.EqualAsJSON(t, text.NewRecoverySMSSent(), json.RawMessage(gjson.Get(actual, "ui.messages.0").Raw))
+
+		after, err := reg.CourierPersister().LatestQueuedMessage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, before.ID, after.ID)
+	})
+
 	t.Run("description=should not be able to use an invalid link", func(t *testing.T) {
 		c := testhelpers.NewClientWithCookies(t)
 		f := testhelpers.InitializeRecoveryFlowViaBrowser(t, c, public)
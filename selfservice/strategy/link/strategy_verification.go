@@ -13,7 +13,6 @@ import (
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 	"kratos/identity"
-	"kratos/schema"
 	"kratos/selfservice/flow"
 	"kratos/selfservice/flow/verification"
 	"kratos/text"
@@ -33,10 +32,9 @@ func (s *Strategy) RegisterAdminVerificationRoutes(admin *x.RouterAdmin) {
 
 func (s *Strategy) PopulateVerificationMethod(r *http.Request, f *verification.Flow) error {
 	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	f.UI.GetNodes().Upsert(
-		// v0.5: form.Field{Name: "email", Type: "email", Required: true}
-		node.NewInputField("email", nil, node.VerificationLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-	)
+	for _, n := range s.addressNodes(r.Context(), node.VerificationLinkGroup, nil, nil) {
+		f.UI.GetNodes().Upsert(n)
+	}
 	f.UI.GetNodes().Append(node.NewInputField("method", s.VerificationStrategyID(), node.VerificationLinkGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSubmit()))
 	return nil
 }
@@ -47,6 +45,7 @@ type verificationSubmitPayload struct {
 	CSRFToken string `json:"csrf_token" form:"csrf_token"`
 	Flow      string `json:"flow" form:"flow"`
 	Email     string `json:"email" form:"email"`
+	Phone     string `json:"phone" form:"phone"`
 }
 
 func (s *Strategy) decodeVerification(r *http.Request) (*verificationSubmitPayload, error) {
@@ -74,10 +73,9 @@ func (s *Strategy) decodeVerification(r *http.Request) (*verificationSubmitPaylo
 func (s *Strategy) handleVerificationError(w http.ResponseWriter, r *http.Request, f *verification.Flow, body *verificationSubmitPayload, err error) error {
 	if f != nil {
 		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-		f.UI.GetNodes().Upsert(
-			// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-			node.NewInputField("email", body.Email, node.VerificationLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-		)
+		for _, n := range s.addressNodes(r.Context(), node.VerificationLinkGroup, body.Email, body.Phone) {
+			f.UI.GetNodes().Upsert(n)
+		}
 	}
 
 	return err
@@ -120,6 +118,15 @@ type submitSelfServiceVerificationFlowWithLinkMethod struct {
 	// in: body
 	Email string `json:"email"`
 
+	// Phone Number to Verify
+	//
+	// May be set instead of the email if text messages are enabled. If the phone
+	// number is a registered verification phone number, a verification link will
+	// be sent by text message.
+	//
+	// in: body
+	Phone string `json:"phone"`
+
 	// Sending the anti-csrf token is only required for browser login flows.
 	CSRFToken string `form:"csrf_token" json:"csrf_token"`
 }
@@ -166,15 +173,16 @@ func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *ht
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
-	if len(body.Email) == 0 {
-		return s.handleVerificationError(w, r, f, body, schema.NewRequiredError("#/email", "email"))
+	via, to, err := s.addressFromPayload(r.Context(), body.Email, body.Phone)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
 	}
 
 	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
-	if err := s.d.LinkSender().SendVerificationLink(r.Context(), f, identity.VerifiableAddressTypeEmail, body.Email); err != nil {
+	if err := s.d.LinkSender().SendVerificationLink(r.Context(), f, via, to); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			return s.handleVerificationError(w, r, f, body, err)
 		}
@@ -182,14 +190,17 @@ func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *ht
 	}
 
 	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
-	f.UI.GetNodes().Upsert(
-		// v0.5: form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email}
-		node.NewInputField("email", body.Email, node.VerificationLinkGroup, node.InputAttributeTypeEmail, node.WithRequiredInputAttribute),
-	)
+	for _, n := range s.addressNodes(r.Context(), node.VerificationLinkGroup, body.Email, body.Phone) {
+		f.UI.GetNodes().Upsert(n)
+	}
 
 	f.Active = sqlxx.NullString(s.VerificationNodeGroup())
 	f.State = verification.StateEmailSent
-	f.UI.Messages.Set(text.NewVerificationEmailSent())
+	if via == identity.VerifiableAddressTypePhone {
+		f.UI.Messages.Set(text.NewVerificationSMSSent())
+	} else {
+		f.UI.Messages.Set(text.NewVerificationEmailSent())
+	}
 	if err := s.d.VerificationFlowPersister().UpdateVerificationFlow(r.Context(), f); err != nil {
 		return s.handleVerificationError(w, r, f, body, err)
 	}
@@ -250,6 +261,15 @@ func (s *Strategy) verificationUseToken(w http.ResponseWriter, r *http.Request,
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
+	i, err := s.d.PrivilegedIdentityPool().GetIdentity(r.Context(), address.IdentityID)
+	if err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := s.d.VerificationExecutor().PostVerificationHook(w, r, f, i); err != nil {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
 	defaultRedirectURL := s.d.Config(r.Context()).SelfServiceFlowVerificationReturnTo(f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowVerificationUI()))
 
 	verificationRequestURL, err := urlx.Parse(f.GetRequestURL())
@@ -21,6 +21,7 @@ import (
 	"github.com/ory/x/assertx"
 	"github.com/ory/x/ioutilx"
 	"github.com/ory/x/sqlxx"
+	"kratos/courier"
 	"kratos/driver/config"
 	"kratos/identity"
 	"kratos/internal"
@@ -299,6 +300,64 @@ func TestVerification(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should verify a phone number", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+		})
+
+		phone := "+12065550123"
+		identityWithPhone := &identity.Identity{
+			ID:       x.NewUUID(),
+			Traits:   identity.Traits(`{"email":"verifymyphone@ory.sh","phone":"` + phone + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), identityWithPhone, identity.ManagerAllowWriteProtectedTraits))
+
+		var check = func(t *testing.T, actual string) {
+			assert.EqualValues(t, phone, gjson.Get(actual, "ui.nodes.#(attributes.name==phone).attributes.value").String(), "%s", actual)
+			assert.EqualValues(t, "tel", gjson.Get(actual, "ui.nodes.#(attributes.name==phone).attributes.type").String(), "%s", actual)
+			assertx.EqualAsJSON(t, text.NewVerificationSMSSent(), json.RawMessage(gjson.Get(actual, "ui.messages.0").Raw))
+
+			message := testhelpers.CourierExpectMessage(t, reg, phone, "")
+			assert.Equal(t, courier.MessageTypeSMS, message.Type)
+
+			verificationLink := testhelpers.CourierExpectLinkInMessage(t, message, 1)
+			assert.Contains(t, verificationLink, public.URL+verification.RouteSubmitFlow)
+
+			res, err := testhelpers.NewClientWithCookies(t).Get(verificationLink)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.EqualValues(t, "passed_challenge", gjson.GetBytes(ioutilx.MustReadAll(res.Body), "state").String())
+
+			id, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), identityWithPhone.ID)
+			require.NoError(t, err)
+
+			var found bool
+			for _, address := range id.VerifiableAddresses {
+				if address.Via == identity.VerifiableAddressTypePhone {
+					found = true
+					assert.EqualValues(t, phone, address.Value)
+					assert.True(t, address.Verified)
+				}
+			}
+			assert.True(t, found, "%+v", id.VerifiableAddresses)
+		}
+
+		var values = func(v url.Values) {
+			v.Del("email")
+			v.Set("phone", phone)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, expectSuccess(t, false, values))
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, expectSuccess(t, true, values))
+		})
+	})
+
 	newValidFlow := func(t *testing.T, requestURL string) (*verification.Flow, *link.VerificationToken) {
 		f, err := verification.NewFlow(conf, time.Hour, x.FakeCSRFToken, httptest.NewRequest("GET", requestURL, nil), nil, flow.TypeBrowser)
 		require.NoError(t, err)
@@ -0,0 +1,160 @@
+package lookup
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/login"
+	"kratos/text"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+}
+
+// PopulateLoginMethod does not add any nodes because lookup secrets can only be used as a second factor.
+func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
+	return nil
+}
+
+func (s *Strategy) HasSecondFactor(_ context.Context, i *identity.Identity) bool {
+	var o CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &o); err != nil {
+		return false
+	}
+
+	return o.HasUnusedCodes()
+}
+
+func (s *Strategy) PopulateSecondFactorMethod(r *http.Request, _ *identity.Identity, sr *login.Flow) error {
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.SetNode(NewLookupSecretNode())
+	sr.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.LookupGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLoginLookupSecret()))
+
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) error {
+	if f != nil {
+		f.UI.Nodes.ResetNodes("lookup_secret")
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceLoginFlowWithLookupSecretMethod
+type submitSelfServiceLoginFlowWithLookupSecretMethodParameters struct {
+	// The Flow ID
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	Body submitSelfServiceLoginFlowWithLookupSecretMethod
+}
+
+// swagger:model submitSelfServiceLoginFlowWithLookupSecretMethod
+type submitSelfServiceLoginFlowWithLookupSecretMethod struct {
+	// Method should be set to "lookup_secret" when logging in using the lookup_secret strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// The backup recovery code.
+	//
+	// required: true
+	Code string `json:"lookup_secret"`
+}
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow) (i *identity.Identity, err error) {
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	id := f.PendingSecondFactor()
+	if id == uuid.Nil {
+		// The first authentication factor has not been completed yet.
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	var p submitSelfServiceLoginFlowWithLookupSecretMethod
+	if err := s.hd.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	// The code is marked as used within the same transaction which reads it so that it can not be used twice.
+	if err := s.d.PrivilegedIdentityPool().UpdateCredentialsConfig(r.Context(), id, s.ID(), func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error) {
+		return useRecoveryCode(config, p.Code)
+	}); errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewInvalidCredentialsError()))
+	} else if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	i, err = s.d.PrivilegedIdentityPool().GetIdentity(r.Context(), id)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	return i, nil
+}
+
+// useRecoveryCode marks the given code as used and returns the updated credentials config.
+func useRecoveryCode(config sqlxx.JSONRawMessage, code string) (sqlxx.JSONRawMessage, error) {
+	var o CredentialsConfig
+	if err := json.Unmarshal(config, &o); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The lookup secrets could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	code = strings.TrimSpace(code)
+	for k, rc := range o.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(rc.Code), []byte(code)) != 1 {
+			continue
+		}
+
+		if !time.Time(rc.UsedAt).IsZero() {
+			return nil, errors.WithStack(schema.NewLookupAlreadyUsedError("#/lookup_secret"))
+		}
+
+		o.RecoveryCodes[k].UsedAt = sqlxx.NullTime(time.Now().UTC().Round(time.Second))
+		updated, err := json.Marshal(&o)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode lookup secrets to JSON: %s", err))
+		}
+
+		return updated, nil
+	}
+
+	return nil, errors.WithStack(schema.NewLookupInvalidError("#/lookup_secret"))
+}
@@ -0,0 +1,153 @@
+package lookup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+
+	"kratos/driver"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/strategy/lookup"
+	"kratos/text"
+	"kratos/x"
+)
+
+func createIdentity(t *testing.T, reg driver.Registry, codes ...string) (*identity.Identity, string) {
+	email := x.NewUUID().String() + "@ory.sh"
+	password := x.NewUUID().String()
+
+	hpw, err := reg.Hasher().Generate(context.Background(), []byte(password))
+	require.NoError(t, err)
+
+	i := &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"email":"%s"}`, email)),
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{email},
+				Config:      []byte(fmt.Sprintf(`{"hashed_password":"%s"}`, hpw)),
+			},
+		},
+	}
+
+	if len(codes) > 0 {
+		var c lookup.CredentialsConfig
+		for _, code := range codes {
+			c.RecoveryCodes = append(c.RecoveryCodes, lookup.RecoveryCode{Code: code})
+		}
+
+		config, err := json.Marshal(&c)
+		require.NoError(t, err)
+		i.Credentials[identity.CredentialsTypeLookup] = identity.Credentials{
+			Type:        identity.CredentialsTypeLookup,
+			Identifiers: []string{x.NewUUID().String()},
+			Config:      config,
+		}
+	}
+
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+	return i, password
+}
+
+func TestCompleteLogin(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeLookup.String(), true)
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	var submit = func(t *testing.T, action string, payload interface{}) (string, *http.Response) {
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		res, err := http.DefaultClient.Do(testhelpers.NewRequest(t, true, "POST", action, bytes.NewReader(raw)))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return string(ioutilx.MustReadAll(res.Body)), res
+	}
+
+	var loginWithPassword = func(t *testing.T, email, password string) (string, *http.Response, string) {
+		f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+		body, res := submit(t, f.Ui.Action, map[string]string{
+			"method":              "password",
+			"password_identifier": email,
+			"password":            password,
+		})
+		return body, res, f.Ui.Action
+	}
+
+	t.Run("case=identity without backup codes is logged in after the first factor", func(t *testing.T) {
+		i, password := createIdentity(t, reg)
+
+		body, res, _ := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.Get(body, "session.aal").String(), body)
+	})
+
+	t.Run("case=identity with backup codes has to complete the second factor", func(t *testing.T) {
+		i, password := createIdentity(t, reg, "code1111", "code2222")
+		email := i.Credentials[identity.CredentialsTypePassword].Identifiers[0]
+
+		body, res, action := loginWithPassword(t, email, password)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+		assert.EqualValues(t, text.InfoSelfServiceMFA, gjson.Get(body, "ui.messages.0.id").Int(), body)
+		assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==lookup_secret)").Exists(), body)
+
+		t.Run("case=rejects an invalid code", func(t *testing.T) {
+			body, res := submit(t, action, map[string]string{"method": "lookup_secret", "lookup_secret": "invalid1"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.EqualValues(t, text.ErrorValidationLookupInvalid, gjson.Get(body, "ui.nodes.#(attributes.name==lookup_secret).messages.0.id").Int(), body)
+		})
+
+		t.Run("case=issues a session with a valid code", func(t *testing.T) {
+			body, res := submit(t, action, map[string]string{"method": "lookup_secret", "lookup_secret": "code1111"})
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+			assert.Equal(t, i.ID.String(), gjson.Get(body, "session.identity.id").String(), body)
+			assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "session.aal").String(), body)
+			assert.EqualValues(t, identity.CredentialsTypeLookup, gjson.Get(body, "session.authentication_methods.1.method").String(), body)
+
+			actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+			require.NoError(t, err)
+			config := actual.Credentials[identity.CredentialsTypeLookup].Config
+			assert.NotEmpty(t, gjson.GetBytes(config, "recovery_codes.0.used_at").String(), string(config))
+			assert.Empty(t, gjson.GetBytes(config, "recovery_codes.1.used_at").String(), string(config))
+		})
+
+		t.Run("case=rejects a code which was already used", func(t *testing.T) {
+			body, res, action := loginWithPassword(t, email, password)
+			require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+
+			body, res = submit(t, action, map[string]string{"method": "lookup_secret", "lookup_secret": "code1111"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+			assert.EqualValues(t, text.ErrorValidationLookupAlreadyUsed, gjson.Get(body, "ui.nodes.#(attributes.name==lookup_secret).messages.0.id").Int(), body)
+		})
+	})
+
+	t.Run("case=backup codes can not be used as the first factor", func(t *testing.T) {
+		createIdentity(t, reg, "code3333")
+
+		f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+		body, res := submit(t, f.Ui.Action, map[string]string{"method": "lookup_secret", "lookup_secret": "code3333"})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+	})
+}
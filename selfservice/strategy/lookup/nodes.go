@@ -0,0 +1,31 @@
+package lookup
+
+import (
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+func NewLookupSecretNode() *node.Node {
+	return node.NewInputField("lookup_secret", nil, node.LookupGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).
+		WithMetaLabel(text.NewInfoLoginLookupSecretLabel())
+}
+
+func NewRevealLookupNode() *node.Node {
+	return node.NewInputField("lookup_secret_reveal", "true", node.LookupGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsRevealLookup())
+}
+
+func NewRegenerateLookupNode() *node.Node {
+	return node.NewInputField("lookup_secret_regenerate", "true", node.LookupGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsRegenerateLookup())
+}
+
+func NewConfirmLookupNode() *node.Node {
+	return node.NewInputField("lookup_secret_confirm", "true", node.LookupGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsLookupConfirm())
+}
+
+func NewDisableLookupNode() *node.Node {
+	return node.NewInputField("lookup_secret_disable", "true", node.LookupGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsDisableLookup())
+}
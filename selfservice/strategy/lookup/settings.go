@@ -0,0 +1,288 @@
+package lookup
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/randx"
+
+	"kratos/identity"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/settings"
+	"kratos/session"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+const (
+	// internalContextKeyRegenerated is the settings flow's internal context key holding the codes which are
+	// waiting to be confirmed.
+	internalContextKeyRegenerated = "lookup_secret_regenerated"
+
+	codeCount  = 12
+	codeLength = 8
+)
+
+func (s *Strategy) RegisterSettingsRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return identity.CredentialsTypeLookup.String()
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceSettingsFlowWithLookupMethod
+type submitSelfServiceSettingsFlowWithLookupMethod struct {
+	// in: body
+	Body submitSelfServiceSettingsFlowWithLookupMethodBody
+
+	// Flow is flow ID.
+	//
+	// in: query
+	Flow string `json:"flow"`
+}
+
+// swagger:model submitSelfServiceSettingsFlowWithLookupMethod
+type submitSelfServiceSettingsFlowWithLookupMethodBody struct {
+	// If set to true will reveal the backup recovery codes.
+	//
+	// type: boolean
+	RevealLookup bool `json:"lookup_secret_reveal"`
+
+	// If set to true will generate new backup recovery codes which need to be confirmed
+	// before they replace the existing codes.
+	//
+	// type: boolean
+	RegenerateLookup bool `json:"lookup_secret_regenerate"`
+
+	// If set to true will save the regenerated backup recovery codes.
+	//
+	// type: boolean
+	ConfirmLookup bool `json:"lookup_secret_confirm"`
+
+	// If set to true will remove the backup recovery codes.
+	//
+	// type: boolean
+	DisableLookup bool `json:"lookup_secret_disable"`
+
+	// CSRFToken is the anti-CSRF token
+	//
+	// type: string
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "lookup_secret" when trying to reveal, regenerate, confirm, or disable backup recovery codes.
+	//
+	// type: string
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+}
+
+func (p *submitSelfServiceSettingsFlowWithLookupMethodBody) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *submitSelfServiceSettingsFlowWithLookupMethodBody) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (*settings.UpdateContext, error) {
+	var p submitSelfServiceSettingsFlowWithLookupMethodBody
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if p.RevealLookup || p.RegenerateLookup || p.ConfirmLookup || p.DisableLookup {
+		// The lookup nodes are submit buttons and thus do not send the method.
+		p.Method = s.SettingsStrategyID()
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.NewHTTP().Decode(r, dest, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(
+	w http.ResponseWriter, r *http.Request,
+	ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithLookupMethodBody,
+) error {
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if err := flow.EnsureCSRF(r, ctxUpdate.Flow.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge()).Before(time.Now()) {
+		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	populate := func(ctxUpdate *settings.UpdateContext) error {
+		return s.PopulateSettingsMethod(r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
+	}
+
+	switch {
+	case p.RevealLookup:
+		var o CredentialsConfig
+		if _, err := i.ParseCredentials(s.ID(), &o); err != nil || len(o.RecoveryCodes) == 0 {
+			return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(herodot.ErrBadRequest.WithReason("There are no backup recovery codes to reveal. Please generate new codes first.")))
+		}
+
+		populate = func(ctxUpdate *settings.UpdateContext) error {
+			resetNodes(ctxUpdate.Flow)
+			ctxUpdate.Flow.UI.Nodes.Append(o.ToNode())
+			ctxUpdate.Flow.UI.Nodes.Append(NewRegenerateLookupNode())
+			ctxUpdate.Flow.UI.Nodes.Append(NewDisableLookupNode())
+			return nil
+		}
+	case p.RegenerateLookup:
+		o := CredentialsConfig{RecoveryCodes: make([]RecoveryCode, codeCount)}
+		for k := range o.RecoveryCodes {
+			o.RecoveryCodes[k] = RecoveryCode{Code: randx.MustString(codeLength, randx.AlphaLowerNum)}
+		}
+
+		ctxUpdate.Flow.InternalContext, err = flow.SetInternalContext(ctxUpdate.Flow.InternalContext, internalContextKeyRegenerated, o.RecoveryCodes)
+		if err != nil {
+			return s.handleSettingsError(w, r, ctxUpdate, p, err)
+		}
+
+		populate = func(ctxUpdate *settings.UpdateContext) error {
+			resetNodes(ctxUpdate.Flow)
+			ctxUpdate.Flow.UI.Nodes.Append(o.ToNode())
+			ctxUpdate.Flow.UI.Nodes.Append(NewConfirmLookupNode())
+			return nil
+		}
+	case p.ConfirmLookup:
+		if err := s.confirmCodes(ctxUpdate, i); err != nil {
+			return s.handleSettingsError(w, r, ctxUpdate, p, err)
+		}
+	case p.DisableLookup:
+		delete(i.Credentials, s.ID())
+	default:
+		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(herodot.ErrBadRequest.WithReason("Please choose whether to reveal, regenerate, confirm, or disable the backup recovery codes.")))
+	}
+
+	if err := s.d.SettingsHookExecutor().PostSettingsHook(w, r, s.SettingsStrategyID(), ctxUpdate, i,
+		settings.WithCallback(populate)); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) confirmCodes(ctxUpdate *settings.UpdateContext, i *identity.Identity) error {
+	regenerated := gjson.GetBytes(ctxUpdate.Flow.InternalContext, internalContextKeyRegenerated)
+	if !regenerated.IsArray() {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Could not find the regenerated backup recovery codes in the settings flow. Please generate new codes first."))
+	}
+
+	var codes []RecoveryCode
+	if err := json.Unmarshal([]byte(regenerated.Raw), &codes); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decode the backup recovery codes stored in the settings flow.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	co, err := json.Marshal(&CredentialsConfig{RecoveryCodes: codes})
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode lookup secrets to JSON: %s", err))
+	}
+
+	i.SetCredentials(s.ID(), identity.Credentials{
+		Type:        s.ID(),
+		Identifiers: []string{i.ID.String()},
+		Config:      co,
+	})
+
+	// The codes may only be confirmed once.
+	ctxUpdate.Flow.InternalContext, err = flow.SetInternalContext(ctxUpdate.Flow.InternalContext, internalContextKeyRegenerated, nil)
+	return err
+}
+
+func resetNodes(f *settings.Flow) {
+	var nodes node.Nodes
+	for _, n := range f.UI.Nodes {
+		if n.Group != node.LookupGroup {
+			nodes = append(nodes, n)
+		}
+	}
+	f.UI.Nodes = nodes
+}
+
+func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id.ID)
+	if err != nil {
+		return err
+	}
+
+	resetNodes(f)
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+
+	var o CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &o); err == nil && len(o.RecoveryCodes) > 0 {
+		f.UI.Nodes.Append(NewRevealLookupNode())
+		f.UI.Nodes.Append(NewRegenerateLookupNode())
+		f.UI.Nodes.Append(NewDisableLookupNode())
+		return nil
+	}
+
+	f.UI.Nodes.Append(NewRegenerateLookupNode())
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithLookupMethodBody, err error) error {
+	// Do not pause flow if the flow type is an API flow as we can't save cookies in those flows.
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) && ctxUpdate.Flow != nil && ctxUpdate.Flow.Type == flow.TypeBrowser {
+		if err := s.d.ContinuityManager().Pause(r.Context(), w, r, settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.GetSessionIdentity())...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
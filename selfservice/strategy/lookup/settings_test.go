@@ -0,0 +1,135 @@
+package lookup_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/settings"
+)
+
+func TestCompleteSettings(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeLookup.String(), true)
+	conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewLoginUIWith401Response(t, conf)
+
+	var initFlow = func(t *testing.T, hc *http.Client) string {
+		res, err := hc.Get(publicTS.URL + settings.RouteInitAPIFlow)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body := string(ioutilx.MustReadAll(res.Body))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		return body
+	}
+
+	var submit = func(t *testing.T, hc *http.Client, flow string, payload interface{}) (string, *http.Response) {
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		res, err := hc.Do(testhelpers.NewRequest(t, true, "POST", gjson.Get(flow, "ui.action").String(), strings.NewReader(string(raw))))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return string(ioutilx.MustReadAll(res.Body)), res
+	}
+
+	var getCodes = func(t *testing.T, id *identity.Identity) []gjson.Result {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id.ID)
+		require.NoError(t, err)
+		return gjson.GetBytes(actual.Credentials[identity.CredentialsTypeLookup].Config, "recovery_codes").Array()
+	}
+
+	i, _ := createIdentity(t, reg)
+	hc := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i)
+
+	t.Run("case=identity without codes can only regenerate", func(t *testing.T) {
+		flow := initFlow(t, hc)
+		assert.True(t, gjson.Get(flow, "ui.nodes.#(attributes.name==lookup_secret_regenerate)").Exists(), flow)
+		assert.False(t, gjson.Get(flow, "ui.nodes.#(attributes.name==lookup_secret_reveal)").Exists(), flow)
+		assert.False(t, gjson.Get(flow, "ui.nodes.#(attributes.name==lookup_secret_disable)").Exists(), flow)
+
+		body, res := submit(t, hc, flow, map[string]interface{}{"lookup_secret_reveal": true})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+	})
+
+	t.Run("case=rejects confirming without regenerating", func(t *testing.T) {
+		body, res := submit(t, hc, initFlow(t, hc), map[string]interface{}{"lookup_secret_confirm": true})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Empty(t, getCodes(t, i))
+	})
+
+	t.Run("case=regenerate and confirm codes", func(t *testing.T) {
+		flow := initFlow(t, hc)
+		body, res := submit(t, hc, flow, map[string]interface{}{"lookup_secret_regenerate": true})
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.True(t, gjson.Get(body, "flow.ui.nodes.#(attributes.name==lookup_secret_confirm)").Exists(), body)
+		secrets := gjson.Get(body, "flow.ui.nodes.#(attributes.id==lookup_secret_codes).attributes.text.context.secrets.#.context.secret").Array()
+		require.Len(t, secrets, 12, body)
+		assert.Empty(t, getCodes(t, i), "codes must not be stored before they are confirmed")
+
+		body, res = submit(t, hc, flow, map[string]interface{}{"lookup_secret_confirm": true})
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.EqualValues(t, settings.StateSuccess, gjson.Get(body, "flow.state").String(), body)
+		assert.True(t, gjson.Get(body, "flow.ui.nodes.#(attributes.name==lookup_secret_reveal)").Exists(), body)
+
+		codes := getCodes(t, i)
+		require.Len(t, codes, 12)
+		for k, code := range codes {
+			assert.EqualValues(t, secrets[k].String(), code.Get("code").String())
+		}
+
+		t.Run("case=codes can only be confirmed once", func(t *testing.T) {
+			body, res := submit(t, hc, flow, map[string]interface{}{"lookup_secret_confirm": true})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		})
+	})
+
+	t.Run("case=reveal codes", func(t *testing.T) {
+		body, res := submit(t, hc, initFlow(t, hc), map[string]interface{}{"lookup_secret_reveal": true})
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		secrets := gjson.Get(body, "flow.ui.nodes.#(attributes.id==lookup_secret_codes).attributes.text.context.secrets.#.context.secret").Array()
+		require.Len(t, secrets, 12, body)
+		assert.EqualValues(t, getCodes(t, i)[0].Get("code").String(), secrets[0].String())
+	})
+
+	t.Run("case=requires a privileged session", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+		})
+
+		flow := initFlow(t, hc)
+		time.Sleep(time.Millisecond)
+
+		body, res := submit(t, hc, flow, map[string]interface{}{"lookup_secret_reveal": true})
+		assert.EqualValues(t, http.StatusForbidden, res.StatusCode, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "re-authenticate", body)
+	})
+
+	t.Run("case=disable codes", func(t *testing.T) {
+		body, res := submit(t, hc, initFlow(t, hc), map[string]interface{}{"lookup_secret_disable": true})
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.EqualValues(t, settings.StateSuccess, gjson.Get(body, "flow.state").String(), body)
+		assert.False(t, gjson.Get(body, "flow.ui.nodes.#(attributes.name==lookup_secret_reveal)").Exists(), body)
+		assert.Empty(t, getCodes(t, i))
+	})
+}
@@ -0,0 +1,51 @@
+package lookup
+
+import (
+	"time"
+
+	"github.com/ory/x/sqlxx"
+
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+// CredentialsConfig is the struct that is being used as part of the identity credentials.
+type CredentialsConfig struct {
+	// RecoveryCodes is a list of single-use backup recovery codes.
+	RecoveryCodes []RecoveryCode `json:"recovery_codes"`
+}
+
+type RecoveryCode struct {
+	// Code is the backup recovery code.
+	Code string `json:"code"`
+
+	// UsedAt is set once the code has been used to sign in.
+	UsedAt sqlxx.NullTime `json:"used_at,omitempty"`
+}
+
+func (c *CredentialsConfig) ToNode() *node.Node {
+	secrets := make([]string, len(c.RecoveryCodes))
+	messages := make([]interface{}, len(c.RecoveryCodes))
+	for k, code := range c.RecoveryCodes {
+		m := text.NewInfoSelfServiceSettingsLookupSecret(code.Code)
+		if !time.Time(code.UsedAt).IsZero() {
+			m = text.NewInfoSelfServiceSettingsLookupSecretUsed(time.Time(code.UsedAt).UTC())
+		}
+
+		secrets[k] = m.Text
+		messages[k] = m
+	}
+
+	return node.NewTextField("lookup_secret_codes", text.NewInfoSelfServiceSettingsLookupSecretList(secrets, messages), node.LookupGroup)
+}
+
+// HasUnusedCodes returns true if at least one of the backup recovery codes has not been used yet.
+func (c *CredentialsConfig) HasUnusedCodes() bool {
+	for _, code := range c.RecoveryCodes {
+		if time.Time(code.UsedAt).IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
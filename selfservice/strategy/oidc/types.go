@@ -1,9 +1,6 @@
 package oidc
 
 import (
-	"bytes"
-	"encoding/json"
-
 	"github.com/ory/x/stringsx"
 	"kratos/text"
 
@@ -12,37 +9,17 @@ import (
 	"kratos/ui/node"
 
 	"github.com/gofrs/uuid"
-	"github.com/pkg/errors"
 
 	"kratos/identity"
-
-	"kratos/x"
 )
 
-type CredentialsConfig struct {
-	Providers []ProviderCredentialsConfig `json:"providers"`
-}
+type CredentialsConfig = identity.CredentialsOIDC
 
 func NewCredentials(provider, subject string) (*identity.Credentials, error) {
-	var b bytes.Buffer
-	if err := json.NewEncoder(&b).Encode(CredentialsConfig{
-		Providers: []ProviderCredentialsConfig{{Subject: subject, Provider: provider}},
-	}); err != nil {
-		return nil, errors.WithStack(x.PseudoPanic.
-			WithDebugf("Unable to encode password options to JSON: %s", err))
-	}
-
-	return &identity.Credentials{
-		Type:        identity.CredentialsTypeOIDC,
-		Identifiers: []string{uid(provider, subject)},
-		Config:      b.Bytes(),
-	}, nil
+	return identity.NewCredentialsOIDC(provider, subject)
 }
 
-type ProviderCredentialsConfig struct {
-	Subject  string `json:"subject"`
-	Provider string `json:"provider"`
-}
+type ProviderCredentialsConfig = identity.CredentialsOIDCProvider
 
 type FlowMethod struct {
 	*container.Container
@@ -2,14 +2,17 @@ package password
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlxx"
 	"kratos/hash"
 	"kratos/identity"
 	"kratos/schema"
@@ -77,13 +80,49 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow)
 		return nil, herodot.ErrInternalServerError.WithReason("The password credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err)
 	}
 
-	if err := hash.Compare(r.Context(), []byte(p.Password), []byte(o.HashedPassword)); err != nil {
+	if err := s.d.Hasher().Compare(r.Context(), []byte(p.Password), []byte(o.HashedPassword)); errors.Is(err, hash.ErrTooManyHashOperations) {
+		return nil, s.handleLoginError(w, r, f, &p, err)
+	} else if err != nil {
 		return nil, s.handleLoginError(w, r, f, &p, errors.WithStack(schema.NewInvalidCredentialsError()))
 	}
 
+	if s.d.Hasher().NeedsRehash(r.Context(), []byte(o.HashedPassword)) {
+		// A failed upgrade must not prevent the login, the hash will be upgraded on one of the next logins.
+		if err := s.migratePasswordHash(r.Context(), i.ID, o.HashedPassword, []byte(p.Password)); err != nil {
+			s.d.Logger().WithError(err).WithRequest(r).Warn("Unable to upgrade the password hash of the identity.")
+		}
+	}
+
 	return i, nil
 }
 
+// migratePasswordHash replaces the stored password hash with one generated by the current hasher unless
+// the password was changed in the meantime.
+func (s *Strategy) migratePasswordHash(ctx context.Context, id uuid.UUID, previous string, password []byte) error {
+	hpw, err := s.d.Hasher().Generate(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	return s.d.PrivilegedIdentityPool().UpdateCredentialsConfig(ctx, id, s.ID(), func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error) {
+		var o CredentialsConfig
+		if err := json.Unmarshal(config, &o); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if o.HashedPassword != previous {
+			return config, nil
+		}
+
+		o.HashedPassword = string(hpw)
+		co, err := json.Marshal(&o)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return co, nil
+	})
+}
+
 func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
 	// This block adds the identifier to the method when the request is forced - as a hint for the user.
 	var identifier string
@@ -23,8 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"golang.org/x/crypto/bcrypt"
 
 	"kratos/driver/config"
+	"kratos/hash"
 	"kratos/identity"
 	"kratos/internal"
 	"kratos/internal/testhelpers"
@@ -57,9 +59,8 @@ func TestCompleteLogin(t *testing.T) {
 			"csrf_token")
 	}
 
-	createIdentity := func(identifier, password string) {
-		p, _ := reg.Hasher().Generate(context.Background(), []byte(password))
-		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
+	createIdentityWithHash := func(identifier string, p []byte) *identity.Identity {
+		i := &identity.Identity{
 			ID:     x.NewUUID(),
 			Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, identifier)),
 			Credentials: map[identity.CredentialsType]identity.Credentials{
@@ -69,7 +70,14 @@ func TestCompleteLogin(t *testing.T) {
 					Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
 				},
 			},
-		}))
+		}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+		return i
+	}
+
+	createIdentity := func(identifier, password string) {
+		p, _ := reg.Hasher().Generate(context.Background(), []byte(password))
+		createIdentityWithHash(identifier, p)
 	}
 
 	apiClient := testhelpers.NewDebugClient(t)
@@ -539,4 +547,88 @@ func TestCompleteLogin(t *testing.T) {
 
 		assert.Equal(t, identifier, gjson.Get(body2, "identity.traits.subject").String(), "%s", body2)
 	})
+
+	t.Run("case=should upgrade outdated password hashes", func(t *testing.T) {
+		outdatedBcrypt, err := bcrypt.GenerateFromPassword([]byte("test"), bcrypt.MinCost+1)
+		require.NoError(t, err)
+
+		for k, tc := range []struct {
+			d    string
+			hash string
+		}{
+			{d: "bcrypt with different cost", hash: string(outdatedBcrypt)},
+			{d: "argon2id", hash: "$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw"},
+			{d: "pbkdf2", hash: "$pbkdf2-sha256$i=1000,l=32$bGVnYWN5c2FsdDEyMzQ1Ng$D3YJ/6OHiB5MQja0HgF28cFSIdSzqh1T87wtlY7GFdc"},
+			{d: "salted sha", hash: "$sha512$bGVnYWN5c2FsdDEyMzQ1Ng$1kiT5TYCK5OK/V+aAq7PdDheiKNha5Nlpi4ri6VOqCZnzy6FgBPw7ZbxOrdYta2OIZqP7wH3nNc9Wyib3cs8ZA"},
+		} {
+			t.Run(fmt.Sprintf("case=%d/description=%s", k, tc.d), func(t *testing.T) {
+				identifier := x.NewUUID().String()
+				i := createIdentityWithHash(identifier, []byte(tc.hash))
+				require.True(t, reg.Hasher().NeedsRehash(context.Background(), []byte(tc.hash)))
+
+				f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
+				values := testhelpers.EncodeFormAsJSON(t, true, url.Values{"method": {"password"}, "password_identifier": {identifier}, "password": {"test"}})
+				body, res := testhelpers.LoginMakeRequest(t, true, f, apiClient, values)
+				require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+				assert.Equal(t, identifier, gjson.Get(body, "session.identity.traits.subject").String(), "%s", body)
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+				require.NoError(t, err)
+				c, ok := actual.GetCredentials(identity.CredentialsTypePassword)
+				require.True(t, ok)
+
+				upgraded := []byte(gjson.GetBytes(c.Config, "hashed_password").String())
+				assert.NotEqual(t, tc.hash, string(upgraded))
+				assert.False(t, reg.Hasher().NeedsRehash(context.Background(), upgraded))
+				assert.NoError(t, hash.Compare(context.Background(), []byte("test"), upgraded))
+			})
+		}
+	})
+
+	t.Run("case=should not change current password hashes", func(t *testing.T) {
+		identifier := x.NewUUID().String()
+		p, err := reg.Hasher().Generate(context.Background(), []byte("test"))
+		require.NoError(t, err)
+		i := createIdentityWithHash(identifier, p)
+
+		f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
+		values := testhelpers.EncodeFormAsJSON(t, true, url.Values{"method": {"password"}, "password_identifier": {identifier}, "password": {"test"}})
+		body, res := testhelpers.LoginMakeRequest(t, true, f, apiClient, values)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+		require.NoError(t, err)
+		c, ok := actual.GetCredentials(identity.CredentialsTypePassword)
+		require.True(t, ok)
+		assert.Equal(t, string(p), gjson.GetBytes(c.Config, "hashed_password").String())
+	})
+
+	t.Run("case=should upgrade password hashes to the current pepper", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySecretsPepper, []string{"old-pepper-secret"})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySecretsPepper, []string{})
+		})
+
+		identifier := x.NewUUID().String()
+		p, err := reg.Hasher().Generate(context.Background(), []byte("test"))
+		require.NoError(t, err)
+		i := createIdentityWithHash(identifier, p)
+
+		conf.MustSet(config.ViperKeySecretsPepper, []string{"new-pepper-secret", "old-pepper-secret"})
+		require.True(t, reg.Hasher().NeedsRehash(context.Background(), p))
+
+		f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
+		values := testhelpers.EncodeFormAsJSON(t, true, url.Values{"method": {"password"}, "password_identifier": {identifier}, "password": {"test"}})
+		body, res := testhelpers.LoginMakeRequest(t, true, f, apiClient, values)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+		require.NoError(t, err)
+		c, ok := actual.GetCredentials(identity.CredentialsTypePassword)
+		require.True(t, ok)
+
+		upgraded := []byte(gjson.GetBytes(c.Config, "hashed_password").String())
+		assert.False(t, reg.Hasher().NeedsRehash(context.Background(), upgraded))
+		assert.NoError(t, hash.Compare(context.Background(), []byte("test"), upgraded, []byte("new-pepper-secret")))
+	})
 }
@@ -126,9 +126,11 @@ func (s *Strategy) validateCredentials(ctx context.Context, i *identity.Identity
 	}
 
 	for _, id := range c.Identifiers {
-		if err := s.d.PasswordValidator().Validate(ctx, id, pw); err != nil {
+		if err := s.d.PasswordValidator().Validate(ctx, i.SchemaID, id, pw); err != nil {
 			if _, ok := errorsx.Cause(err).(*herodot.DefaultError); ok {
 				return err
+			} else if _, ok := errorsx.Cause(err).(*schema.ValidationError); ok {
+				return err
 			}
 			return schema.NewPasswordPolicyViolationError("#/password", err.Error())
 		}
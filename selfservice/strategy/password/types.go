@@ -1,14 +1,12 @@
 package password
 
 import (
+	"kratos/identity"
 	"kratos/ui/container"
 )
 
 // CredentialsConfig is the struct that is being used as part of the identity credentials.
-type CredentialsConfig struct {
-	// HashedPassword is a hash-representation of the password.
-	HashedPassword string `json:"hashed_password"`
-}
+type CredentialsConfig = identity.CredentialsPassword
 
 // submitSelfServiceLoginFlowWithPasswordMethod is used to decode the login form payload.
 //
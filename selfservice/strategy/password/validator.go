@@ -3,7 +3,10 @@ package password
 import (
 	"bufio"
 	"context"
+	"os"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-retryablehttp"
 
@@ -25,15 +28,19 @@ import (
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/stringsx"
+
+	"kratos/schema"
+	"kratos/text"
 )
 
 // Validator implements a validation strategy for passwords. One example is that the password
 // has to have at least 6 characters and at least one lower and one uppercase password.
 type Validator interface {
-	// Validate returns nil if the password is passing the validation strategy and an error otherwise. If a validation error
-	// occurs, a regular error will be returned. If some other type of error occurs (e.g. HTTP request failed), an error
+	// Validate returns nil if the password is passing the validation strategy of the identity schema and an error
+	// otherwise. If the password violates the password policy, an error of type *schema.ValidationError with one message
+	// per violated rule will be returned. If some other type of error occurs (e.g. HTTP request failed), an error
 	// of type *herodot.DefaultError will be returned.
-	Validate(ctx context.Context, identifier, password string) error
+	Validate(ctx context.Context, schemaID, identifier, password string) error
 }
 
 type ValidationProvider interface {
@@ -55,12 +62,10 @@ var ErrUnexpectedStatusCode = errors.New("unexpected status code")
 // password has been breached in a previous data leak using k-anonymity.
 type DefaultPasswordValidator struct {
 	sync.RWMutex
-	reg    validatorDependencies
-	Client *retryablehttp.Client
-	hashes map[string]int64
-
-	minIdentifierPasswordDist            int
-	maxIdentifierPasswordSubstrThreshold float32
+	reg       validatorDependencies
+	Client    *retryablehttp.Client
+	hashes    map[string]int64
+	denylists map[string]map[string]struct{}
 }
 
 type validatorDependencies interface {
@@ -69,10 +74,11 @@ type validatorDependencies interface {
 
 func NewDefaultPasswordValidatorStrategy(reg validatorDependencies) *DefaultPasswordValidator {
 	return &DefaultPasswordValidator{
-		Client:                    httpx.NewResilientClient(httpx.ResilientClientWithConnectionTimeout(time.Second)),
-		reg:                       reg,
-		hashes:                    map[string]int64{},
-		minIdentifierPasswordDist: 5, maxIdentifierPasswordSubstrThreshold: 0.5}
+		Client:    httpx.NewResilientClient(httpx.ResilientClientWithConnectionTimeout(time.Second)),
+		reg:       reg,
+		hashes:    map[string]int64{},
+		denylists: map[string]map[string]struct{}{},
+	}
 }
 
 func b20(src []byte) string {
@@ -144,18 +150,55 @@ func (s *DefaultPasswordValidator) fetch(hpw []byte) error {
 	return nil
 }
 
-func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, password string) error {
-	if len(password) < 6 {
-		return errors.Errorf("password length must be at least 6 characters but only got %d", len(password))
+func (s *DefaultPasswordValidator) Validate(ctx context.Context, schemaID, identifier, password string) error {
+	policy, err := s.reg.Config(ctx).PasswordPolicyConfigForSchema(schemaID)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to load the password policy: %s", err))
+	}
+
+	var violations text.Messages
+	length := utf8.RuneCountInString(password)
+	if length < policy.MinPasswordLength {
+		violations.Add(text.NewErrorValidationPasswordMinLength(policy.MinPasswordLength, length))
+	}
+
+	if policy.MaxPasswordLength > 0 && length > policy.MaxPasswordLength {
+		violations.Add(text.NewErrorValidationPasswordMaxLength(policy.MaxPasswordLength, length))
+	}
+
+	for _, class := range policy.RequiredCharacterClasses {
+		if !containsCharacterClass(password, class) {
+			violations.Add(text.NewErrorValidationPasswordCharacterClass(class))
+		}
+	}
+
+	if len(policy.DenylistFile) > 0 {
+		denied, err := s.isDenied(policy.DenylistFile, password)
+		if err != nil {
+			return err
+		} else if denied {
+			violations.Add(text.NewErrorValidationPasswordDenied())
+		}
+	}
+
+	if len(identifier) > 0 && len(password) > 0 {
+		compIdentifier, compPassword := strings.ToLower(identifier), strings.ToLower(password)
+		dist := levenshtein.Distance(compIdentifier, compPassword)
+		lcs := float64(lcsLength(compIdentifier, compPassword)) / float64(len(compPassword))
+		if dist < policy.MinIdentifierDistance || lcs > policy.MaxIdentifierSubstringRatio {
+			violations.Add(text.NewErrorValidationPasswordIdentifierTooSimilar())
+		}
 	}
 
-	compIdentifier, compPassword := strings.ToLower(identifier), strings.ToLower(password)
-	dist := levenshtein.Distance(compIdentifier, compPassword)
-	lcs := float32(lcsLength(compIdentifier, compPassword)) / float32(len(compPassword))
-	if dist < s.minIdentifierPasswordDist || lcs > s.maxIdentifierPasswordSubstrThreshold {
-		return errors.Errorf("the password is too similar to the user identifier")
+	if len(violations) > 0 {
+		return schema.NewPasswordPolicyRulesViolationError("#/password", violations)
 	}
 
+	// The breach lookup requires a network request and is only done if all other rules pass.
+	return s.validateBreaches(ctx, policy, password)
+}
+
+func (s *DefaultPasswordValidator) validateBreaches(ctx context.Context, policy *config.PasswordPolicy, password string) error {
 	/* #nosec G401 sha1 is used for k-anonymity */
 	h := sha1.New()
 	if _, err := h.Write([]byte(password)); err != nil {
@@ -169,18 +212,76 @@ func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, pas
 
 	if !ok {
 		err := s.fetch(hpw)
-		if (errors.Is(err, ErrNetworkFailure) || errors.Is(err, ErrUnexpectedStatusCode)) && s.reg.Config(ctx).PasswordPolicyConfig().IgnoreNetworkErrors {
+		if (errors.Is(err, ErrNetworkFailure) || errors.Is(err, ErrUnexpectedStatusCode)) && policy.IgnoreNetworkErrors {
 			return nil
 		} else if err != nil {
 			return err
 		}
 
-		return s.Validate(ctx, identifier, password)
+		return s.validateBreaches(ctx, policy, password)
 	}
 
-	if c > int64(s.reg.Config(ctx).PasswordPolicyConfig().MaxBreaches) {
-		return errors.New("the password has been found in data breaches and must no longer be used.")
+	if c > int64(policy.MaxBreaches) {
+		return schema.NewPasswordPolicyRulesViolationError("#/password", new(text.Messages).Add(text.NewErrorValidationPasswordTooManyBreaches(c)))
 	}
 
 	return nil
 }
+
+// isDenied returns true if the password is contained in the denylist file. The file is read once and cached.
+func (s *DefaultPasswordValidator) isDenied(path, password string) (bool, error) {
+	s.RLock()
+	denylist, ok := s.denylists[path]
+	s.RUnlock()
+
+	if !ok {
+		f, err := os.Open(path)
+		if err != nil {
+			return false, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to open the password denylist file: %s", err))
+		}
+		defer f.Close()
+
+		denylist = map[string]struct{}{}
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			if line := strings.TrimSpace(sc.Text()); len(line) > 0 && !strings.HasPrefix(line, "#") {
+				denylist[strings.ToLower(line)] = struct{}{}
+			}
+		}
+
+		if err := sc.Err(); err != nil {
+			return false, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to read the password denylist file: %s", err))
+		}
+
+		s.Lock()
+		s.denylists[path] = denylist
+		s.Unlock()
+	}
+
+	_, denied := denylist[strings.ToLower(password)]
+	return denied, nil
+}
+
+func containsCharacterClass(password, class string) bool {
+	for _, r := range password {
+		switch class {
+		case "lowercase":
+			if unicode.IsLower(r) {
+				return true
+			}
+		case "uppercase":
+			if unicode.IsUpper(r) {
+				return true
+			}
+		case "digit":
+			if unicode.IsDigit(r) {
+				return true
+			}
+		case "symbol":
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
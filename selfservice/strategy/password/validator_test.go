@@ -7,16 +7,22 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/ory/herodot"
+	"github.com/ory/x/errorsx"
 	"github.com/ory/x/httpx"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"kratos/driver/config"
 	"kratos/internal"
+	"kratos/schema"
 	"kratos/selfservice/strategy/password"
+	"kratos/text"
 )
 
 func TestDefaultPasswordValidationStrategy(t *testing.T) {
@@ -62,7 +68,7 @@ func TestDefaultPasswordValidationStrategy(t *testing.T) {
 		} {
 			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 				t.Parallel()
-				err := s.Validate(context.Background(), tc.id, tc.pw)
+				err := s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, tc.id, tc.pw)
 				if tc.pass {
 					require.NoError(t, err, "err: %+v, id: %s, pw: %s", err, tc.id, tc.pw)
 				} else {
@@ -81,32 +87,32 @@ func TestDefaultPasswordValidationStrategy(t *testing.T) {
 
 		t.Run("case=should send request to pwnedpasswords.com", func(t *testing.T) {
 			conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
-			require.Error(t, s.Validate(context.Background(), "mohutdesub", "damrumukuh"))
+			require.Error(t, s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, "mohutdesub", "damrumukuh"))
 			require.Contains(t, fakeClient.RequestedURLs(), "https://api.pwnedpasswords.com/range/BCBA9")
 		})
 
 		t.Run("case=should fail if request fails and ignoreNetworkErrors is not set", func(t *testing.T) {
 			conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
 			fakeClient.RespondWithError("Network request failed")
-			require.Error(t, s.Validate(context.Background(), "", "sumdarmetp"))
+			require.Error(t, s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, "", "sumdarmetp"))
 		})
 
 		t.Run("case=should not fail if request fails and ignoreNetworkErrors is set", func(t *testing.T) {
 			conf.MustSet(config.ViperKeyIgnoreNetworkErrors, true)
 			fakeClient.RespondWithError("Network request failed")
-			require.NoError(t, s.Validate(context.Background(), "", "pepegtawni"))
+			require.NoError(t, s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, "", "pepegtawni"))
 		})
 
 		t.Run("case=should fail if response has non 200 code and ignoreNetworkErrors is not set", func(t *testing.T) {
 			conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
 			fakeClient.RespondWith(http.StatusForbidden, "")
-			require.Error(t, s.Validate(context.Background(), "", "jolhakowef"))
+			require.Error(t, s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, "", "jolhakowef"))
 		})
 
 		t.Run("case=should not fail if response has non 200 code code and ignoreNetworkErrors is set", func(t *testing.T) {
 			conf.MustSet(config.ViperKeyIgnoreNetworkErrors, true)
 			fakeClient.RespondWith(http.StatusInternalServerError, "")
-			require.NoError(t, s.Validate(context.Background(), "", "jenuzuhjoj"))
+			require.NoError(t, s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, "", "jenuzuhjoj"))
 		})
 	})
 
@@ -166,7 +172,7 @@ func TestDefaultPasswordValidationStrategy(t *testing.T) {
 				format = "case=should fail if response %s"
 			}
 			t.Run(fmt.Sprintf(format, tc.cs), func(t *testing.T) {
-				err := s.Validate(context.Background(), "", tc.pw)
+				err := s.Validate(context.Background(), config.DefaultIdentityTraitsSchemaID, "", tc.pw)
 				if tc.pass {
 					require.NoError(t, err)
 				} else {
@@ -177,6 +183,97 @@ func TestDefaultPasswordValidationStrategy(t *testing.T) {
 	})
 }
 
+func TestPasswordPolicyRules(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	s := password.NewDefaultPasswordValidatorStrategy(reg)
+	fakeClient := NewFakeHTTPClient()
+	fakeClient.RespondWith(http.StatusOK, "")
+	s.Client = httpx.NewResilientClient(httpx.ResilientClientWithClient(&fakeClient.Client), httpx.ResilientClientWithMaxRetry(1), httpx.ResilientClientWithConnectionTimeout(time.Millisecond))
+
+	denylist := filepath.Join(t.TempDir(), "denylist.txt")
+	require.NoError(t, ioutil.WriteFile(denylist, []byte("# common passwords\n\nCorrectHorse1!\nhunter2hunter2\n"), 0600))
+
+	conf.MustSet(config.ViperKeyPasswordMinLength, 8)
+	conf.MustSet(config.ViperKeyPasswordMaxLength, 16)
+	conf.MustSet(config.ViperKeyPasswordRequiredCharacterClasses, []string{"lowercase", "uppercase", "digit", "symbol"})
+	conf.MustSet(config.ViperKeyPasswordDenylistFile, denylist)
+	conf.MustSet(config.ViperKeyPasswordSchemaOverrides, map[string]interface{}{
+		"relaxed": map[string]interface{}{
+			"min_password_length":        4,
+			"required_character_classes": []string{},
+		},
+		"strict": map[string]interface{}{
+			"min_password_length":            20,
+			"max_password_length":            0,
+			"min_identifier_distance":        0,
+			"max_identifier_substring_ratio": 1,
+		},
+	})
+
+	messageIDs := func(t *testing.T, err error) []text.ID {
+		if err == nil {
+			return nil
+		}
+
+		var ve *schema.ValidationError
+		require.True(t, errors.As(err, &ve), "%+v", err)
+		assert.Equal(t, "#/password", ve.InstancePtr)
+
+		ids := make([]text.ID, len(ve.Messages))
+		for k, m := range ve.Messages {
+			ids[k] = m.ID
+		}
+		return ids
+	}
+
+	for k, tc := range []struct {
+		schema string
+		id     string
+		pw     string
+		expect []text.ID
+	}{
+		{pw: "Tr0ub4dor&3", expect: nil},
+		{pw: "Tr0ub&", expect: []text.ID{text.ErrorValidationPasswordMinLength}},
+		{pw: "Tr0ub4dor&3Tr0ub4dor&3", expect: []text.ID{text.ErrorValidationPasswordMaxLength}},
+		{pw: "tr0ub4dor&3", expect: []text.ID{text.ErrorValidationPasswordCharacterClass}},
+		{pw: "abc", expect: []text.ID{
+			text.ErrorValidationPasswordMinLength,
+			text.ErrorValidationPasswordCharacterClass,
+			text.ErrorValidationPasswordCharacterClass,
+			text.ErrorValidationPasswordCharacterClass,
+		}},
+		{pw: "correcthorse1!", expect: []text.ID{text.ErrorValidationPasswordCharacterClass, text.ErrorValidationPasswordDenied}},
+		{pw: "CORRECTHORSE1!", expect: []text.ID{text.ErrorValidationPasswordCharacterClass, text.ErrorValidationPasswordDenied}},
+		{pw: "CorrectHorse1!", expect: []text.ID{text.ErrorValidationPasswordDenied}},
+		{id: "hello@example.com", pw: "Hello@example.com1", expect: []text.ID{text.ErrorValidationPasswordMaxLength, text.ErrorValidationPasswordIdentifierTooSimilar}},
+		{schema: "relaxed", pw: "hunt", expect: nil},
+		{schema: "relaxed", pw: "hunter2hunter2", expect: []text.ID{text.ErrorValidationPasswordDenied}},
+		{schema: "strict", pw: "Tr0ub4dor&3", expect: []text.ID{text.ErrorValidationPasswordMinLength}},
+		{schema: "strict", id: "hello@example.com", pw: "Hello@example.com1234", expect: nil},
+		{schema: "unknown", pw: "Tr0ub&", expect: []text.ID{text.ErrorValidationPasswordMinLength}},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			assert.Equal(t, tc.expect, messageIDs(t, s.Validate(context.Background(), tc.schema, tc.id, tc.pw)))
+		})
+	}
+
+	t.Run("case=reports breaches as their own message", func(t *testing.T) {
+		fakeClient.RespondWith(http.StatusOK, "61DDCC5E8A2DABEDE0F3B482CD9AEA9434D:5")
+		assert.Equal(t, []text.ID{text.ErrorValidationPasswordTooManyBreaches}, messageIDs(t, s.Validate(context.Background(), "relaxed", "", "hello")))
+	})
+
+	t.Run("case=fails if the denylist can not be read", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyPasswordDenylistFile, filepath.Join(t.TempDir(), "does-not-exist.txt"))
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPasswordDenylistFile, denylist)
+		})
+
+		err := s.Validate(context.Background(), "", "", "Tr0ub4dor&3")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, errorsx.Cause(err).(*herodot.DefaultError).StatusCode())
+	})
+}
+
 type fakeHttpClient struct {
 	http.Client
 
@@ -33,6 +33,7 @@ import (
 	"kratos/internal"
 	"kratos/internal/testhelpers"
 	"kratos/selfservice/flow/settings"
+	"kratos/text"
 	"kratos/x"
 )
 
@@ -385,6 +386,55 @@ func TestStrategyTraits(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should come back with form errors if an interrupting web hook rejects the update", func(t *testing.T) {
+		setPrivileged(t)
+
+		var called int
+		hookTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"messages":[{"instance_ptr":"#/traits/stringy","messages":[{"id":1234,"text":"stringy is not allowed","type":"error"}]},{"messages":[{"text":"rejected by web hook"}]}]}`))
+		}))
+		t.Cleanup(hookTS.Close)
+
+		conf.MustSet(config.ViperKeySelfServiceSettingsAfter+".profile.hooks", []map[string]interface{}{
+			{"hook": "web_hook", "config": map[string]interface{}{"url": hookTS.URL, "can_interrupt": true}},
+		})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceSettingsAfter+".profile.hooks", nil)
+		})
+
+		var check = func(t *testing.T, actual string) {
+			assert.Equal(t, "not-allowed", gjson.Get(actual, "ui.nodes.#(attributes.name==traits.stringy).attributes.value").String(), "%s", actual)
+			assert.EqualValues(t, 1234, gjson.Get(actual, "ui.nodes.#(attributes.name==traits.stringy).messages.0.id").Int(), "%s", actual)
+			assert.Equal(t, "stringy is not allowed", gjson.Get(actual, "ui.nodes.#(attributes.name==traits.stringy).messages.0.text").String(), "%s", actual)
+			assert.Equal(t, "rejected by web hook", gjson.Get(actual, "ui.messages.0.text").String(), "%s", actual)
+			assert.EqualValues(t, text.ErrorValidationGeneric, gjson.Get(actual, "ui.messages.0.id").Int(), "%s", actual)
+		}
+
+		var payload = func(v url.Values) {
+			v.Set("method", "profile")
+			v.Set("traits.stringy", "not-allowed")
+		}
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, expectValidationError(t, true, apiUser1, payload))
+			actual, err := reg.PrivilegedIdentityPool().GetIdentity(context.Background(), apiIdentity1.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "foobar", gjson.GetBytes(actual.Traits, "stringy").String())
+		})
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, expectValidationError(t, false, browserUser1, payload))
+			actual, err := reg.PrivilegedIdentityPool().GetIdentity(context.Background(), browserIdentity1.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "foobar", gjson.GetBytes(actual.Traits, "stringy").String())
+		})
+
+		assert.Equal(t, 2, called, "the web hook must only be called before the identity is updated")
+	})
+
 	t.Run("description=should not be able to make requests for another user", func(t *testing.T) {
 		setUnprivileged(t)
 
@@ -549,6 +599,32 @@ func TestStrategyTraits(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should not update or expose the metadata", func(t *testing.T) {
+		setPrivileged(t)
+
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), apiIdentity1.ID)
+		require.NoError(t, err)
+		i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"role":"user"}`)
+		i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"note":"secret"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), i))
+
+		actual := expectSuccess(t, true, apiUser1, func(v url.Values) {
+			v.Set("method", settings.StrategyProfile)
+			v.Set("traits.email", "not-john-doe-api@mail.com")
+			v.Set("traits.should_long_string", "this is such a long string, amazing stuff!")
+			v.Set("metadata_public.role", "admin")
+			v.Set("metadata_admin.note", "tampered")
+		})
+		assert.Equal(t, "user", gjson.Get(actual, "identity.metadata_public.role").String(), "%s", actual)
+		assert.False(t, gjson.Get(actual, "identity.metadata_admin").Exists(), "%s", actual)
+		assert.False(t, gjson.Get(actual, "flow.identity.metadata_admin").Exists(), "%s", actual)
+
+		i, err = reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), apiIdentity1.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"role":"user"}`, string(i.MetadataPublic))
+		assert.JSONEq(t, `{"note":"secret"}`, string(i.MetadataAdmin))
+	})
+
 	t.Run("flow=try another update with invalid data", func(t *testing.T) {
 		setPrivileged(t)
 
@@ -0,0 +1,59 @@
+package totp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image/png"
+
+	"github.com/pkg/errors"
+	"github.com/pquerna/otp"
+	stdtotp "github.com/pquerna/otp/totp"
+
+	"github.com/ory/herodot"
+
+	"kratos/driver/config"
+	"kratos/identity"
+)
+
+// qrCodeSize is the width and height of the generated QR code in pixels.
+const qrCodeSize = 256
+
+// NewKey creates a new TOTP key for the given account name.
+func NewKey(ctx context.Context, accountName string, d interface {
+	config.Provider
+}) (*otp.Key, error) {
+	key, err := stdtotp.Generate(stdtotp.GenerateOpts{
+		Issuer:      d.Config(ctx).TOTPIssuer(),
+		AccountName: accountName,
+	})
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to generate TOTP key: %s", err))
+	}
+
+	return key, nil
+}
+
+// KeyToHTMLImage renders the key as a PNG QR code which can be used as an image source.
+func KeyToHTMLImage(key *otp.Key) (string, error) {
+	img, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to render TOTP QR code: %s", err))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode TOTP QR code: %s", err))
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// accountName returns the name which is shown in the authenticator app next to the issuer.
+func accountName(i *identity.Identity) string {
+	if c, ok := i.GetCredentials(identity.CredentialsTypePassword); ok && len(c.Identifiers) > 0 && len(c.Identifiers[0]) > 0 {
+		return c.Identifiers[0]
+	}
+
+	return i.ID.String()
+}
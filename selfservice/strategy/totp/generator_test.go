@@ -0,0 +1,50 @@
+package totp_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	stdtotp "github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kratos/driver/config"
+	"kratos/internal"
+	"kratos/selfservice/strategy/totp"
+)
+
+func TestGenerator(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	t.Run("case=defaults to the public base url's host", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+
+		key, err := totp.NewKey(context.Background(), "foo@ory.sh", reg)
+		require.NoError(t, err)
+		assert.Equal(t, "www.ory.sh", key.Issuer())
+	})
+
+	t.Run("case=uses the configured issuer", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyTOTPIssuer, "ory.sh")
+
+		key, err := totp.NewKey(context.Background(), "foo@ory.sh", reg)
+		require.NoError(t, err)
+		assert.Equal(t, "ory.sh", key.Issuer())
+		assert.Equal(t, "foo@ory.sh", key.AccountName())
+
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+		assert.True(t, stdtotp.Validate(code, key.Secret()))
+	})
+
+	t.Run("case=renders key as an image source", func(t *testing.T) {
+		key, err := totp.NewKey(context.Background(), "foo@ory.sh", reg)
+		require.NoError(t, err)
+
+		src, err := totp.KeyToHTMLImage(key)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(src, "data:image/png;base64,"), src)
+	})
+}
@@ -0,0 +1,213 @@
+package totp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/pquerna/otp"
+	stdtotp "github.com/pquerna/otp/totp"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/login"
+	"kratos/text"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+const (
+	// period is the period of the generated keys, see stdtotp.Generate.
+	period = 30 * time.Second
+
+	internalContextKeyAttempts = "totp.attempts"
+)
+
+var errCodeWrong = errors.New("the TOTP code is wrong")
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+}
+
+// PopulateLoginMethod does not add any nodes because TOTP can only be used as a second factor.
+func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
+	return nil
+}
+
+func (s *Strategy) HasSecondFactor(_ context.Context, i *identity.Identity) bool {
+	var o CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &o); err != nil {
+		return false
+	}
+
+	return len(o.TOTPURL) > 0
+}
+
+func (s *Strategy) PopulateSecondFactorMethod(r *http.Request, _ *identity.Identity, sr *login.Flow) error {
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.SetNode(NewVerifyTOTPNode())
+	sr.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.TOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLoginTOTP()))
+
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) error {
+	if f != nil {
+		f.UI.Nodes.ResetNodes("totp_code")
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceLoginFlowWithTotpMethod
+type submitSelfServiceLoginFlowWithTotpMethodParameters struct {
+	// The Flow ID
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	Body submitSelfServiceLoginFlowWithTotpMethod
+}
+
+// swagger:model submitSelfServiceLoginFlowWithTotpMethod
+type submitSelfServiceLoginFlowWithTotpMethod struct {
+	// Method should be set to "totp" when logging in using the TOTP strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// The TOTP code.
+	//
+	// required: true
+	TOTPCode string `json:"totp_code"`
+}
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow) (i *identity.Identity, err error) {
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	id := f.PendingSecondFactor()
+	if id == uuid.Nil {
+		// The first authentication factor has not been completed yet.
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	var p submitSelfServiceLoginFlowWithTotpMethod
+	if err := s.hd.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	// The time step of the code is recorded within the same transaction which checks it so that it can not be used twice.
+	if err := s.d.PrivilegedIdentityPool().UpdateCredentialsConfig(r.Context(), id, s.ID(), func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error) {
+		return useCode(config, p.TOTPCode, time.Now().UTC())
+	}); errors.Is(err, errCodeWrong) {
+		// The updated attempts are persisted by the error handler.
+		return nil, s.handleLoginError(r, f, s.attemptFailed(r.Context(), f))
+	} else if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewInvalidCredentialsError()))
+	} else if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	i, err = s.d.PrivilegedIdentityPool().GetIdentity(r.Context(), id)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	return i, nil
+}
+
+// attemptFailed counts a wrong code in the flow's internal context and returns the error to show. Once the code
+// was entered incorrectly too many times, the pending second factor is invalidated and the first factor has to be
+// completed again.
+func (s *Strategy) attemptFailed(ctx context.Context, f *login.Flow) error {
+	attempts := gjson.GetBytes(f.InternalContext, internalContextKeyAttempts).Int() + 1
+	if attempts < int64(s.d.Config(ctx).TOTPMaxAttempts()) {
+		ic, err := flow.SetInternalContext(f.InternalContext, internalContextKeyAttempts, attempts)
+		if err != nil {
+			return err
+		}
+
+		f.InternalContext = ic
+		return errors.WithStack(schema.NewTOTPVerifierWrongError("#/totp_code"))
+	}
+
+	ic, err := flow.SetInternalContext(f.InternalContext, internalContextKeyAttempts, nil)
+	if err != nil {
+		return err
+	}
+
+	f.InternalContext = ic
+	if err := f.ResetPendingSecondFactor(); err != nil {
+		return err
+	}
+
+	return errors.WithStack(schema.NewTOTPTooManyAttemptsError("#/totp_code"))
+}
+
+// useCode checks the code and records its time step in the credentials config. Like stdtotp.Validate, the codes
+// of the previous and the next time step are accepted as well to allow for clock drift.
+func useCode(config sqlxx.JSONRawMessage, code string, at time.Time) (sqlxx.JSONRawMessage, error) {
+	var o CredentialsConfig
+	if err := json.Unmarshal(config, &o); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The TOTP credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	key, err := otp.NewKeyFromURL(o.TOTPURL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The TOTP credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	for _, skew := range []time.Duration{0, -period, period} {
+		expected, err := stdtotp.GenerateCode(key.Secret(), at.Add(skew))
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to generate TOTP code: %s", err))
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) != 1 {
+			continue
+		}
+
+		step := uint64(at.Add(skew).Unix()) / uint64(period/time.Second)
+		if step <= o.LastUsedStep {
+			return nil, errors.WithStack(schema.NewTOTPAlreadyUsedError("#/totp_code"))
+		}
+
+		o.LastUsedStep = step
+		updated, err := json.Marshal(&o)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode TOTP credentials to JSON: %s", err))
+		}
+
+		return updated, nil
+	}
+
+	return nil, errors.WithStack(errCodeWrong)
+}
@@ -0,0 +1,250 @@
+package totp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp"
+	stdtotp "github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+
+	"kratos/driver"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/login"
+	"kratos/selfservice/strategy/totp"
+	"kratos/session"
+	"kratos/text"
+	"kratos/x"
+)
+
+func createIdentityWithTOTP(t *testing.T, reg driver.Registry, withTOTP bool) (*identity.Identity, string, *otp.Key) {
+	email := x.NewUUID().String() + "@ory.sh"
+	password := x.NewUUID().String()
+
+	hpw, err := reg.Hasher().Generate(context.Background(), []byte(password))
+	require.NoError(t, err)
+
+	i := &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"email":"%s"}`, email)),
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{email},
+				Config:      []byte(fmt.Sprintf(`{"hashed_password":"%s"}`, hpw)),
+			},
+		},
+	}
+
+	var key *otp.Key
+	if withTOTP {
+		key, err = totp.NewKey(context.Background(), email, reg)
+		require.NoError(t, err)
+		i.Credentials[identity.CredentialsTypeTOTP] = identity.Credentials{
+			Type:        identity.CredentialsTypeTOTP,
+			Identifiers: []string{x.NewUUID().String()},
+			Config:      []byte(fmt.Sprintf(`{"totp_url":"%s"}`, key.URL())),
+		}
+	}
+
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+	return i, password, key
+}
+
+func TestCompleteLogin(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeTOTP.String(), true)
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	var submit = func(t *testing.T, action string, payload interface{}) (string, *http.Response) {
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		res, err := http.DefaultClient.Do(testhelpers.NewRequest(t, true, "POST", action, bytes.NewReader(raw)))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return string(ioutilx.MustReadAll(res.Body)), res
+	}
+
+	var loginWithPassword = func(t *testing.T, email, password string) (string, *http.Response, string) {
+		f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+		body, res := submit(t, f.Ui.Action, map[string]string{
+			"method":              "password",
+			"password_identifier": email,
+			"password":            password,
+		})
+		return body, res, f.Ui.Action
+	}
+
+	t.Run("case=identity without totp is logged in after the first factor", func(t *testing.T) {
+		i, password, _ := createIdentityWithTOTP(t, reg, false)
+
+		body, res, _ := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, gjson.Get(body, "session.aal").String(), body)
+		assert.EqualValues(t, identity.CredentialsTypePassword, gjson.Get(body, "session.authentication_methods.0.method").String(), body)
+	})
+
+	t.Run("case=identity with totp has to complete the second factor", func(t *testing.T) {
+		i, password, key := createIdentityWithTOTP(t, reg, true)
+
+		body, res, action := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+		assert.EqualValues(t, text.InfoSelfServiceMFA, gjson.Get(body, "ui.messages.0.id").Int(), body)
+		assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code)").Exists(), body)
+		assert.False(t, gjson.Get(body, "ui.nodes.#(attributes.name==password)").Exists(), body)
+
+		t.Run("case=rejects an invalid code", func(t *testing.T) {
+			body, res := submit(t, action, map[string]string{"method": "totp", "totp_code": "111111"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.EqualValues(t, text.ErrorValidationTOTPVerifierWrong, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code).messages.0.id").Int(), body)
+		})
+
+		t.Run("case=issues a session with a valid code", func(t *testing.T) {
+			code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+			require.NoError(t, err)
+
+			body, res := submit(t, action, map[string]string{"method": "totp", "totp_code": code})
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+			assert.Equal(t, i.ID.String(), gjson.Get(body, "session.identity.id").String(), body)
+			assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "session.aal").String(), body)
+			assert.EqualValues(t, identity.CredentialsTypePassword, gjson.Get(body, "session.authentication_methods.0.method").String(), body)
+			assert.EqualValues(t, identity.CredentialsTypeTOTP, gjson.Get(body, "session.authentication_methods.1.method").String(), body)
+		})
+	})
+
+	t.Run("case=rejects a code which was already used", func(t *testing.T) {
+		i, password, key := createIdentityWithTOTP(t, reg, true)
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+
+		body, res, action := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		body, res = submit(t, action, map[string]string{"method": "totp", "totp_code": code})
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+
+		body, res, action = loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		body, res = submit(t, action, map[string]string{"method": "totp", "totp_code": code})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+		assert.EqualValues(t, text.ErrorValidationTOTPAlreadyUsed, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code).messages.0.id").Int(), body)
+	})
+
+	t.Run("case=invalidates the second factor after too many wrong codes", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyTOTPMaxAttempts, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyTOTPMaxAttempts, 5)
+		})
+
+		i, password, key := createIdentityWithTOTP(t, reg, true)
+		body, res, action := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+
+		body, res = submit(t, action, map[string]string{"method": "totp", "totp_code": "111111"})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.EqualValues(t, text.ErrorValidationTOTPVerifierWrong, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code).messages.0.id").Int(), body)
+
+		body, res = submit(t, action, map[string]string{"method": "totp", "totp_code": "111111"})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.EqualValues(t, text.ErrorValidationTOTPTooManyAttempts, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code).messages.0.id").Int(), body)
+
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+
+		body, res = submit(t, action, map[string]string{"method": "totp", "totp_code": code})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Empty(t, gjson.Get(body, "session_token").String(), body, "a valid code must not be accepted once the second factor was invalidated")
+	})
+
+	t.Run("case=step up an existing session", func(t *testing.T) {
+		var initFlow = func(t *testing.T, hc *http.Client) (string, *http.Response) {
+			res, err := hc.Get(publicTS.URL + login.RouteInitAPIFlow + "?aal=aal2")
+			require.NoError(t, err)
+			defer res.Body.Close()
+			return string(ioutilx.MustReadAll(res.Body)), res
+		}
+
+		t.Run("case=requires a session", func(t *testing.T) {
+			body, res := initFlow(t, http.DefaultClient)
+			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode, body)
+		})
+
+		t.Run("case=requires a second factor", func(t *testing.T) {
+			i, _, _ := createIdentityWithTOTP(t, reg, false)
+			body, res := initFlow(t, testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i))
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		})
+
+		t.Run("case=updates the session with a valid code", func(t *testing.T) {
+			i, _, key := createIdentityWithTOTP(t, reg, true)
+			hc := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i)
+
+			body, res := initFlow(t, hc)
+			require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "requested_aal").String(), body)
+			assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code)").Exists(), body)
+			assert.False(t, gjson.Get(body, "ui.nodes.#(attributes.name==password)").Exists(), body)
+
+			code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+			require.NoError(t, err)
+
+			res, err = hc.Do(testhelpers.NewRequest(t, true, "POST", gjson.Get(body, "ui.action").String(), bytes.NewReader([]byte(fmt.Sprintf(`{"method":"totp","totp_code":"%s"}`, code)))))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body = string(ioutilx.MustReadAll(res.Body))
+			require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+
+			res, err = hc.Get(publicTS.URL + session.RouteWhoami)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body = string(ioutilx.MustReadAll(res.Body))
+			assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "aal").String(), body)
+			assert.EqualValues(t, identity.CredentialsTypeTOTP, gjson.Get(body, "authentication_methods.#(method==totp).method").String(), body)
+		})
+	})
+
+	t.Run("case=totp can not be used as the first factor", func(t *testing.T) {
+		_, _, key := createIdentityWithTOTP(t, reg, true)
+		code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+		require.NoError(t, err)
+
+		f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+		body, res := submit(t, f.Ui.Action, map[string]string{"method": "totp", "totp_code": code})
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+		assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+		assert.EqualValues(t, text.ErrorValidationLoginNoStrategyFound, gjson.Get(body, "ui.messages.0.id").Int(), body)
+	})
+
+	t.Run("case=second factor is skipped if totp is disabled", func(t *testing.T) {
+		testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeTOTP.String(), false)
+		t.Cleanup(func() {
+			testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeTOTP.String(), true)
+		})
+
+		i, password, _ := createIdentityWithTOTP(t, reg, true)
+		body, res, _ := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+	})
+
+}
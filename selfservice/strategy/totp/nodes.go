@@ -0,0 +1,32 @@
+package totp
+
+import (
+	"github.com/pquerna/otp"
+
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+func NewVerifyTOTPNode() *node.Node {
+	return node.NewInputField("totp_code", nil, node.TOTPGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).
+		WithMetaLabel(text.NewInfoNodeLabelVerifyOTP())
+}
+
+func NewTOTPImageQRNode(key *otp.Key) (*node.Node, error) {
+	src, err := KeyToHTMLImage(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.NewImageField("totp_qr", src, node.TOTPGroup).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsTOTPQRCode()), nil
+}
+
+func NewTOTPSecretNode(key *otp.Key) *node.Node {
+	return node.NewTextField("totp_secret_key", text.NewInfoSelfServiceSettingsTOTPSecret(key.Secret()), node.TOTPGroup)
+}
+
+func NewUnlinkTOTPNode() *node.Node {
+	return node.NewInputField("totp_unlink", "true", node.TOTPGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsUpdateUnlinkTOTP())
+}
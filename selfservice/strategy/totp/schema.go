@@ -0,0 +1,11 @@
+package totp
+
+import (
+	_ "embed"
+)
+
+//go:embed .schema/login.schema.json
+var loginSchema []byte
+
+//go:embed .schema/settings.schema.json
+var settingsSchema []byte
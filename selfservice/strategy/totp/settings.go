@@ -0,0 +1,256 @@
+package totp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/pquerna/otp"
+	stdtotp "github.com/pquerna/otp/totp"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/settings"
+	"kratos/session"
+	"kratos/text"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+// internalContextKeyURL is the settings flow's internal context key holding the TOTP key which is being enrolled.
+const internalContextKeyURL = "totp_url"
+
+func (s *Strategy) RegisterSettingsRoutes(_ *x.RouterPublic) {
+}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return identity.CredentialsTypeTOTP.String()
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceSettingsFlowWithTotpMethod
+type submitSelfServiceSettingsFlowWithTotpMethod struct {
+	// in: body
+	Body submitSelfServiceSettingsFlowWithTotpMethodBody
+
+	// Flow is flow ID.
+	//
+	// in: query
+	Flow string `json:"flow"`
+}
+
+// swagger:model submitSelfServiceSettingsFlowWithTotpMethod
+type submitSelfServiceSettingsFlowWithTotpMethodBody struct {
+	// ValidationTOTP must contain a valid TOTP based on the secret shown in the settings flow.
+	//
+	// type: string
+	ValidationTOTP string `json:"totp_code"`
+
+	// UnlinkTOTP if true will remove the TOTP pairing,
+	// effectively removing the credential. This can be used
+	// to set up a new TOTP device.
+	//
+	// type: boolean
+	UnlinkTOTP bool `json:"totp_unlink"`
+
+	// CSRFToken is the anti-CSRF token
+	//
+	// type: string
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "totp" when trying to add, update, or remove a totp pairing.
+	//
+	// type: string
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+}
+
+func (p *submitSelfServiceSettingsFlowWithTotpMethodBody) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *submitSelfServiceSettingsFlowWithTotpMethodBody) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (*settings.UpdateContext, error) {
+	var p submitSelfServiceSettingsFlowWithTotpMethodBody
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if p.UnlinkTOTP {
+		// The unlink node is a submit button and thus does not send the method.
+		p.Method = s.SettingsStrategyID()
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.NewHTTP().Decode(r, dest, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(
+	w http.ResponseWriter, r *http.Request,
+	ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithTotpMethodBody,
+) error {
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if err := flow.EnsureCSRF(r, ctxUpdate.Flow.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge()).Before(time.Now()) {
+		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if p.UnlinkTOTP {
+		delete(i.Credentials, s.ID())
+	} else if err := s.addTOTP(ctxUpdate, p, i); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if err := s.d.SettingsHookExecutor().PostSettingsHook(w, r, s.SettingsStrategyID(), ctxUpdate, i,
+		settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
+			return s.PopulateSettingsMethod(r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
+		})); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) addTOTP(ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithTotpMethodBody, i *identity.Identity) error {
+	if len(p.ValidationTOTP) == 0 {
+		return schema.NewRequiredError("#/totp_code", "totp_code")
+	}
+
+	keyURL := gjson.GetBytes(ctxUpdate.Flow.InternalContext, internalContextKeyURL).String()
+	if len(keyURL) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Could not find the TOTP key in the settings flow. Please restart the flow."))
+	}
+
+	key, err := otp.NewKeyFromURL(keyURL)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decode the TOTP key stored in the settings flow.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	if !stdtotp.Validate(p.ValidationTOTP, key.Secret()) {
+		return errors.WithStack(schema.NewTOTPVerifierWrongError("#/totp_code"))
+	}
+
+	co, err := json.Marshal(&CredentialsConfig{TOTPURL: key.URL()})
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode totp options to JSON: %s", err))
+	}
+
+	i.SetCredentials(s.ID(), identity.Credentials{
+		Type:        s.ID(),
+		Identifiers: []string{i.ID.String()},
+		Config:      co,
+	})
+	return nil
+}
+
+func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id.ID)
+	if err != nil {
+		return err
+	}
+
+	var nodes node.Nodes
+	for _, n := range f.UI.Nodes {
+		if n.Group != node.TOTPGroup {
+			nodes = append(nodes, n)
+		}
+	}
+	f.UI.Nodes = nodes
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+
+	if s.HasSecondFactor(r.Context(), i) {
+		f.UI.Nodes.Append(NewUnlinkTOTPNode())
+		return nil
+	}
+
+	key, err := NewKey(r.Context(), accountName(i), s.d)
+	if err != nil {
+		return err
+	}
+
+	f.InternalContext, err = flow.SetInternalContext(f.InternalContext, internalContextKeyURL, key.URL())
+	if err != nil {
+		return err
+	}
+
+	qr, err := NewTOTPImageQRNode(key)
+	if err != nil {
+		return err
+	}
+
+	f.UI.Nodes.Append(qr)
+	f.UI.Nodes.Append(NewTOTPSecretNode(key))
+	f.UI.Nodes.Append(NewVerifyTOTPNode())
+	f.UI.Nodes.Append(node.NewInputField("method", s.ID(), node.TOTPGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSave()))
+
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithTotpMethodBody, err error) error {
+	// Do not pause flow if the flow type is an API flow as we can't save cookies in those flows.
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) && ctxUpdate.Flow != nil && ctxUpdate.Flow.Type == flow.TypeBrowser {
+		if err := s.d.ContinuityManager().Pause(r.Context(), w, r, settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.GetSessionIdentity())...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
@@ -0,0 +1,122 @@
+package totp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	stdtotp "github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/settings"
+	"kratos/text"
+)
+
+func TestCompleteSettings(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeTOTP.String(), true)
+	conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewLoginUIWith401Response(t, conf)
+
+	var initFlow = func(t *testing.T, hc *http.Client) string {
+		res, err := hc.Get(publicTS.URL + settings.RouteInitAPIFlow)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body := string(ioutilx.MustReadAll(res.Body))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		return body
+	}
+
+	var submit = func(t *testing.T, hc *http.Client, flow string, payload interface{}) (string, *http.Response) {
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		res, err := hc.Do(testhelpers.NewRequest(t, true, "POST", gjson.Get(flow, "ui.action").String(), strings.NewReader(string(raw))))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return string(ioutilx.MustReadAll(res.Body)), res
+	}
+
+	var getIdentity = func(t *testing.T, id *identity.Identity) *identity.Identity {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id.ID)
+		require.NoError(t, err)
+		return actual
+	}
+
+	i, _, _ := createIdentityWithTOTP(t, reg, false)
+	hc := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i)
+
+	t.Run("case=enroll totp", func(t *testing.T) {
+		flow := initFlow(t, hc)
+
+		assert.True(t, strings.HasPrefix(gjson.Get(flow, "ui.nodes.#(attributes.id==totp_qr).attributes.src").String(), "data:image/png;base64,"), flow)
+		secret := gjson.Get(flow, "ui.nodes.#(attributes.id==totp_secret_key).attributes.text.context.secret").String()
+		require.NotEmpty(t, secret, flow)
+		assert.EqualValues(t, text.InfoSelfServiceSettingsTOTPSecret, gjson.Get(flow, "ui.nodes.#(attributes.id==totp_secret_key).attributes.text.id").Int(), flow)
+		assert.False(t, gjson.Get(flow, "ui.nodes.#(attributes.name==totp_unlink)").Exists(), flow)
+
+		t.Run("case=rejects an invalid code", func(t *testing.T) {
+			body, res := submit(t, hc, flow, map[string]string{"method": "totp", "totp_code": "111111"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.EqualValues(t, text.ErrorValidationTOTPVerifierWrong, gjson.Get(body, "ui.nodes.#(attributes.name==totp_code).messages.0.id").Int(), body)
+			assert.NotContains(t, getIdentity(t, i).Credentials, identity.CredentialsTypeTOTP)
+		})
+
+		t.Run("case=saves the credentials with a valid code", func(t *testing.T) {
+			code, err := stdtotp.GenerateCode(secret, time.Now())
+			require.NoError(t, err)
+
+			body, res := submit(t, hc, flow, map[string]string{"method": "totp", "totp_code": code})
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.EqualValues(t, settings.StateSuccess, gjson.Get(body, "flow.state").String(), body)
+			assert.True(t, gjson.Get(body, "flow.ui.nodes.#(attributes.name==totp_unlink)").Exists(), body)
+			assert.False(t, gjson.Get(body, "flow.ui.nodes.#(attributes.id==totp_qr)").Exists(), body)
+
+			actual := getIdentity(t, i)
+			require.Contains(t, actual.Credentials, identity.CredentialsTypeTOTP)
+			assert.Contains(t, gjson.GetBytes(actual.Credentials[identity.CredentialsTypeTOTP].Config, "totp_url").String(), "secret="+secret)
+		})
+	})
+
+	t.Run("case=unlink totp", func(t *testing.T) {
+		flow := initFlow(t, hc)
+		require.True(t, gjson.Get(flow, "ui.nodes.#(attributes.name==totp_unlink)").Exists(), flow)
+
+		body, res := submit(t, hc, flow, map[string]interface{}{"totp_unlink": true})
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.EqualValues(t, settings.StateSuccess, gjson.Get(body, "flow.state").String(), body)
+		assert.True(t, gjson.Get(body, "flow.ui.nodes.#(attributes.id==totp_qr)").Exists(), body)
+		assert.NotContains(t, getIdentity(t, i).Credentials, identity.CredentialsTypeTOTP)
+	})
+
+	t.Run("case=requires a privileged session", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+		})
+
+		flow := initFlow(t, hc)
+		time.Sleep(time.Millisecond)
+
+		body, res := submit(t, hc, flow, map[string]string{"method": "totp", "totp_code": "111111"})
+		assert.EqualValues(t, http.StatusForbidden, res.StatusCode, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "re-authenticate", body)
+	})
+}
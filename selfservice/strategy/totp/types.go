@@ -0,0 +1,11 @@
+package totp
+
+// CredentialsConfig is the struct that is being used as part of the identity credentials.
+type CredentialsConfig struct {
+	// TOTPURL is the key URL (e.g. `otpauth://totp/...`) which contains the shared secret.
+	TOTPURL string `json:"totp_url"`
+
+	// LastUsedStep is the time step of the last code which was used to sign in. Codes of this and earlier
+	// time steps are rejected so that a code can not be used twice.
+	LastUsedStep uint64 `json:"last_used_step,omitempty"`
+}
@@ -0,0 +1,156 @@
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	testRPID     = "localhost"
+	testRPOrigin = "http://localhost:4455"
+)
+
+// authenticator is a software security key which answers the WebAuthn ceremonies found in a flow.
+type authenticator struct {
+	id      []byte
+	key     *ecdsa.PrivateKey
+	counter uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	require.NoError(t, err)
+
+	return &authenticator{id: id, key: key}
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decode accepts both encodings as the WebAuthn options encode binary values using standard base64.
+func decode(t *testing.T, s string) []byte {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		b, err = base64.StdEncoding.DecodeString(s)
+	}
+	require.NoError(t, err)
+	return b
+}
+
+// options extracts the WebAuthn options from the onclick handler of the given trigger node.
+func options(t *testing.T, flow, trigger string) string {
+	onclick := gjson.Get(flow, "ui.nodes.#(attributes.name=="+trigger+").attributes.onclick").String()
+	require.NotEmpty(t, onclick, flow)
+
+	start, end := strings.Index(onclick, "("), strings.LastIndex(onclick, ")")
+	require.True(t, start > 0 && end > start, onclick)
+	return onclick[start+1 : end]
+}
+
+func (a *authenticator) clientData(t *testing.T, ceremony, challenge string) []byte {
+	raw, err := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    testRPOrigin,
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func (a *authenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	a.counter++
+
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = append(data, make([]byte, 4)...)
+	binary.BigEndian.PutUint32(data[33:], a.counter)
+	return append(data, attested...)
+}
+
+// publicKey returns the COSE encoded public key of the authenticator.
+func (a *authenticator) publicKey(t *testing.T) []byte {
+	publicKey, err := cbor.Marshal(map[int]interface{}{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	require.NoError(t, err)
+	return publicKey
+}
+
+// register answers the registration challenge found in the given settings flow.
+func (a *authenticator) register(t *testing.T, flow string) string {
+	challenge := encode(decode(t, gjson.Get(options(t, flow, "webauthn_register_trigger"), "publicKey.challenge").String()))
+	require.NotEmpty(t, challenge, flow)
+
+	publicKey := a.publicKey(t)
+	attested := make([]byte, 16) // AAGUID
+	attested = append(attested, byte(len(a.id)>>8), byte(len(a.id)))
+	attested = append(attested, a.id...)
+	attested = append(attested, publicKey...)
+
+	attestation, err := cbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(0x41, attested),
+	})
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"id":    encode(a.id),
+		"rawId": encode(a.id),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    encode(a.clientData(t, "webauthn.create", challenge)),
+			"attestationObject": encode(attestation),
+		},
+	})
+	require.NoError(t, err)
+	return string(raw)
+}
+
+// login answers the login challenge found in the given login flow.
+func (a *authenticator) login(t *testing.T, flow string) string {
+	challenge := encode(decode(t, gjson.Get(options(t, flow, "webauthn_login_trigger"), "publicKey.challenge").String()))
+	require.NotEmpty(t, challenge, flow)
+
+	authData := a.authData(0x01, nil)
+	clientData := a.clientData(t, "webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"id":    encode(a.id),
+		"rawId": encode(a.id),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    encode(clientData),
+			"authenticatorData": encode(authData),
+			"signature":         encode(signature),
+		},
+	})
+	require.NoError(t, err)
+	return string(raw)
+}
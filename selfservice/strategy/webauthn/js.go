@@ -0,0 +1,37 @@
+package webauthn
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	// embed is needed for the go:embed directive
+	_ "embed"
+
+	"github.com/julienschmidt/httprouter"
+
+	"kratos/x"
+)
+
+// ScriptURL is the path of the script which performs the WebAuthn ceremonies in the browser.
+const ScriptURL = "/.well-known/ory/webauthn.js"
+
+//go:embed js/webauthn.js
+var script []byte
+
+var scriptIntegrity = func() string {
+	sum := sha512.Sum384(script)
+	return fmt.Sprintf("sha384-%s", base64.StdEncoding.EncodeToString(sum[:]))
+}()
+
+func (s *Strategy) registerScriptRoute(r *x.RouterPublic) {
+	if handle, _, _ := r.Lookup("GET", ScriptURL); handle == nil {
+		r.GET(ScriptURL, s.serveScript)
+	}
+}
+
+func (s *Strategy) serveScript(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Set("Content-Type", "text/javascript; charset=UTF-8")
+	_, _ = w.Write(script)
+}
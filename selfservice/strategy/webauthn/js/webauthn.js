@@ -0,0 +1,94 @@
+;(function () {
+  if (!window) {
+    return
+  }
+
+  if (!window.PublicKeyCredential) {
+    console.log('This browser does not support WebAuthn!')
+    return
+  }
+
+  function __oryWebAuthnBufferDecode(value) {
+    value = value.replace(/-/g, '+').replace(/_/g, '/')
+    while (value.length % 4) {
+      value += '='
+    }
+    return Uint8Array.from(atob(value), function (c) {
+      return c.charCodeAt(0)
+    })
+  }
+
+  function __oryWebAuthnBufferEncode(value) {
+    return btoa(String.fromCharCode.apply(null, new Uint8Array(value)))
+      .replace(/\+/g, '-')
+      .replace(/\//g, '_')
+      .replace(/=/g, '')
+  }
+
+  function __oryWebAuthnSubmit(name, value) {
+    var input = document.querySelector('*[name="' + name + '"]')
+    var form = input.closest('form')
+
+    // Submitting the form programmatically does not include the value of any submit button.
+    var method = document.createElement('input')
+    method.type = 'hidden'
+    method.name = 'method'
+    method.value = 'webauthn'
+    form.appendChild(method)
+
+    input.value = JSON.stringify(value)
+    form.submit()
+  }
+
+  function __oryWebAuthnLogin(opt) {
+    opt.publicKey.challenge = __oryWebAuthnBufferDecode(opt.publicKey.challenge)
+    opt.publicKey.allowCredentials = (opt.publicKey.allowCredentials || []).map(function (value) {
+      return Object.assign({}, value, {
+        id: __oryWebAuthnBufferDecode(value.id)
+      })
+    })
+
+    navigator.credentials.get(opt).then(function (credential) {
+      __oryWebAuthnSubmit('webauthn_login', {
+        id: credential.id,
+        rawId: __oryWebAuthnBufferEncode(credential.rawId),
+        type: credential.type,
+        response: {
+          authenticatorData: __oryWebAuthnBufferEncode(credential.response.authenticatorData),
+          clientDataJSON: __oryWebAuthnBufferEncode(credential.response.clientDataJSON),
+          signature: __oryWebAuthnBufferEncode(credential.response.signature),
+          userHandle: __oryWebAuthnBufferEncode(credential.response.userHandle || new ArrayBuffer(0))
+        }
+      })
+    }).catch(function (err) {
+      alert(err)
+    })
+  }
+
+  function __oryWebAuthnRegistration(opt) {
+    opt.publicKey.user.id = __oryWebAuthnBufferDecode(opt.publicKey.user.id)
+    opt.publicKey.challenge = __oryWebAuthnBufferDecode(opt.publicKey.challenge)
+    opt.publicKey.excludeCredentials = (opt.publicKey.excludeCredentials || []).map(function (value) {
+      return Object.assign({}, value, {
+        id: __oryWebAuthnBufferDecode(value.id)
+      })
+    })
+
+    navigator.credentials.create(opt).then(function (credential) {
+      __oryWebAuthnSubmit('webauthn_register', {
+        id: credential.id,
+        rawId: __oryWebAuthnBufferEncode(credential.rawId),
+        type: credential.type,
+        response: {
+          attestationObject: __oryWebAuthnBufferEncode(credential.response.attestationObject),
+          clientDataJSON: __oryWebAuthnBufferEncode(credential.response.clientDataJSON)
+        }
+      })
+    }).catch(function (err) {
+      alert(err)
+    })
+  }
+
+  window.__oryWebAuthnLogin = __oryWebAuthnLogin
+  window.__oryWebAuthnRegistration = __oryWebAuthnRegistration
+})()
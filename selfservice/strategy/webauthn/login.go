@@ -0,0 +1,306 @@
+package webauthn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/login"
+	"kratos/text"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+// internalContextKeySessionData is the flow's internal context key holding the WebAuthn ceremony's session data.
+const internalContextKeySessionData = "webauthn_session_data"
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+	s.registerScriptRoute(r)
+}
+
+// PopulateLoginMethod only adds nodes if security keys may be used for passwordless login. Otherwise,
+// they are used as a second factor.
+func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
+	if !s.d.Config(r.Context()).WebAuthnForPasswordless() {
+		return nil
+	}
+
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.SetNode(node.NewInputField("identifier", nil, node.WebAuthnGroup, node.InputAttributeTypeText, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeLabelID()))
+	sr.UI.GetNodes().Append(node.NewInputField("method", s.ID(), node.WebAuthnGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoLoginWebAuthn()))
+
+	return nil
+}
+
+func (s *Strategy) HasSecondFactor(ctx context.Context, i *identity.Identity) bool {
+	if s.d.Config(ctx).WebAuthnForPasswordless() {
+		return false
+	}
+
+	var o CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &o); err != nil {
+		return false
+	}
+
+	return len(o.Credentials) > 0
+}
+
+func (s *Strategy) PopulateSecondFactorMethod(r *http.Request, i *identity.Identity, sr *login.Flow) error {
+	var o CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &o); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("The WebAuthn credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	return s.populateLoginChallenge(r, i, o.Credentials, sr)
+}
+
+func (s *Strategy) populateLoginChallenge(r *http.Request, i *identity.Identity, credentials Credentials, sr *login.Flow) error {
+	web, err := webauthn.New(s.d.Config(r.Context()).WebAuthnConfig())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to initiate WebAuthn.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	options, sessionData, err := web.BeginLogin(newUser(i, credentials))
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to initiate WebAuthn login.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	injectOptions, err := json.Marshal(options)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode WebAuthn options to JSON: %s", err))
+	}
+
+	sr.InternalContext, err = flow.SetInternalContext(sr.InternalContext, internalContextKeySessionData, sessionData)
+	if err != nil {
+		return err
+	}
+
+	sr.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	sr.UI.Nodes.Upsert(NewWebAuthnScript(s.d.Config(r.Context()).SelfPublicURL(r)))
+	sr.UI.Nodes.Upsert(NewWebAuthnLoginInput())
+	sr.UI.Nodes.Upsert(NewWebAuthnLoginTrigger(string(injectOptions)))
+
+	return nil
+}
+
+func (s *Strategy) handleLoginError(r *http.Request, f *login.Flow, err error) error {
+	if f != nil {
+		f.UI.Nodes.ResetNodes("webauthn_login")
+		if f.Type == flow.TypeBrowser {
+			f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		}
+	}
+
+	return err
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceLoginFlowWithWebAuthnMethod
+type submitSelfServiceLoginFlowWithWebAuthnMethodParameters struct {
+	// The Flow ID
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	Body submitSelfServiceLoginFlowWithWebAuthnMethod
+}
+
+// swagger:model submitSelfServiceLoginFlowWithWebAuthnMethod
+type submitSelfServiceLoginFlowWithWebAuthnMethod struct {
+	// Method should be set to "webauthn" when logging in using the WebAuthn strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `json:"csrf_token"`
+
+	// Identifier is the identifier of the identity which signs in without a password. It is only
+	// used if passwordless login with WebAuthn is enabled.
+	Identifier string `json:"identifier"`
+
+	// Login is the JSON encoded response of the security key to the WebAuthn challenge.
+	Login string `json:"webauthn_login"`
+}
+
+func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow) (i *identity.Identity, err error) {
+	if err := flow.MethodEnabledAndAllowedFromRequest(r, s.ID().String(), s.d); err != nil {
+		return nil, err
+	}
+
+	passwordless := s.d.Config(r.Context()).WebAuthnForPasswordless()
+	if !passwordless && f.PendingSecondFactor() == uuid.Nil {
+		// Security keys are only used as a second factor and the first factor has not been completed yet.
+		return nil, errors.WithStack(flow.ErrStrategyNotResponsible)
+	}
+
+	var p submitSelfServiceLoginFlowWithWebAuthnMethod
+	if err := s.hd.Decode(r, &p,
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if err := flow.EnsureCSRF(r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	if passwordless && f.PendingSecondFactor() == uuid.Nil && len(p.Login) == 0 {
+		return nil, s.initPasswordless(w, r, f, p.Identifier)
+	}
+
+	if len(p.Login) == 0 {
+		return nil, s.handleLoginError(r, f, schema.NewRequiredError("#/webauthn_login", "webauthn_login"))
+	}
+
+	return s.verify(r, f, p.Login)
+}
+
+// initPasswordless looks up the identity by its identifier and responds with the WebAuthn challenge.
+func (s *Strategy) initPasswordless(w http.ResponseWriter, r *http.Request, f *login.Flow, identifier string) error {
+	if len(identifier) == 0 {
+		return s.handleLoginError(r, f, schema.NewRequiredError("#/identifier", "identifier"))
+	}
+
+	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), identifier)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return s.handleLoginError(r, f, errors.WithStack(schema.NewInvalidCredentialsError()))
+	} else if err != nil {
+		return s.handleLoginError(r, f, err)
+	}
+
+	var o CredentialsConfig
+	if err := json.Unmarshal(c.Config, &o); err != nil || len(o.Credentials) == 0 {
+		// The identity has not registered any security keys yet.
+		return s.handleLoginError(r, f, errors.WithStack(schema.NewInvalidCredentialsError()))
+	}
+
+	var nodes node.Nodes
+	for _, n := range f.UI.Nodes {
+		if n.Group != node.WebAuthnGroup {
+			nodes = append(nodes, n)
+		}
+	}
+	f.UI.Nodes = nodes
+	f.UI.Nodes.Append(node.NewInputField("identifier", identifier, node.WebAuthnGroup, node.InputAttributeTypeHidden))
+
+	if err := s.populateLoginChallenge(r, i, o.Credentials, f); err != nil {
+		return s.handleLoginError(r, f, err)
+	}
+
+	f.Active = s.ID()
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
+		return s.handleLoginError(r, f, err)
+	}
+
+	if f.Type == flow.TypeAPI {
+		s.d.Writer().Write(w, r, f)
+	} else {
+		http.Redirect(w, r, f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusFound)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+// verify validates the security key's response against the challenge stored in the flow.
+func (s *Strategy) verify(r *http.Request, f *login.Flow, response string) (*identity.Identity, error) {
+	raw := gjson.GetBytes(f.InternalContext, internalContextKeySessionData).Raw
+	if len(raw) == 0 {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReason("Could not find the WebAuthn challenge in the login flow. Please restart the flow.")))
+	}
+
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal([]byte(raw), &sessionData); err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decode the WebAuthn challenge stored in the login flow.").WithDebug(err.Error()).WithWrap(err)))
+	}
+
+	id, err := uuid.FromBytes(sessionData.UserID)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decode the WebAuthn challenge stored in the login flow.").WithDebug(err.Error()).WithWrap(err)))
+	}
+
+	if pending := f.PendingSecondFactor(); pending != uuid.Nil && pending != id {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrBadRequest.WithReason("The WebAuthn challenge does not belong to the identity which completed the first factor. Please restart the flow.")))
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	var o CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &o); err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewInvalidCredentialsError()))
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(response))
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewWebAuthnVerifierWrongError("#/webauthn_login")))
+	}
+
+	web, err := webauthn.New(s.d.Config(r.Context()).WebAuthnConfig())
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to initiate WebAuthn.").WithDebug(err.Error()).WithWrap(err)))
+	}
+
+	credential, err := web.ValidateLogin(newUser(i, o.Credentials), sessionData, parsed)
+	if err != nil {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewWebAuthnVerifierWrongError("#/webauthn_login")))
+	}
+
+	if err := s.updateAuthenticator(r.Context(), i.ID, credential); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
+	// The signature counter did not increase, which means that at least two copies of the key exist.
+	if credential.Authenticator.CloneWarning {
+		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewWebAuthnClonedError("#/webauthn_login")))
+	}
+
+	return i.CopyWithoutCredentials(), nil
+}
+
+// updateAuthenticator stores the signature counter of the security key used to sign in. Once a clone
+// warning was raised, it is kept so that the key can not be used anymore.
+func (s *Strategy) updateAuthenticator(ctx context.Context, id uuid.UUID, credential *webauthn.Credential) error {
+	return s.d.PrivilegedIdentityPool().UpdateCredentialsConfig(ctx, id, s.ID(), func(config sqlxx.JSONRawMessage) (sqlxx.JSONRawMessage, error) {
+		var o CredentialsConfig
+		if err := json.Unmarshal(config, &o); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for k := range o.Credentials {
+			if !bytes.Equal(o.Credentials[k].ID, credential.ID) {
+				continue
+			}
+
+			o.Credentials[k].Authenticator.SignCount = credential.Authenticator.SignCount
+			o.Credentials[k].Authenticator.CloneWarning = o.Credentials[k].Authenticator.CloneWarning || credential.Authenticator.CloneWarning
+		}
+
+		co, err := json.Marshal(&o)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return co, nil
+	})
+}
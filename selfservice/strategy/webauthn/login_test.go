@@ -0,0 +1,235 @@
+package webauthn_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+
+	"kratos/driver"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/strategy/webauthn"
+	"kratos/text"
+	"kratos/x"
+)
+
+func enableWebAuthn(t *testing.T, conf *config.Config, passwordless bool) {
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeWebAuthn.String(), true)
+	conf.MustSet(config.ViperKeyWebAuthnRPID, testRPID)
+	conf.MustSet(config.ViperKeyWebAuthnRPOrigin, testRPOrigin)
+	conf.MustSet(config.ViperKeyWebAuthnRPDisplayName, "Ory Kratos")
+	conf.MustSet(config.ViperKeyWebAuthnPasswordless, passwordless)
+}
+
+func createIdentity(t *testing.T, reg driver.Registry, key *authenticator) (*identity.Identity, string) {
+	email := x.NewUUID().String() + "@ory.sh"
+	password := x.NewUUID().String()
+
+	hpw, err := reg.Hasher().Generate(context.Background(), []byte(password))
+	require.NoError(t, err)
+
+	i := &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"email":"%s"}`, email)),
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{email},
+				Config:      []byte(fmt.Sprintf(`{"hashed_password":"%s"}`, hpw)),
+			},
+		},
+	}
+
+	if key != nil {
+		config, err := json.Marshal(&webauthn.CredentialsConfig{Credentials: webauthn.Credentials{{
+			ID:              key.id,
+			PublicKey:       key.publicKey(t),
+			AttestationType: "none",
+			Authenticator:   webauthn.Authenticator{AAGUID: make([]byte, 16)},
+			DisplayName:     "my key",
+		}}})
+		require.NoError(t, err)
+
+		i.Credentials[identity.CredentialsTypeWebAuthn] = identity.Credentials{
+			Type:        identity.CredentialsTypeWebAuthn,
+			Identifiers: []string{email},
+			Config:      config,
+		}
+	}
+
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+	return i, password
+}
+
+func storedKey(t *testing.T, reg driver.Registry, i *identity.Identity) webauthn.Credential {
+	actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+	require.NoError(t, err)
+
+	var o webauthn.CredentialsConfig
+	_, err = actual.ParseCredentials(identity.CredentialsTypeWebAuthn, &o)
+	require.NoError(t, err)
+	require.Len(t, o.Credentials, 1)
+	return o.Credentials[0]
+}
+
+func TestCompleteLogin(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	enableWebAuthn(t, conf, false)
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	var submit = func(t *testing.T, action string, payload interface{}) (string, *http.Response) {
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		res, err := http.DefaultClient.Do(testhelpers.NewRequest(t, true, "POST", action, bytes.NewReader(raw)))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return string(ioutilx.MustReadAll(res.Body)), res
+	}
+
+	var loginWithPassword = func(t *testing.T, email, password string) (string, *http.Response, string) {
+		f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+		body, res := submit(t, f.Ui.Action, map[string]string{
+			"method":              "password",
+			"password_identifier": email,
+			"password":            password,
+		})
+		return body, res, f.Ui.Action
+	}
+
+	t.Run("case=second factor", func(t *testing.T) {
+		t.Run("case=identity without security key is logged in after the first factor", func(t *testing.T) {
+			i, password := createIdentity(t, reg, nil)
+
+			body, res, _ := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+		})
+
+		t.Run("case=identity with security key has to complete the second factor", func(t *testing.T) {
+			key := newAuthenticator(t)
+			i, password := createIdentity(t, reg, key)
+
+			body, res, action := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+			require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+			assert.EqualValues(t, text.InfoSelfServiceMFA, gjson.Get(body, "ui.messages.0.id").Int(), body)
+			assert.True(t, gjson.Get(body, "ui.nodes.#(attributes.id==webauthn_script)").Exists(), body)
+			assert.EqualValues(t, key.id, decode(t, gjson.Get(options(t, body, "webauthn_login_trigger"), "publicKey.allowCredentials.0.id").String()), body)
+
+			t.Run("case=rejects an invalid response", func(t *testing.T) {
+				body, res := submit(t, action, map[string]string{"method": "webauthn", "webauthn_login": "{}"})
+				assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+				assert.EqualValues(t, text.ErrorValidationWebAuthnVerifierWrong, gjson.Get(body, "ui.nodes.#(attributes.name==webauthn_login).messages.0.id").Int(), body)
+			})
+
+			t.Run("case=rejects a response signed by another security key", func(t *testing.T) {
+				other := newAuthenticator(t)
+				other.id = key.id
+
+				body, res := submit(t, action, map[string]string{"method": "webauthn", "webauthn_login": other.login(t, body)})
+				assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+				assert.EqualValues(t, text.ErrorValidationWebAuthnVerifierWrong, gjson.Get(body, "ui.nodes.#(attributes.name==webauthn_login).messages.0.id").Int(), body)
+			})
+
+			t.Run("case=issues a session with a valid response", func(t *testing.T) {
+				body, res := submit(t, action, map[string]string{"method": "webauthn", "webauthn_login": key.login(t, body)})
+				assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+				assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+				assert.Equal(t, i.ID.String(), gjson.Get(body, "session.identity.id").String(), body)
+				assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "session.aal").String(), body)
+				assert.EqualValues(t, identity.CredentialsTypeWebAuthn, gjson.Get(body, "session.authentication_methods.1.method").String(), body)
+
+				stored := storedKey(t, reg, i)
+				assert.Equal(t, key.counter, stored.Authenticator.SignCount)
+				assert.False(t, stored.Authenticator.CloneWarning)
+			})
+
+			t.Run("case=rejects a cloned security key", func(t *testing.T) {
+				clone := *key
+				clone.counter = 0
+
+				body, res, action := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+				require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+
+				body, res = submit(t, action, map[string]string{"method": "webauthn", "webauthn_login": clone.login(t, body)})
+				assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+				assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+				assert.EqualValues(t, text.ErrorValidationWebAuthnCloned, gjson.Get(body, "ui.nodes.#(attributes.name==webauthn_login).messages.0.id").Int(), body)
+				assert.True(t, storedKey(t, reg, i).Authenticator.CloneWarning)
+
+				body, res, action = loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+				require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+
+				body, res = submit(t, action, map[string]string{"method": "webauthn", "webauthn_login": key.login(t, body)})
+				assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+				assert.EqualValues(t, text.ErrorValidationWebAuthnCloned, gjson.Get(body, "ui.nodes.#(attributes.name==webauthn_login).messages.0.id").Int(), body, "the original key can not be used either once a clone was detected")
+			})
+		})
+
+		t.Run("case=security key can not be used as the first factor", func(t *testing.T) {
+			f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+			assert.False(t, gjson.Get(x.MustEncodeJSON(t, f), "ui.nodes.#(attributes.name==identifier)").Exists())
+
+			body, res := submit(t, f.Ui.Action, map[string]string{"method": "webauthn", "webauthn_login": "{}"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+			assert.EqualValues(t, text.ErrorValidationLoginNoStrategyFound, gjson.Get(body, "ui.messages.0.id").Int(), body)
+		})
+	})
+
+	t.Run("case=passwordless", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyWebAuthnPasswordless, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyWebAuthnPasswordless, false)
+		})
+
+		t.Run("case=identity with security key logs in without password", func(t *testing.T) {
+			key := newAuthenticator(t)
+			i, _ := createIdentity(t, reg, key)
+
+			f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+			body, res := submit(t, f.Ui.Action, map[string]string{"method": "webauthn", "identifier": i.Credentials[identity.CredentialsTypeWebAuthn].Identifiers[0]})
+			require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.Empty(t, gjson.Get(body, "session_token").String(), body)
+			assert.EqualValues(t, identity.CredentialsTypeWebAuthn, gjson.Get(body, "active").String(), body)
+
+			body, res = submit(t, f.Ui.Action, map[string]string{"method": "webauthn", "webauthn_login": key.login(t, body)})
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+			assert.Equal(t, i.ID.String(), gjson.Get(body, "session.identity.id").String(), body)
+		})
+
+		t.Run("case=identity without security key can not log in", func(t *testing.T) {
+			i, _ := createIdentity(t, reg, nil)
+
+			f := testhelpers.InitializeLoginFlowViaAPI(t, http.DefaultClient, publicTS, false)
+			body, res := submit(t, f.Ui.Action, map[string]string{"method": "webauthn", "identifier": i.Credentials[identity.CredentialsTypePassword].Identifiers[0]})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.EqualValues(t, text.ErrorValidationInvalidCredentials, gjson.Get(body, "ui.messages.0.id").Int(), body)
+		})
+
+		t.Run("case=password login is not followed by a second factor", func(t *testing.T) {
+			i, password := createIdentity(t, reg, newAuthenticator(t))
+
+			body, res, _ := loginWithPassword(t, i.Credentials[identity.CredentialsTypePassword].Identifiers[0], password)
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), body)
+		})
+	})
+}
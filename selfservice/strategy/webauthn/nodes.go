@@ -0,0 +1,46 @@
+package webauthn
+
+import (
+	"net/url"
+
+	"github.com/ory/x/urlx"
+
+	"kratos/text"
+	"kratos/ui/node"
+)
+
+func NewWebAuthnScript(base *url.URL) *node.Node {
+	return node.NewScriptField("webauthn_script", urlx.AppendPaths(base, ScriptURL).String(), node.WebAuthnGroup, scriptIntegrity)
+}
+
+func NewWebAuthnConnectionTrigger(options string) *node.Node {
+	return node.NewInputField("webauthn_register_trigger", "", node.WebAuthnGroup, node.InputAttributeTypeButton,
+		node.WithInputAttributes(func(a *node.InputAttributes) {
+			a.OnClick = "window.__oryWebAuthnRegistration(" + options + ")"
+		})).WithMetaLabel(text.NewInfoSelfServiceSettingsRegisterWebAuthn())
+}
+
+func NewWebAuthnConnectionInput() *node.Node {
+	return node.NewInputField("webauthn_register", "", node.WebAuthnGroup, node.InputAttributeTypeHidden)
+}
+
+func NewWebAuthnConnectionName() *node.Node {
+	return node.NewInputField("webauthn_register_displayname", "", node.WebAuthnGroup, node.InputAttributeTypeText).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsRegisterWebAuthnDisplayName())
+}
+
+func NewWebAuthnUnlink(c *Credential) *node.Node {
+	return node.NewInputField("webauthn_remove", c.Handle(), node.WebAuthnGroup, node.InputAttributeTypeSubmit).
+		WithMetaLabel(text.NewInfoSelfServiceSettingsRemoveWebAuthn(c.DisplayName, c.AddedAt))
+}
+
+func NewWebAuthnLoginTrigger(options string) *node.Node {
+	return node.NewInputField("webauthn_login_trigger", "", node.WebAuthnGroup, node.InputAttributeTypeButton,
+		node.WithInputAttributes(func(a *node.InputAttributes) {
+			a.OnClick = "window.__oryWebAuthnLogin(" + options + ")"
+		})).WithMetaLabel(text.NewInfoLoginWebAuthn())
+}
+
+func NewWebAuthnLoginInput() *node.Node {
+	return node.NewInputField("webauthn_login", "", node.WebAuthnGroup, node.InputAttributeTypeHidden)
+}
@@ -0,0 +1,342 @@
+package webauthn
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+
+	"kratos/identity"
+	"kratos/schema"
+	"kratos/selfservice/flow"
+	"kratos/selfservice/flow/settings"
+	"kratos/session"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+func (s *Strategy) RegisterSettingsRoutes(r *x.RouterPublic) {
+	s.registerScriptRoute(r)
+}
+
+func (s *Strategy) SettingsStrategyID() string {
+	return identity.CredentialsTypeWebAuthn.String()
+}
+
+// nolint:deadcode,unused
+// swagger:parameters submitSelfServiceSettingsFlowWithWebAuthnMethod
+type submitSelfServiceSettingsFlowWithWebAuthnMethod struct {
+	// in: body
+	Body submitSelfServiceSettingsFlowWithWebAuthnMethodBody
+
+	// Flow is flow ID.
+	//
+	// in: query
+	Flow string `json:"flow"`
+}
+
+// swagger:model submitSelfServiceSettingsFlowWithWebAuthnMethod
+type submitSelfServiceSettingsFlowWithWebAuthnMethodBody struct {
+	// Register is the JSON encoded response of the security key to the WebAuthn registration challenge.
+	//
+	// type: string
+	Register string `json:"webauthn_register"`
+
+	// RegisterDisplayName is the name of the security key which is being registered.
+	//
+	// type: string
+	RegisterDisplayName string `json:"webauthn_register_displayname"`
+
+	// Remove references the security key which should be removed.
+	//
+	// type: string
+	Remove string `json:"webauthn_remove"`
+
+	// CSRFToken is the anti-CSRF token
+	//
+	// type: string
+	CSRFToken string `json:"csrf_token"`
+
+	// Method
+	//
+	// Should be set to "webauthn" when trying to add, update, or remove a security key.
+	//
+	// type: string
+	Method string `json:"method"`
+
+	// Flow is flow ID.
+	//
+	// swagger:ignore
+	Flow string `json:"flow"`
+}
+
+func (p *submitSelfServiceSettingsFlowWithWebAuthnMethodBody) GetFlowID() uuid.UUID {
+	return x.ParseUUID(p.Flow)
+}
+
+func (p *submitSelfServiceSettingsFlowWithWebAuthnMethodBody) SetFlowID(rid uuid.UUID) {
+	p.Flow = rid.String()
+}
+
+func (s *Strategy) Settings(w http.ResponseWriter, r *http.Request, f *settings.Flow, ss *session.Session) (*settings.UpdateContext, error) {
+	var p submitSelfServiceSettingsFlowWithWebAuthnMethodBody
+	ctxUpdate, err := settings.PrepareUpdate(s.d, w, r, f, ss, settings.ContinuityKey(s.SettingsStrategyID()), &p)
+	if errors.Is(err, settings.ErrContinuePreviousAction) {
+		return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+	} else if err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if err := s.decodeSettingsFlow(r, &p); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	if len(p.Remove) > 0 {
+		// The remove node is a submit button and thus does not send the method.
+		p.Method = s.SettingsStrategyID()
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return ctxUpdate, s.handleSettingsError(w, r, ctxUpdate, &p, err)
+	}
+
+	// This does not come from the payload!
+	p.Flow = ctxUpdate.Flow.ID.String()
+	return ctxUpdate, s.continueSettingsFlow(w, r, ctxUpdate, &p)
+}
+
+func (s *Strategy) decodeSettingsFlow(r *http.Request, dest interface{}) error {
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(settingsSchema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return decoderx.NewHTTP().Decode(r, dest, compiler,
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	)
+}
+
+func (s *Strategy) continueSettingsFlow(
+	w http.ResponseWriter, r *http.Request,
+	ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithWebAuthnMethodBody,
+) error {
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.SettingsStrategyID(), p.Method, s.d); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if err := flow.EnsureCSRF(r, ctxUpdate.Flow.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge()).Before(time.Now()) {
+		return s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(settings.NewFlowNeedsReAuth()))
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
+	if err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	if len(p.Remove) > 0 {
+		if err := s.removeCredential(i, p.Remove); err != nil {
+			return s.handleSettingsError(w, r, ctxUpdate, p, err)
+		}
+	} else if len(p.Register) > 0 {
+		if err := s.addCredential(r, ctxUpdate, p, i); err != nil {
+			return s.handleSettingsError(w, r, ctxUpdate, p, err)
+		}
+	} else {
+		return s.handleSettingsError(w, r, ctxUpdate, p, schema.NewRequiredError("#/webauthn_register", "webauthn_register"))
+	}
+
+	if err := s.d.SettingsHookExecutor().PostSettingsHook(w, r, s.SettingsStrategyID(), ctxUpdate, i,
+		settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
+			return s.PopulateSettingsMethod(r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
+		})); err != nil {
+		return s.handleSettingsError(w, r, ctxUpdate, p, err)
+	}
+
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+func (s *Strategy) addCredential(r *http.Request, ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithWebAuthnMethodBody, i *identity.Identity) error {
+	raw := gjson.GetBytes(ctxUpdate.Flow.InternalContext, internalContextKeySessionData).Raw
+	if len(raw) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Could not find the WebAuthn challenge in the settings flow. Please restart the flow."))
+	}
+
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal([]byte(raw), &sessionData); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decode the WebAuthn challenge stored in the settings flow.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(strings.NewReader(p.Register))
+	if err != nil {
+		return errors.WithStack(schema.NewWebAuthnVerifierWrongError("#/webauthn_register"))
+	}
+
+	web, err := webauthn.New(s.d.Config(r.Context()).WebAuthnConfig())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to initiate WebAuthn.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	var o CredentialsConfig
+	c, ok := i.GetCredentials(s.ID())
+	if ok && len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &o); err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReason("The WebAuthn credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+		}
+	}
+
+	credential, err := web.CreateCredential(newUser(i, o.Credentials), sessionData, parsed)
+	if err != nil {
+		return errors.WithStack(schema.NewWebAuthnVerifierWrongError("#/webauthn_register"))
+	}
+
+	wc := CredentialFromWebAuthn(credential)
+	wc.DisplayName = p.RegisterDisplayName
+	wc.AddedAt = time.Now().UTC().Round(time.Second)
+	o.Credentials = append(o.Credentials, *wc)
+
+	co, err := json.Marshal(&o)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode webauthn options to JSON: %s", err))
+	}
+
+	identifiers := []string{i.ID.String()}
+	if ok && len(c.Identifiers) > 0 {
+		identifiers = c.Identifiers
+	}
+
+	i.SetCredentials(s.ID(), identity.Credentials{
+		Type:        s.ID(),
+		Identifiers: identifiers,
+		Config:      co,
+	})
+	return nil
+}
+
+func (s *Strategy) removeCredential(i *identity.Identity, handle string) error {
+	c, ok := i.GetCredentials(s.ID())
+	if !ok || len(c.Config) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The security key could not be found."))
+	}
+
+	var o CredentialsConfig
+	if err := json.Unmarshal(c.Config, &o); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("The WebAuthn credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	var remaining Credentials
+	for k := range o.Credentials {
+		if o.Credentials[k].Handle() != handle {
+			remaining = append(remaining, o.Credentials[k])
+		}
+	}
+
+	if len(remaining) == len(o.Credentials) {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The security key could not be found."))
+	}
+
+	if len(remaining) == 0 {
+		delete(i.Credentials, s.ID())
+		return nil
+	}
+
+	o.Credentials = remaining
+	co, err := json.Marshal(&o)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode webauthn options to JSON: %s", err))
+	}
+
+	c.Config = co
+	i.SetCredentials(s.ID(), *c)
+	return nil
+}
+
+func (s *Strategy) PopulateSettingsMethod(r *http.Request, id *identity.Identity, f *settings.Flow) error {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id.ID)
+	if err != nil {
+		return err
+	}
+
+	var nodes node.Nodes
+	for _, n := range f.UI.Nodes {
+		if n.Group != node.WebAuthnGroup {
+			nodes = append(nodes, n)
+		}
+	}
+	f.UI.Nodes = nodes
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+
+	var o CredentialsConfig
+	if c, ok := i.GetCredentials(s.ID()); ok && len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &o); err != nil {
+			return errors.WithStack(herodot.ErrInternalServerError.WithReason("The WebAuthn credentials could not be decoded properly").WithDebug(err.Error()).WithWrap(err))
+		}
+	}
+
+	exclude := make([]protocol.CredentialDescriptor, len(o.Credentials))
+	for k := range o.Credentials {
+		f.UI.Nodes.Append(NewWebAuthnUnlink(&o.Credentials[k]))
+		exclude[k] = protocol.CredentialDescriptor{
+			Type:         protocol.PublicKeyCredentialType,
+			CredentialID: o.Credentials[k].ID,
+		}
+	}
+
+	web, err := webauthn.New(s.d.Config(r.Context()).WebAuthnConfig())
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to initiate WebAuthn.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	options, sessionData, err := web.BeginRegistration(newUser(i, o.Credentials), webauthn.WithExclusions(exclude))
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to initiate WebAuthn registration.").WithDebug(err.Error()).WithWrap(err))
+	}
+
+	injectOptions, err := json.Marshal(options)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode WebAuthn options to JSON: %s", err))
+	}
+
+	f.InternalContext, err = flow.SetInternalContext(f.InternalContext, internalContextKeySessionData, sessionData)
+	if err != nil {
+		return err
+	}
+
+	f.UI.Nodes.Append(NewWebAuthnScript(s.d.Config(r.Context()).SelfPublicURL(r)))
+	f.UI.Nodes.Append(NewWebAuthnConnectionName())
+	f.UI.Nodes.Append(NewWebAuthnConnectionInput())
+	f.UI.Nodes.Append(NewWebAuthnConnectionTrigger(string(injectOptions)))
+
+	return nil
+}
+
+func (s *Strategy) handleSettingsError(w http.ResponseWriter, r *http.Request, ctxUpdate *settings.UpdateContext, p *submitSelfServiceSettingsFlowWithWebAuthnMethodBody, err error) error {
+	// Do not pause flow if the flow type is an API flow as we can't save cookies in those flows.
+	if e := new(settings.FlowNeedsReAuth); errors.As(err, &e) && ctxUpdate.Flow != nil && ctxUpdate.Flow.Type == flow.TypeBrowser {
+		if err := s.d.ContinuityManager().Pause(r.Context(), w, r, settings.ContinuityKey(s.SettingsStrategyID()), settings.ContinuityOptions(p, ctxUpdate.GetSessionIdentity())...); err != nil {
+			return err
+		}
+	}
+
+	if ctxUpdate.Flow != nil {
+		ctxUpdate.Flow.UI.ResetMessages()
+		ctxUpdate.Flow.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	}
+
+	return err
+}
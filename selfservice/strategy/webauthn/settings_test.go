@@ -0,0 +1,136 @@
+package webauthn_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/ioutilx"
+
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/internal/testhelpers"
+	"kratos/selfservice/flow/settings"
+	"kratos/text"
+)
+
+func TestCompleteSettings(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypePassword.String(), true)
+	enableWebAuthn(t, conf, false)
+	conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewLoginUIWith401Response(t, conf)
+
+	var initFlow = func(t *testing.T, hc *http.Client) string {
+		res, err := hc.Get(publicTS.URL + settings.RouteInitAPIFlow)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body := string(ioutilx.MustReadAll(res.Body))
+		require.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		return body
+	}
+
+	var submit = func(t *testing.T, hc *http.Client, flow string, payload interface{}) (string, *http.Response) {
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		res, err := hc.Do(testhelpers.NewRequest(t, true, "POST", gjson.Get(flow, "ui.action").String(), strings.NewReader(string(raw))))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return string(ioutilx.MustReadAll(res.Body)), res
+	}
+
+	var getIdentity = func(t *testing.T, id *identity.Identity) *identity.Identity {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id.ID)
+		require.NoError(t, err)
+		return actual
+	}
+
+	i, _ := createIdentity(t, reg, nil)
+	hc := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i)
+	key := newAuthenticator(t)
+
+	t.Run("case=register a security key", func(t *testing.T) {
+		flow := initFlow(t, hc)
+
+		assert.True(t, gjson.Get(flow, "ui.nodes.#(attributes.id==webauthn_script)").Exists(), flow)
+		assert.True(t, gjson.Get(flow, "ui.nodes.#(attributes.name==webauthn_register)").Exists(), flow)
+		assert.False(t, gjson.Get(flow, "ui.nodes.#(attributes.name==webauthn_remove)").Exists(), flow)
+		assert.EqualValues(t, i.ID.Bytes(), decode(t, gjson.Get(options(t, flow, "webauthn_register_trigger"), "publicKey.user.id").String()), flow)
+
+		t.Run("case=rejects an invalid response", func(t *testing.T) {
+			body, res := submit(t, hc, flow, map[string]string{"method": "webauthn", "webauthn_register": "{}"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.EqualValues(t, text.ErrorValidationWebAuthnVerifierWrong, gjson.Get(body, "ui.nodes.#(attributes.name==webauthn_register).messages.0.id").Int(), body)
+			assert.Empty(t, gjson.GetBytes(getIdentity(t, i).Credentials[identity.CredentialsTypeWebAuthn].Config, "credentials").Array())
+		})
+
+		t.Run("case=saves the security key", func(t *testing.T) {
+			body, res := submit(t, hc, flow, map[string]string{
+				"method":                        "webauthn",
+				"webauthn_register":             key.register(t, flow),
+				"webauthn_register_displayname": "my key",
+			})
+			assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+			assert.EqualValues(t, settings.StateSuccess, gjson.Get(body, "flow.state").String(), body)
+			assert.EqualValues(t, "my key", gjson.Get(body, "flow.ui.nodes.#(attributes.name==webauthn_remove).meta.label.context.display_name").String(), body)
+
+			actual := getIdentity(t, i)
+			require.Contains(t, actual.Credentials, identity.CredentialsTypeWebAuthn)
+			c := actual.Credentials[identity.CredentialsTypeWebAuthn]
+			assert.EqualValues(t, []string{i.Credentials[identity.CredentialsTypePassword].Identifiers[0]}, c.Identifiers)
+			assert.Len(t, gjson.GetBytes(c.Config, "credentials").Array(), 1)
+			assert.EqualValues(t, "my key", gjson.GetBytes(c.Config, "credentials.0.display_name").String())
+		})
+
+		t.Run("case=excludes the registered security key", func(t *testing.T) {
+			flow := initFlow(t, hc)
+			assert.EqualValues(t, key.id, decode(t, gjson.Get(options(t, flow, "webauthn_register_trigger"), "publicKey.excludeCredentials.0.id").String()), flow)
+		})
+	})
+
+	t.Run("case=remove a security key", func(t *testing.T) {
+		flow := initFlow(t, hc)
+		handle := gjson.Get(flow, "ui.nodes.#(attributes.name==webauthn_remove).attributes.value").String()
+		require.NotEmpty(t, handle, flow)
+
+		t.Run("case=rejects an unknown security key", func(t *testing.T) {
+			body, res := submit(t, hc, flow, map[string]string{"webauthn_remove": "1234"})
+			assert.EqualValues(t, http.StatusBadRequest, res.StatusCode, body)
+			assert.Contains(t, getIdentity(t, i).Credentials, identity.CredentialsTypeWebAuthn)
+		})
+
+		body, res := submit(t, hc, flow, map[string]string{"webauthn_remove": handle})
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, body)
+		assert.EqualValues(t, settings.StateSuccess, gjson.Get(body, "flow.state").String(), body)
+		assert.False(t, gjson.Get(body, "flow.ui.nodes.#(attributes.name==webauthn_remove)").Exists(), body)
+		assert.Empty(t, gjson.GetBytes(getIdentity(t, i).Credentials[identity.CredentialsTypeWebAuthn].Config, "credentials").Array())
+	})
+
+	t.Run("case=requires a privileged session", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1m")
+		})
+
+		flow := initFlow(t, hc)
+		time.Sleep(time.Millisecond)
+
+		body, res := submit(t, hc, flow, map[string]string{"method": "webauthn", "webauthn_register": key.register(t, flow)})
+		assert.EqualValues(t, http.StatusForbidden, res.StatusCode, body)
+		assert.Contains(t, gjson.Get(body, "error.reason").String(), "re-authenticate", body)
+	})
+}
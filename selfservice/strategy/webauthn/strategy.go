@@ -0,0 +1,69 @@
+package webauthn
+
+import (
+	"github.com/ory/x/decoderx"
+
+	"kratos/continuity"
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/selfservice/errorx"
+	"kratos/selfservice/flow/login"
+	"kratos/selfservice/flow/settings"
+	"kratos/session"
+	"kratos/ui/node"
+	"kratos/x"
+)
+
+var _ login.Strategy = new(Strategy)
+var _ login.SecondFactorStrategy = new(Strategy)
+var _ settings.Strategy = new(Strategy)
+
+type webauthnStrategyDependencies interface {
+	x.LoggingProvider
+	x.WriterProvider
+	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
+
+	config.Provider
+
+	continuity.ManagementProvider
+
+	errorx.ManagementProvider
+
+	login.HooksProvider
+	login.ErrorHandlerProvider
+	login.HookExecutorProvider
+	login.FlowPersistenceProvider
+	login.HandlerProvider
+
+	settings.FlowPersistenceProvider
+	settings.HookExecutorProvider
+	settings.HooksProvider
+	settings.ErrorHandlerProvider
+
+	identity.PrivilegedPoolProvider
+	identity.ValidationProvider
+
+	session.HandlerProvider
+	session.ManagementProvider
+}
+
+type Strategy struct {
+	d  webauthnStrategyDependencies
+	hd *decoderx.HTTP
+}
+
+func NewStrategy(d webauthnStrategyDependencies) *Strategy {
+	return &Strategy{
+		d:  d,
+		hd: decoderx.NewHTTP(),
+	}
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeWebAuthn
+}
+
+func (s *Strategy) NodeGroup() node.Group {
+	return node.WebAuthnGroup
+}
@@ -0,0 +1,121 @@
+package webauthn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/duo-labs/webauthn/webauthn"
+
+	"kratos/identity"
+)
+
+// CredentialsConfig is the struct that is being used as part of the identity credentials.
+type CredentialsConfig struct {
+	// List of webauthn credentials.
+	Credentials Credentials `json:"credentials"`
+}
+
+type Credentials []Credential
+
+type Credential struct {
+	ID              []byte        `json:"id"`
+	PublicKey       []byte        `json:"public_key"`
+	AttestationType string        `json:"attestation_type"`
+	Authenticator   Authenticator `json:"authenticator"`
+	DisplayName     string        `json:"display_name"`
+	AddedAt         time.Time     `json:"added_at"`
+}
+
+type Authenticator struct {
+	AAGUID       []byte `json:"aaguid"`
+	SignCount    uint32 `json:"sign_count"`
+	CloneWarning bool   `json:"clone_warning"`
+}
+
+func CredentialFromWebAuthn(credential *webauthn.Credential) *Credential {
+	return &Credential{
+		ID:              credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Authenticator: Authenticator{
+			AAGUID:       credential.Authenticator.AAGUID,
+			SignCount:    credential.Authenticator.SignCount,
+			CloneWarning: credential.Authenticator.CloneWarning,
+		},
+	}
+}
+
+func (c Credentials) ToWebAuthn() []webauthn.Credential {
+	result := make([]webauthn.Credential, len(c))
+	for k, cc := range c {
+		result[k] = *cc.ToWebAuthn()
+	}
+	return result
+}
+
+func (c *Credential) ToWebAuthn() *webauthn.Credential {
+	return &webauthn.Credential{
+		ID:              c.ID,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		Authenticator: webauthn.Authenticator{
+			AAGUID:       c.Authenticator.AAGUID,
+			SignCount:    c.Authenticator.SignCount,
+			CloneWarning: c.Authenticator.CloneWarning,
+		},
+	}
+}
+
+// Handle returns the hex representation of the credential ID which is used to reference
+// the credential in the settings flow.
+func (c *Credential) Handle() string {
+	return fmt.Sprintf("%x", c.ID)
+}
+
+var _ webauthn.User = new(user)
+
+type user struct {
+	id          []byte
+	name        string
+	credentials []webauthn.Credential
+}
+
+// newUser wraps an identity and its security keys so it can be used with the WebAuthn ceremonies.
+func newUser(i *identity.Identity, credentials Credentials) *user {
+	return &user{
+		id:          i.ID.Bytes(),
+		name:        accountName(i),
+		credentials: credentials.ToWebAuthn(),
+	}
+}
+
+func (u *user) WebAuthnID() []byte {
+	return u.id
+}
+
+func (u *user) WebAuthnName() string {
+	return u.name
+}
+
+func (u *user) WebAuthnDisplayName() string {
+	return u.name
+}
+
+func (u *user) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *user) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// accountName returns the name shown by the authenticator for this identity.
+func accountName(i *identity.Identity) string {
+	for _, ct := range []identity.CredentialsType{identity.CredentialsTypePassword, identity.CredentialsTypeWebAuthn} {
+		if c, ok := i.GetCredentials(ct); ok && len(c.Identifiers) > 0 && len(c.Identifiers[0]) > 0 {
+			return c.Identifiers[0]
+		}
+	}
+
+	return i.ID.String()
+}
@@ -1,18 +1,26 @@
 package session
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 
+	"github.com/ory/nosurf"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/stringsx"
+	"github.com/ory/x/urlx"
 
 	"github.com/ory/x/errorsx"
 
 	"github.com/ory/herodot"
 
 	"kratos/driver/config"
+	"kratos/identity"
 	"kratos/x"
 )
 
@@ -20,9 +28,13 @@ type (
 	handlerDependencies interface {
 		ManagementProvider
 		PersistenceProvider
+		TokenizerProvider
+		identity.PoolProvider
 		x.WriterProvider
 		x.LoggingProvider
 		x.CSRFProvider
+		x.CSRFTokenGeneratorProvider
+		config.Provider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -43,25 +55,66 @@ func NewHandler(
 }
 
 const (
-	RouteWhoami = "/sessions/whoami"
-	RouteRevoke = "/sessions"
+	RouteWhoami     = "/sessions/whoami"
+	RouteRevoke     = "/sessions"
+	RouteCollection = "/sessions"
+	RouteOthers     = "/sessions/others"
+	RouteOther      = RouteOthers + "/:id"
+	RouteRefresh    = "/sessions/refresh"
+	RouteTokenize   = "/sessions/tokenize"
+	RouteJWKS       = "/.well-known/jwks.json"
 	// SessionsWhoisPath  = "/sessions/whois"
+
+	RouteAdminSession          = "/sessions/:id"
+	RouteAdminIdentitySessions = identity.RouteBase + "/:id/sessions"
 )
 
 func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	h.r.CSRFHandler().ExemptPath(RouteWhoami)
 	h.r.CSRFHandler().ExemptPath(RouteRevoke)
 
+	// These routes accept session tokens as well as session cookies. Requests using session cookies
+	// are checked for a valid anti-CSRF token by ensureCSRF.
+	h.r.CSRFHandler().ExemptPath(RouteOthers)
+	h.r.CSRFHandler().ExemptGlob(RouteOthers + "/*")
+	h.r.CSRFHandler().ExemptPath(RouteRefresh)
+
 	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
 		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace} {
 		public.Handle(m, RouteWhoami, h.whoami)
 	}
 
 	public.DELETE(RouteRevoke, h.revoke)
+
+	public.GET(RouteCollection, h.listMySessions)
+	public.DELETE(RouteOthers, h.revokeMyOtherSessions)
+	public.DELETE(RouteOther, h.revokeMySession)
+	public.POST(RouteRefresh, h.refresh)
+	public.GET(RouteTokenize, h.tokenize)
+	public.GET(RouteJWKS, h.jwks)
+}
+
+// ensureCSRF requires a valid anti-CSRF token, sent in the X-CSRF-Token header or the csrf_token parameter,
+// if the request is authenticated using a session cookie. Browsers can not be tricked into sending session
+// tokens in a header, so requests using them do not need an anti-CSRF token.
+func (h *Handler) ensureCSRF(r *http.Request) error {
+	if !tokenFromCookie(r) {
+		return nil
+	}
+
+	actual := stringsx.Coalesce(r.Header.Get("X-CSRF-Token"), r.FormValue(x.CSRFTokenName))
+	if !nosurf.VerifyToken(h.r.GenerateCSRFToken(r), actual) {
+		return errors.WithStack(x.ErrInvalidCSRFToken)
+	}
+	return nil
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	// admin.GET(SessionsWhoisPath, h.fromPath)
+	admin.GET(RouteAdminSession, h.adminGetSession)
+	admin.DELETE(RouteAdminSession, h.adminRevokeSession)
+	admin.GET(RouteAdminIdentitySessions, h.adminListIdentitySessions)
+	admin.DELETE(RouteAdminIdentitySessions, h.adminRevokeIdentitySessions)
 }
 
 // swagger:parameters revokeSession
@@ -137,6 +190,11 @@ type whoamiParameters struct {
 // Returns a session object in the body or 401 if the credentials are invalid or no credentials were sent.
 // Additionally when the request it successful it adds the user ID to the 'X-Kratos-Authenticated-Identity-Id' header in the response.
 //
+// If `session.whoami.required_aal` is set to `highest_available`, this endpoint returns 403 if the
+// session has not completed all authentication factors available to the identity.
+//
+// If `session.sliding_expiry` is enabled, sessions which expire soon are extended by this endpoint.
+//
 // This endpoint is useful for reverse proxies and API Gateways.
 //
 //     Produces:
@@ -150,6 +208,7 @@ type whoamiParameters struct {
 //     Responses:
 //       200: session
 //       401: genericError
+//       403: genericError
 //       500: genericError
 func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
@@ -159,8 +218,18 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
-	// s.Devices = nil
-	s.Identity = s.Identity.CopyWithoutCredentials()
+	if err := h.r.SessionManager().DoesSessionSatisfy(r.Context(), s, h.r.Config(r.Context()).SessionWhoAmIAAL()); err != nil {
+		h.r.Audit().WithRequest(r).WithError(err).Info("Session does not fulfill the required authenticator assurance level.")
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.SessionManager().RefreshCookie(r.Context(), w, r, s); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.Identity = s.Identity.Declassify()
 
 	// Set userId as the X-Kratos-Authenticated-Identity-Id header.
 	w.Header().Set("X-Kratos-Authenticated-Identity-Id", s.Identity.ID.String())
@@ -168,6 +237,566 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	h.r.Writer().Write(w, r, s)
 }
 
+// swagger:parameters listMySessions
+// nolint:deadcode,unused
+type listMySessionsParameters struct {
+	// in: header
+	Cookie string `json:"Cookie"`
+
+	// in: header
+	Authorization string `json:"Authorization"`
+
+	// Items per Page
+	//
+	// This is the number of items per page.
+	//
+	// required: false
+	// in: query
+	// default: 250
+	// min: 1
+	// max: 1000
+	PerPage int `json:"per_page"`
+
+	// Pagination Page
+	//
+	// required: false
+	// in: query
+	// default: 0
+	// min: 0
+	Page int `json:"page"`
+}
+
+// swagger:route GET /sessions public listMySessions
+//
+// List the Other Active Sessions of the Current Identity
+//
+// This endpoint returns all active sessions of the identity the current session belongs to, except the
+// current session itself. Each session lists the devices which used it.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       sessionToken:
+//
+//     Responses:
+//       200: sessionList
+//       401: genericError
+//       500: genericError
+func (h *Handler) listMySessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		return
+	}
+
+	active := true
+	page, itemsPerPage := x.ParsePagination(r)
+	sess, err := h.r.SessionPersister().ListSessionsByIdentity(r.Context(), s.IdentityID, &active, page, itemsPerPage, s.ID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	total, err := h.r.SessionPersister().CountSessionsByIdentity(r.Context(), s.IdentityID, &active, s.ID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for _, s := range sess {
+		s.Identity = s.Identity.Declassify()
+	}
+
+	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfPublicURL(r), RouteCollection), total, page, itemsPerPage)
+	h.r.Writer().Write(w, r, sess)
+}
+
+// swagger:parameters revokeMyOtherSessions
+// nolint:deadcode,unused
+type revokeMyOtherSessionsParameters struct {
+	// in: header
+	Cookie string `json:"Cookie"`
+
+	// in: header
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:route DELETE /sessions/others public revokeMyOtherSessions
+//
+// Revoke All Other Sessions of the Current Identity
+//
+// This endpoint signs the identity the current session belongs to out of all other devices. The
+// current session stays active.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       sessionToken:
+//
+//     Responses:
+//       204: emptyResponse
+//       401: genericError
+//       500: genericError
+func (h *Handler) revokeMyOtherSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		return
+	}
+
+	if err := h.ensureCSRF(r); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if _, err := h.r.SessionPersister().RevokeSessionsByIdentity(r.Context(), s.IdentityID, s.ID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:parameters revokeMySession
+// nolint:deadcode,unused
+type revokeMySessionParameters struct {
+	// ID is the ID of the session to revoke.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: header
+	Cookie string `json:"Cookie"`
+
+	// in: header
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:route DELETE /sessions/others/{id} public revokeMySession
+//
+// Revoke Another Session of the Current Identity
+//
+// This endpoint revokes a session which belongs to the same identity as the current session. The current
+// session can not be revoked using this endpoint, use the logout flow instead.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       sessionToken:
+//
+//     Responses:
+//       204: emptyResponse
+//       400: genericError
+//       401: genericError
+//       404: genericError
+//       500: genericError
+func (h *Handler) revokeMySession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		return
+	}
+
+	if err := h.ensureCSRF(r); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	sid := x.ParseUUID(ps.ByName("id"))
+	if sid == s.ID {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The current session can not be revoked using this endpoint. Use the logout flow instead.")))
+		return
+	}
+
+	other, err := h.r.SessionPersister().GetSession(r.Context(), sid)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	} else if other.IdentityID != s.IdentityID {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound.WithReason("The requested session does not exist.")))
+		return
+	}
+
+	if err := h.r.SessionPersister().RevokeSessionByID(r.Context(), sid); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:parameters adminGetSession adminRevokeSession
+// nolint:deadcode,unused
+type adminSessionParameters struct {
+	// ID is the session's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /sessions/{id} admin adminGetSession
+//
+// Get a Session
+//
+// This endpoint returns the session with the given ID, regardless of whether it is active or not.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: session
+//       404: genericError
+//       500: genericError
+func (h *Handler) adminGetSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.r.SessionPersister().GetSession(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.Identity = s.Identity.CopyWithoutCredentials()
+	h.r.Writer().Write(w, r, s)
+}
+
+// swagger:route DELETE /sessions/{id} admin adminRevokeSession
+//
+// Revoke a Session
+//
+// This endpoint marks the session with the given ID as inactive. The session can no longer be used
+// to authenticate requests.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       204: emptyResponse
+//       404: genericError
+//       500: genericError
+func (h *Handler) adminRevokeSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if err := h.r.SessionPersister().RevokeSessionByID(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:response sessionList
+// nolint:deadcode,unused
+type sessionListResponse struct {
+	// in: body
+	// required: true
+	// type: array
+	Body []Session
+}
+
+// swagger:parameters adminListIdentitySessions
+// nolint:deadcode,unused
+type adminListIdentitySessionsParameters struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Active is a boolean flag that filters out sessions based on the state. If no value is provided, all sessions are returned.
+	//
+	// required: false
+	// in: query
+	Active bool `json:"active"`
+
+	// Items per Page
+	//
+	// This is the number of items per page.
+	//
+	// required: false
+	// in: query
+	// default: 250
+	// min: 1
+	// max: 1000
+	PerPage int `json:"per_page"`
+
+	// Pagination Page
+	//
+	// required: false
+	// in: query
+	// default: 0
+	// min: 0
+	Page int `json:"page"`
+}
+
+// swagger:route GET /identities/{id}/sessions admin adminListIdentitySessions
+//
+// List All Sessions of an Identity
+//
+// This endpoint returns all sessions that belong to the given identity, newest first.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: sessionList
+//       400: genericError
+//       404: genericError
+//       500: genericError
+func (h *Handler) adminListIdentitySessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	iID := x.ParseUUID(ps.ByName("id"))
+	if _, err := h.r.IdentityPool().GetIdentity(r.Context(), iID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var active *bool
+	if raw := r.URL.Query().Get("active"); raw != "" {
+		a, err := strconv.ParseBool(raw)
+		if err != nil {
+			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Query parameter active must be a boolean but got: %s", raw)))
+			return
+		}
+		active = &a
+	}
+
+	page, itemsPerPage := x.ParsePagination(r)
+	sess, err := h.r.SessionPersister().ListSessionsByIdentity(r.Context(), iID, active, page, itemsPerPage, uuid.Nil)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	total, err := h.r.SessionPersister().CountSessionsByIdentity(r.Context(), iID, active, uuid.Nil)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	for _, s := range sess {
+		s.Identity = s.Identity.CopyWithoutCredentials()
+	}
+
+	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), identity.RouteBase, iID.String(), "sessions"), total, page, itemsPerPage)
+	h.r.Writer().Write(w, r, sess)
+}
+
+// swagger:parameters adminRevokeIdentitySessions
+// nolint:deadcode,unused
+type adminRevokeIdentitySessionsParameters struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /identities/{id}/sessions admin adminRevokeIdentitySessions
+//
+// Revoke All Sessions of an Identity
+//
+// This endpoint marks all active sessions of the given identity as inactive. Use this endpoint to sign
+// an identity out of all devices, for example when the account was compromised.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       204: emptyResponse
+//       404: genericError
+//       500: genericError
+func (h *Handler) adminRevokeIdentitySessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	iID := x.ParseUUID(ps.ByName("id"))
+	if _, err := h.r.IdentityPool().GetIdentity(r.Context(), iID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if _, err := h.r.SessionPersister().RevokeSessionsByIdentity(r.Context(), iID, uuid.Nil); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:parameters refreshSession
+// nolint:deadcode,unused
+type refreshSessionParameters struct {
+	// in: header
+	Cookie string `json:"Cookie"`
+
+	// in: header
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:route POST /sessions/refresh public refreshSession
+//
+// Refresh the Current Session
+//
+// Extends the session which is sent in the request so that it expires one session lifespan from now, and returns
+// the session. This endpoint is particularly useful for API clients such as mobile apps which want to keep
+// their session token alive. If the session is sent as a cookie, the cookie is re-issued.
+//
+// This endpoint returns 403 if `session.sliding_expiry` is not enabled.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       sessionToken:
+//
+//     Responses:
+//       200: session
+//       401: genericError
+//       403: genericError
+//       500: genericError
+func (h *Handler) refresh(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if !h.r.Config(r.Context()).SessionSlidingExpiryEnabled() {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrForbidden.WithReason("Refreshing sessions is disabled. Enable it by setting `session.sliding_expiry.enabled` to true.")))
+		return
+	}
+
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		return
+	}
+
+	if err := h.ensureCSRF(r); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.SessionManager().Refresh(r.Context(), w, r, s); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, s.Declassify())
+}
+
+// The Tokenized Session
+//
+// swagger:model tokenizedSession
+type tokenizedSession struct {
+	// Token is a JSON Web Token which is signed with the keys published at `/.well-known/jwks.json`.
+	//
+	// required: true
+	Token string `json:"token"`
+
+	// ExpiresAt is the time at which the token expires.
+	//
+	// required: true
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// swagger:parameters tokenizeSession
+// nolint:deadcode,unused
+type tokenizeSessionParameters struct {
+	// in: header
+	Cookie string `json:"Cookie"`
+
+	// in: header
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:route GET /sessions/tokenize public tokenizeSession
+//
+// Exchange the Current Session for a JSON Web Token
+//
+// Returns a short-lived JSON Web Token for the session which is sent in the request. Services can verify the
+// token offline using the keys published at `/.well-known/jwks.json` instead of calling `/sessions/whoami`
+// on every request. The claims of the token are configured using `session.tokenizer.claims_mapper_url`.
+//
+// The same authenticator assurance level as for `/sessions/whoami` is required. This endpoint returns 403
+// if `session.tokenizer.jwks_url` is not set.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       sessionToken:
+//
+//     Responses:
+//       200: tokenizedSession
+//       401: genericError
+//       403: genericError
+//       500: genericError
+func (h *Handler) tokenize(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		return
+	}
+
+	if err := h.r.SessionManager().DoesSessionSatisfy(r.Context(), s, h.r.Config(r.Context()).SessionWhoAmIAAL()); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	token, expiresAt, err := h.r.SessionTokenizer().TokenizeSession(r.Context(), s.Declassify())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, &tokenizedSession{Token: token, ExpiresAt: expiresAt})
+}
+
+// swagger:model jsonWebKeySet
+// nolint:deadcode,unused
+type jsonWebKeySet struct {
+	// The keys which are used to sign tokenized sessions.
+	//
+	// required: true
+	Keys []json.RawMessage `json:"keys"`
+}
+
+// swagger:route GET /.well-known/jwks.json public getSessionJSONWebKeySet
+//
+// Get the JSON Web Key Set for Tokenized Sessions
+//
+// Returns the public keys which are used to sign tokenized sessions. The set is empty if tokenizing
+// sessions is disabled.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: jsonWebKeySet
+//       500: genericError
+func (h *Handler) jwks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	keys, err := h.r.SessionTokenizer().PublicKeys(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, keys)
+}
+
 func (h *Handler) IsAuthenticated(wrap httprouter.Handle, onUnauthenticated httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		if _, err := h.r.SessionManager().FetchFromRequest(r.Context(), r); err != nil {
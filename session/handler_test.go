@@ -2,7 +2,9 @@ package session_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,8 +15,12 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 
 	"github.com/ory/kratos-client-go"
+	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 	"kratos/driver/config"
 	"kratos/identity"
@@ -99,6 +105,411 @@ func TestSessionRevoke(t *testing.T) {
 	assert.False(t, actual.IsActive())
 }
 
+func TestHandlerAdminSessionManagement(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	_, adminTS := testhelpers.NewKratosServer(t, reg)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://stub/identity.schema.json")
+
+	var do = func(t *testing.T, method, href string, expectCode int) gjson.Result {
+		req, err := http.NewRequest(method, adminTS.URL+href, nil)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	var createSessions = func(t *testing.T, count int) (*identity.Identity, []*Session) {
+		i := &identity.Identity{Traits: identity.Traits(`{"baz":"bar"}`)}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+		sess := make([]*Session, count)
+		for k := range sess {
+			sess[k] = NewActiveSession(i, conf, time.Now())
+			require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), sess[k]))
+		}
+		return i, sess
+	}
+
+	var identitySessions = func(i *identity.Identity) string {
+		return "/identities/" + i.ID.String() + "/sessions"
+	}
+
+	t.Run("case=should get a session", func(t *testing.T) {
+		i, sess := createSessions(t, 1)
+		actual := do(t, "GET", "/sessions/"+sess[0].ID.String(), http.StatusOK)
+		assert.Equal(t, sess[0].ID.String(), actual.Get("id").String(), "%s", actual.Raw)
+		assert.Equal(t, i.ID.String(), actual.Get("identity.id").String(), "%s", actual.Raw)
+		assert.False(t, actual.Get("identity.credentials").Exists(), "%s", actual.Raw)
+
+		do(t, "GET", "/sessions/"+x.NewUUID().String(), http.StatusNotFound)
+	})
+
+	t.Run("case=should revoke a session", func(t *testing.T) {
+		_, sess := createSessions(t, 2)
+		do(t, "DELETE", "/sessions/"+sess[0].ID.String(), http.StatusNoContent)
+
+		actual, err := reg.SessionPersister().GetSession(context.Background(), sess[0].ID)
+		require.NoError(t, err)
+		assert.False(t, actual.IsActive())
+
+		actual, err = reg.SessionPersister().GetSession(context.Background(), sess[1].ID)
+		require.NoError(t, err)
+		assert.True(t, actual.IsActive())
+
+		do(t, "DELETE", "/sessions/"+x.NewUUID().String(), http.StatusNotFound)
+	})
+
+	t.Run("case=should list the sessions of an identity", func(t *testing.T) {
+		i, sess := createSessions(t, 3)
+		_, _ = createSessions(t, 1)
+		require.NoError(t, reg.SessionPersister().RevokeSessionByID(context.Background(), sess[0].ID))
+
+		actual := do(t, "GET", identitySessions(i), http.StatusOK)
+		assert.Len(t, actual.Array(), 3, "%s", actual.Raw)
+		for _, s := range actual.Array() {
+			assert.Equal(t, i.ID.String(), s.Get("identity.id").String(), "%s", actual.Raw)
+		}
+
+		actual = do(t, "GET", identitySessions(i)+"?active=true", http.StatusOK)
+		assert.Len(t, actual.Array(), 2, "%s", actual.Raw)
+
+		actual = do(t, "GET", identitySessions(i)+"?active=false", http.StatusOK)
+		require.Len(t, actual.Array(), 1, "%s", actual.Raw)
+		assert.Equal(t, sess[0].ID.String(), actual.Get("0.id").String(), "%s", actual.Raw)
+
+		actual = do(t, "GET", identitySessions(i)+"?per_page=2", http.StatusOK)
+		assert.Len(t, actual.Array(), 2, "%s", actual.Raw)
+
+		do(t, "GET", identitySessions(i)+"?active=maybe", http.StatusBadRequest)
+		do(t, "GET", "/identities/"+x.NewUUID().String()+"/sessions", http.StatusNotFound)
+	})
+
+	t.Run("case=should revoke all sessions of an identity", func(t *testing.T) {
+		i, sess := createSessions(t, 2)
+		_, other := createSessions(t, 1)
+
+		do(t, "DELETE", identitySessions(i), http.StatusNoContent)
+		for _, s := range sess {
+			actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+			require.NoError(t, err)
+			assert.False(t, actual.IsActive())
+		}
+
+		actual, err := reg.SessionPersister().GetSession(context.Background(), other[0].ID)
+		require.NoError(t, err)
+		assert.True(t, actual.IsActive())
+
+		// Revoking again is a no-op.
+		do(t, "DELETE", identitySessions(i), http.StatusNoContent)
+		do(t, "DELETE", "/identities/"+x.NewUUID().String()+"/sessions", http.StatusNotFound)
+	})
+}
+
+func TestHandlerSelfServiceSessionManagement(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://stub/identity.schema.json")
+
+	var do = func(t *testing.T, method, href, token string, expectCode int) gjson.Result {
+		req, err := http.NewRequest(method, publicTS.URL+href, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		res, err := publicTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	var createSessions = func(t *testing.T, count int) []*Session {
+		i := &identity.Identity{Traits: identity.Traits(`{"baz":"bar"}`)}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+		sess := make([]*Session, count)
+		for k := range sess {
+			sess[k] = NewActiveSession(i, conf, time.Now())
+			require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), sess[k]))
+		}
+		return sess
+	}
+
+	var isActive = func(t *testing.T, s *Session) bool {
+		actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+		require.NoError(t, err)
+		return actual.IsActive()
+	}
+
+	t.Run("case=should require a session", func(t *testing.T) {
+		do(t, "GET", RouteCollection, "invalid", http.StatusUnauthorized)
+		do(t, "DELETE", RouteOthers, "invalid", http.StatusUnauthorized)
+		do(t, "DELETE", RouteOthers+"/"+x.NewUUID().String(), "invalid", http.StatusUnauthorized)
+	})
+
+	t.Run("case=should list the other active sessions", func(t *testing.T) {
+		sess := createSessions(t, 4)
+		_ = createSessions(t, 1)
+		require.NoError(t, reg.SessionPersister().RevokeSessionByID(context.Background(), sess[3].ID))
+
+		// Use the second session so that it has a device.
+		do(t, "GET", RouteWhoami, sess[1].Token, http.StatusOK)
+
+		actual := do(t, "GET", RouteCollection, sess[0].Token, http.StatusOK)
+		require.Len(t, actual.Array(), 2, "%s", actual.Raw)
+		assert.ElementsMatch(t, []string{sess[1].ID.String(), sess[2].ID.String()},
+			[]string{actual.Get("0.id").String(), actual.Get("1.id").String()}, "%s", actual.Raw)
+		assert.Equal(t, "Mozilla/5.0", actual.Get(`#(id=="`+sess[1].ID.String()+`").devices.0.user_agent`).String(), "%s", actual.Raw)
+		assert.NotEmpty(t, actual.Get(`#(id=="`+sess[1].ID.String()+`").devices.0.ip_address`).String(), "%s", actual.Raw)
+		assert.NotEmpty(t, actual.Get(`#(id=="`+sess[1].ID.String()+`").devices.0.last_seen_at`).String(), "%s", actual.Raw)
+	})
+
+	t.Run("case=should record the device on whoami", func(t *testing.T) {
+		sess := createSessions(t, 1)
+		actual := do(t, "GET", RouteWhoami, sess[0].Token, http.StatusOK)
+		assert.Equal(t, "Mozilla/5.0", actual.Get("devices.0.user_agent").String(), "%s", actual.Raw)
+	})
+
+	t.Run("case=should only show the public metadata on whoami", func(t *testing.T) {
+		i := &identity.Identity{
+			Traits:         identity.Traits(`{"baz":"bar"}`),
+			MetadataPublic: sqlxx.NullJSONRawMessage(`{"role":"user"}`),
+			MetadataAdmin:  sqlxx.NullJSONRawMessage(`{"note":"vip"}`),
+		}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+		s := NewActiveSession(i, conf, time.Now())
+		require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), s))
+
+		actual := do(t, "GET", RouteWhoami, s.Token, http.StatusOK)
+		assert.Equal(t, "user", actual.Get("identity.metadata_public.role").String(), "%s", actual.Raw)
+		assert.False(t, actual.Get("identity.metadata_admin").Exists(), "%s", actual.Raw)
+	})
+
+	t.Run("case=should revoke another session", func(t *testing.T) {
+		sess := createSessions(t, 2)
+		other := createSessions(t, 1)
+
+		do(t, "DELETE", RouteOthers+"/"+sess[0].ID.String(), sess[0].Token, http.StatusBadRequest)
+		do(t, "DELETE", RouteOthers+"/"+other[0].ID.String(), sess[0].Token, http.StatusNotFound)
+		do(t, "DELETE", RouteOthers+"/"+x.NewUUID().String(), sess[0].Token, http.StatusNotFound)
+		assert.True(t, isActive(t, other[0]))
+
+		do(t, "DELETE", RouteOthers+"/"+sess[1].ID.String(), sess[0].Token, http.StatusNoContent)
+		assert.True(t, isActive(t, sess[0]))
+		assert.False(t, isActive(t, sess[1]))
+	})
+
+	t.Run("case=should revoke all other sessions", func(t *testing.T) {
+		sess := createSessions(t, 3)
+		other := createSessions(t, 1)
+
+		do(t, "DELETE", RouteOthers, sess[0].Token, http.StatusNoContent)
+		assert.True(t, isActive(t, sess[0]))
+		assert.False(t, isActive(t, sess[1]))
+		assert.False(t, isActive(t, sess[2]))
+		assert.True(t, isActive(t, other[0]))
+
+		actual := do(t, "GET", RouteCollection, sess[0].Token, http.StatusOK)
+		assert.Len(t, actual.Array(), 0, "%s", actual.Raw)
+	})
+
+	t.Run("case=should require an anti-CSRF token for session cookies", func(t *testing.T) {
+		sess := createSessions(t, 2)
+		client := testhelpers.NewHTTPClientWithSessionCookie(t, reg, sess[0])
+
+		var doWithCookie = func(t *testing.T, href, csrfToken string, expectCode int) {
+			req, err := http.NewRequest("DELETE", publicTS.URL+href, nil)
+			require.NoError(t, err)
+			if csrfToken != "" {
+				req.Header.Set("X-CSRF-Token", csrfToken)
+			}
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		}
+
+		doWithCookie(t, RouteOthers+"/"+sess[1].ID.String(), "", http.StatusForbidden)
+		doWithCookie(t, RouteOthers, "invalid", http.StatusForbidden)
+		assert.True(t, isActive(t, sess[1]))
+
+		doWithCookie(t, RouteOthers+"/"+sess[1].ID.String(), x.FakeCSRFToken, http.StatusNoContent)
+		assert.False(t, isActive(t, sess[1]))
+		doWithCookie(t, RouteOthers, x.FakeCSRFToken, http.StatusNoContent)
+	})
+}
+
+func TestHandlerSessionRefresh(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://stub/identity.schema.json")
+	conf.MustSet(config.ViperKeySessionLifespan, "24h")
+	conf.MustSet(config.ViperKeySessionSlidingExpiryThreshold, "1h")
+
+	var do = func(t *testing.T, method, href, token string, expectCode int) gjson.Result {
+		req, err := http.NewRequest(method, publicTS.URL+href, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := publicTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		assert.Empty(t, res.Header.Get("Set-Cookie"), "no cookie must be issued for session tokens")
+		return gjson.ParseBytes(body)
+	}
+
+	// createSession creates a session which expires after the given duration.
+	var createSession = func(t *testing.T, expiresIn time.Duration) *Session {
+		i := &identity.Identity{Traits: identity.Traits(`{"baz":"bar"}`)}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+		s := NewActiveSession(i, conf, time.Now().Add(expiresIn-conf.SessionLifespan()))
+		require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), s))
+		return s
+	}
+
+	var expiresAt = func(t *testing.T, s *Session) time.Time {
+		actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+		require.NoError(t, err)
+		return actual.ExpiresAt
+	}
+
+	t.Run("case=sliding expiry disabled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySessionSlidingExpiryEnabled, false)
+
+		s := createSession(t, 10*time.Minute)
+		do(t, "GET", RouteWhoami, s.Token, http.StatusOK)
+		assert.WithinDuration(t, s.ExpiresAt, expiresAt(t, s), time.Second)
+
+		do(t, "POST", RouteRefresh, s.Token, http.StatusForbidden)
+		assert.WithinDuration(t, s.ExpiresAt, expiresAt(t, s), time.Second)
+	})
+
+	t.Run("case=sliding expiry enabled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySessionSlidingExpiryEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionSlidingExpiryEnabled, false)
+		})
+
+		t.Run("case=whoami extends sessions within the threshold", func(t *testing.T) {
+			s := createSession(t, 10*time.Minute)
+			actual := do(t, "GET", RouteWhoami, s.Token, http.StatusOK)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt(t, s), time.Minute)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), actual.Get("expires_at").Time(), time.Minute)
+		})
+
+		t.Run("case=whoami does not extend sessions outside the threshold", func(t *testing.T) {
+			s := createSession(t, 10*time.Hour)
+			do(t, "GET", RouteWhoami, s.Token, http.StatusOK)
+			assert.WithinDuration(t, s.ExpiresAt, expiresAt(t, s), time.Second)
+		})
+
+		t.Run("case=refresh extends sessions outside the threshold", func(t *testing.T) {
+			s := createSession(t, 10*time.Hour)
+			actual := do(t, "POST", RouteRefresh, s.Token, http.StatusOK)
+			assert.Equal(t, s.ID.String(), actual.Get("id").String(), "%s", actual.Raw)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), actual.Get("expires_at").Time(), time.Minute)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt(t, s), time.Minute)
+		})
+
+		t.Run("case=refresh requires an anti-CSRF token for session cookies", func(t *testing.T) {
+			s := createSession(t, 10*time.Hour)
+			client := testhelpers.NewHTTPClientWithSessionCookie(t, reg, s)
+
+			var refresh = func(t *testing.T, csrfToken string, expectCode int) {
+				req, err := http.NewRequest("POST", publicTS.URL+RouteRefresh, nil)
+				require.NoError(t, err)
+				req.Header.Set("X-CSRF-Token", csrfToken)
+				res, err := client.Do(req)
+				require.NoError(t, err)
+				defer res.Body.Close()
+				require.EqualValues(t, expectCode, res.StatusCode)
+			}
+
+			refresh(t, "", http.StatusForbidden)
+			assert.WithinDuration(t, s.ExpiresAt, expiresAt(t, s), time.Second)
+
+			refresh(t, x.FakeCSRFToken, http.StatusOK)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt(t, s), time.Minute)
+		})
+
+		t.Run("case=refresh requires an active session", func(t *testing.T) {
+			s := createSession(t, 10*time.Hour)
+			require.NoError(t, reg.SessionPersister().RevokeSessionByID(context.Background(), s.ID))
+			do(t, "POST", RouteRefresh, s.Token, http.StatusUnauthorized)
+		})
+	})
+}
+
+func TestHandlerTokenize(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://stub/identity.schema.json")
+
+	var do = func(t *testing.T, href, token string, expectCode int) gjson.Result {
+		req, err := http.NewRequest("GET", publicTS.URL+href, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := publicTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	i := &identity.Identity{Traits: identity.Traits(`{"baz":"bar"}`)}
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+	s := NewActiveSession(i, conf, time.Now())
+	require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), s))
+
+	t.Run("case=tokenizer disabled", func(t *testing.T) {
+		do(t, RouteTokenize, s.Token, http.StatusForbidden)
+		assert.Len(t, do(t, RouteJWKS, "", http.StatusOK).Get("keys").Array(), 0)
+	})
+
+	t.Run("case=tokenizer enabled", func(t *testing.T) {
+		jwksURL, key := newTokenizerKeys(t)
+		conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, jwksURL)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, "")
+		})
+
+		t.Run("case=should require a session", func(t *testing.T) {
+			do(t, RouteTokenize, "", http.StatusUnauthorized)
+		})
+
+		t.Run("case=should issue a token which can be verified with the public keys", func(t *testing.T) {
+			actual := do(t, RouteTokenize, s.Token, http.StatusOK)
+			assert.NotEmpty(t, actual.Get("expires_at").String(), "%s", actual.Raw)
+
+			keys := do(t, RouteJWKS, "", http.StatusOK)
+			require.Len(t, keys.Get("keys").Array(), 1, "%s", keys.Raw)
+			assert.Equal(t, key.KeyID, keys.Get("keys.0.kid").String())
+			assert.False(t, keys.Get("keys.0.d").Exists(), "private keys must not be exposed: %s", keys.Raw)
+
+			var set jose.JSONWebKeySet
+			require.NoError(t, json.Unmarshal([]byte(keys.Raw), &set))
+			token, err := jwt.ParseSigned(actual.Get("token").String())
+			require.NoError(t, err)
+
+			var claims jwt.Claims
+			require.NoError(t, token.Claims(set.Keys[0], &claims))
+			assert.Equal(t, i.ID.String(), claims.Subject)
+		})
+	})
+}
+
 func TestIsNotAuthenticatedSecurecookie(t *testing.T) {
 	conf, reg := internal.NewFastRegistryWithMocks(t)
 	r := x.NewRouterPublic()
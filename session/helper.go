@@ -14,3 +14,12 @@ func bearerTokenFromRequest(r *http.Request) (string, bool) {
 
 	return "", false
 }
+
+// tokenFromCookie reports whether the session token was sent as a cookie rather than in a header.
+func tokenFromCookie(r *http.Request) bool {
+	if _, ok := bearerTokenFromRequest(r); ok {
+		return false
+	}
+
+	return len(r.Header.Get("X-Session-Token")) == 0
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/gofrs/uuid"
+
 	"github.com/ory/herodot"
+
+	"kratos/identity"
 )
 
 var (
 	// ErrNoActiveSessionFound is returned when no active cookie session could be found in the request.
 	ErrNoActiveSessionFound = herodot.ErrUnauthorized.WithError("request does not have a valid authentication session").WithReason("No active session was found in this request.")
+
+	// ErrAALNotSatisfied is returned when the session does not meet the required authenticator assurance level.
+	ErrAALNotSatisfied = herodot.ErrForbidden.WithError("session does not fulfill the required authenticator assurance level").WithReason("The session does not fulfill the required authenticator assurance level. Please complete the second authentication factor by initializing a login flow with `?aal=aal2`.")
 )
 
 // Manager handles identity sessions.
@@ -27,8 +34,25 @@ type Manager interface {
 	// FetchFromRequest creates an HTTP session using cookies.
 	FetchFromRequest(context.Context, *http.Request) (*Session, error)
 
+	// RefreshCookie extends the session if sliding expiry is enabled and the session expires within the
+	// sliding expiry threshold. If the session was sent as a cookie, the cookie is re-issued.
+	RefreshCookie(context.Context, http.ResponseWriter, *http.Request, *Session) error
+
+	// Refresh extends the session regardless of the sliding expiry threshold. If the session was sent as
+	// a cookie, the cookie is re-issued.
+	Refresh(context.Context, http.ResponseWriter, *http.Request, *Session) error
+
 	// PurgeFromRequest removes an HTTP session.
 	PurgeFromRequest(context.Context, http.ResponseWriter, *http.Request) error
+
+	// DoesSessionSatisfy returns ErrAALNotSatisfied if the session does not meet the required
+	// authenticator assurance level which is either "aal1" or "highest_available".
+	DoesSessionSatisfy(ctx context.Context, s *Session, requiredAAL string) error
+}
+
+// AvailableAALProvider returns the highest authenticator assurance level an identity is able to reach.
+type AvailableAALProvider interface {
+	HighestAvailableAAL(ctx context.Context, id uuid.UUID) (identity.AuthenticatorAssuranceLevel, error)
 }
 
 type ManagementProvider interface {
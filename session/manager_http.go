@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -21,6 +22,7 @@ type (
 		config.Provider
 		identity.PoolProvider
 		x.CookieProvider
+		AvailableAALProvider
 		x.CSRFProvider
 		PersistenceProvider
 	}
@@ -127,14 +129,62 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request) (*S
 		return nil, err
 	}
 
-	if !se.IsActive() {
+	if !se.IsActive() || !se.Identity.IsActive() {
 		return nil, errors.WithStack(ErrNoActiveSessionFound)
 	}
 
-	se.Identity = se.Identity.CopyWithoutCredentials()
+	if se.SeenFrom(r, s.r.Config(ctx), time.Now().UTC()) {
+		if err := s.r.SessionPersister().UpdateSessionDevices(ctx, se.ID, se.Devices); err != nil {
+			return nil, err
+		}
+	}
+
+	se.Identity = se.Identity.Declassify()
 	return se, nil
 }
 
+func (s *ManagerHTTP) RefreshCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, session *Session) error {
+	if !s.r.Config(ctx).SessionSlidingExpiryEnabled() || !session.CanBeRefreshed(s.r.Config(ctx)) {
+		return nil
+	}
+
+	return s.Refresh(ctx, w, r, session)
+}
+
+func (s *ManagerHTTP) Refresh(ctx context.Context, w http.ResponseWriter, r *http.Request, session *Session) error {
+	session.Refresh(s.r.Config(ctx))
+	if err := s.r.SessionPersister().ExtendSession(ctx, session.ID, session.ExpiresAt); err != nil {
+		if errors.Is(err, sqlcon.ErrNoRows) {
+			// The session was revoked in the meantime.
+			return errors.WithStack(ErrNoActiveSessionFound)
+		}
+		return err
+	}
+
+	if !tokenFromCookie(r) {
+		return nil
+	}
+
+	return s.IssueCookie(ctx, w, r, session)
+}
+
+func (s *ManagerHTTP) DoesSessionSatisfy(ctx context.Context, sess *Session, requiredAAL string) error {
+	required := identity.AuthenticatorAssuranceLevel(requiredAAL)
+	if requiredAAL == config.HighestAvailableAAL {
+		available, err := s.r.HighestAvailableAAL(ctx, sess.IdentityID)
+		if err != nil {
+			return err
+		}
+		required = available
+	}
+
+	if sess.AuthenticatorAssuranceLevel.Rank() < required.Rank() {
+		return errors.WithStack(ErrAALNotSatisfied)
+	}
+
+	return nil
+}
+
 func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	if token, ok := bearerTokenFromRequest(r); ok {
 		return errors.WithStack(s.r.SessionPersister().RevokeSessionByToken(ctx, token))
@@ -3,6 +3,7 @@ package session_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -29,6 +30,9 @@ type mockCSRFHandler struct {
 func (f *mockCSRFHandler) ExemptPath(s string) {
 }
 
+func (f *mockCSRFHandler) ExemptGlob(s string) {
+}
+
 func (f *mockCSRFHandler) IgnorePath(s string) {
 }
 
@@ -67,6 +71,13 @@ func TestManagerHTTP(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
+		rp.GET("/session/refresh", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+			sess, err := reg.SessionManager().FetchFromRequest(r.Context(), r)
+			require.NoError(t, err)
+			require.NoError(t, reg.SessionManager().RefreshCookie(r.Context(), w, r, sess))
+			reg.Writer().Write(w, r, sess)
+		})
+
 		rp.GET("/session/get", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 			sess, err := reg.SessionManager().FetchFromRequest(r.Context(), r)
 			if err != nil {
@@ -117,6 +128,51 @@ func TestManagerHTTP(t *testing.T) {
 			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
 		})
 
+		t.Run("case=refresh cookie", func(t *testing.T) {
+			conf.MustSet(config.ViperKeySessionSlidingExpiryThreshold, "1h")
+			conf.MustSet(config.ViperKeySessionLifespan, "2h")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeySessionSlidingExpiryEnabled, false)
+				conf.MustSet(config.ViperKeySessionLifespan, "1m")
+			})
+
+			i := identity.Identity{Traits: []byte("{}")}
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &i))
+			s = session.NewActiveSession(&i, conf, time.Now().Add(-90*time.Minute))
+
+			c := testhelpers.NewClientWithCookies(t)
+			testhelpers.MockHydrateCookieClient(t, c, pts.URL+"/session/set")
+
+			conf.MustSet(config.ViperKeySessionSlidingExpiryEnabled, false)
+			res, err := c.Get(pts.URL + "/session/refresh")
+			require.NoError(t, err)
+			assert.EqualValues(t, http.StatusOK, res.StatusCode)
+			assert.Empty(t, res.Header.Get("Set-Cookie"))
+
+			conf.MustSet(config.ViperKeySessionSlidingExpiryEnabled, true)
+			res, err = c.Get(pts.URL + "/session/refresh")
+			require.NoError(t, err)
+			assert.EqualValues(t, http.StatusOK, res.StatusCode)
+			assert.Contains(t, res.Header.Get("Set-Cookie"), conf.SessionName())
+
+			actual, err := reg.SessionPersister().GetSession(context.Background(), s.ID)
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(2*time.Hour), actual.ExpiresAt, time.Minute)
+		})
+
+		t.Run("case=inactive identity", func(t *testing.T) {
+			i := identity.Identity{Traits: []byte("{}"), State: identity.StateInactive}
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &i))
+			s = session.NewActiveSession(&i, conf, time.Now())
+
+			c := testhelpers.NewClientWithCookies(t)
+			testhelpers.MockHydrateCookieClient(t, c, pts.URL+"/session/set")
+
+			res, err := c.Get(pts.URL + "/session/get")
+			require.NoError(t, err)
+			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
+		})
+
 		t.Run("case=revoked", func(t *testing.T) {
 			i := identity.Identity{Traits: []byte("{}")}
 			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &i))
@@ -136,4 +192,48 @@ func TestManagerHTTP(t *testing.T) {
 			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
 		})
 	})
+
+	t.Run("suite=does session satisfy", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/fake-session.schema.json")
+		testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeTOTP.String(), true)
+
+		var newSession = func(t *testing.T, withTOTP bool) *session.Session {
+			i := identity.Identity{Traits: []byte("{}"), Credentials: map[identity.CredentialsType]identity.Credentials{}}
+			if withTOTP {
+				i.Credentials[identity.CredentialsTypeTOTP] = identity.Credentials{
+					Type:        identity.CredentialsTypeTOTP,
+					Identifiers: []string{x.NewUUID().String()},
+					Config:      []byte(`{"totp_url":"otpauth://totp/ory:foo@ory.sh?secret=JBSWY3DPEHPK3PXP"}`),
+				}
+			}
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &i))
+			return session.NewActiveSession(&i, conf, time.Now())
+		}
+
+		for k, tc := range []struct {
+			withTOTP bool
+			aal      identity.AuthenticatorAssuranceLevel
+			required string
+			err      error
+		}{
+			{aal: identity.AuthenticatorAssuranceLevel1, required: "aal1"},
+			{aal: identity.AuthenticatorAssuranceLevel1, required: config.HighestAvailableAAL},
+			{withTOTP: true, aal: identity.AuthenticatorAssuranceLevel1, required: "aal1"},
+			{withTOTP: true, aal: identity.AuthenticatorAssuranceLevel1, required: config.HighestAvailableAAL, err: session.ErrAALNotSatisfied},
+			{withTOTP: true, aal: identity.AuthenticatorAssuranceLevel2, required: config.HighestAvailableAAL},
+		} {
+			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+				s := newSession(t, tc.withTOTP)
+				s.AuthenticatorAssuranceLevel = tc.aal
+
+				err := reg.SessionManager().DoesSessionSatisfy(context.Background(), s, tc.required)
+				if tc.err != nil {
+					assert.ErrorIs(t, err, tc.err)
+					return
+				}
+				assert.NoError(t, err)
+			})
+		}
+	})
 }
@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
 	"github.com/gofrs/uuid"
@@ -22,9 +23,26 @@ type Persister interface {
 	// GetSession retrieves a session from the store.
 	GetSession(ctx context.Context, sid uuid.UUID) (*Session, error)
 
+	// ListSessionsByIdentity retrieves sessions for an identity from the store. If active is not nil, only
+	// sessions for which IsActive equals active are returned. The session with the ID except is omitted.
+	ListSessionsByIdentity(ctx context.Context, iID uuid.UUID, active *bool, page, perPage int, except uuid.UUID) ([]*Session, error)
+
+	// CountSessionsByIdentity counts the sessions of an identity. If active is not nil, only sessions
+	// for which IsActive equals active are counted. The session with the ID except is omitted.
+	CountSessionsByIdentity(ctx context.Context, iID uuid.UUID, active *bool, except uuid.UUID) (int64, error)
+
 	// CreateSession adds a session to the store.
 	CreateSession(ctx context.Context, s *Session) error
 
+	// UpdateSession updates an existing session in the store.
+	UpdateSession(ctx context.Context, s *Session) error
+
+	// UpdateSessionDevices only updates the devices of an existing session in the store.
+	UpdateSessionDevices(ctx context.Context, sID uuid.UUID, devices Devices) error
+
+	// ExtendSession only updates the expiry of an existing and active session in the store.
+	ExtendSession(ctx context.Context, sID uuid.UUID, expiresAt time.Time) error
+
 	// DeleteSession removes a session from the store.
 	DeleteSession(ctx context.Context, id uuid.UUID) error
 
@@ -45,6 +63,13 @@ type Persister interface {
 
 	// RevokeSessionByToken marks a session inactive with the given token.
 	RevokeSessionByToken(ctx context.Context, token string) error
+
+	// RevokeSessionByID marks a session inactive with the given ID.
+	RevokeSessionByID(ctx context.Context, sID uuid.UUID) error
+
+	// RevokeSessionsByIdentity marks all active sessions of the given identity except the session
+	// with the ID except inactive and returns the number of revoked sessions.
+	RevokeSessionsByIdentity(ctx context.Context, iID uuid.UUID, except uuid.UUID) (int, error)
 }
 
 func TestPersister(ctx context.Context, conf *config.Config, p interface {
@@ -131,6 +156,103 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			assert.False(t, actual.Active)
 		})
 
+		t.Run("case=list and revoke sessions by identity", func(t *testing.T) {
+			var expected1 Session
+			var expected2 Session
+			require.NoError(t, faker.FakeData(&expected1))
+			expected1.Active = true
+			require.NoError(t, p.CreateIdentity(ctx, expected1.Identity))
+			require.NoError(t, p.CreateSession(ctx, &expected1))
+
+			require.NoError(t, faker.FakeData(&expected2))
+			expected2.Active = true
+			expected2.Identity = expected1.Identity
+			expected2.IdentityID = expected1.IdentityID
+			require.NoError(t, p.CreateSession(ctx, &expected2))
+
+			active, inactive := true, false
+			actual, err := p.ListSessionsByIdentity(ctx, expected1.IdentityID, nil, 0, 10, uuid.Nil)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			for _, s := range actual {
+				assert.Equal(t, expected1.IdentityID, s.Identity.ID)
+			}
+
+			actual, err = p.ListSessionsByIdentity(ctx, expected1.IdentityID, nil, 0, 1, uuid.Nil)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+
+			require.NoError(t, p.RevokeSessionByID(ctx, expected1.ID))
+			require.Error(t, p.RevokeSessionByID(ctx, x.NewUUID()))
+
+			actual, err = p.ListSessionsByIdentity(ctx, expected1.IdentityID, &active, 0, 10, uuid.Nil)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, expected2.ID, actual[0].ID)
+
+			count, err := p.CountSessionsByIdentity(ctx, expected1.IdentityID, &inactive, uuid.Nil)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, count)
+
+			actual, err = p.ListSessionsByIdentity(ctx, expected1.IdentityID, nil, 0, 10, expected2.ID)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, expected1.ID, actual[0].ID)
+
+			revoked, err := p.RevokeSessionsByIdentity(ctx, expected1.IdentityID, expected2.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 0, revoked)
+
+			revoked, err = p.RevokeSessionsByIdentity(ctx, expected1.IdentityID, uuid.Nil)
+			require.NoError(t, err)
+			assert.Equal(t, 1, revoked)
+
+			count, err = p.CountSessionsByIdentity(ctx, expected1.IdentityID, &active, uuid.Nil)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, count)
+
+			count, err = p.CountSessionsByIdentity(ctx, expected1.IdentityID, nil, uuid.Nil)
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, count)
+		})
+
+		t.Run("case=update session devices", func(t *testing.T) {
+			var expected Session
+			require.NoError(t, faker.FakeData(&expected))
+			require.NoError(t, p.CreateIdentity(ctx, expected.Identity))
+			require.NoError(t, p.CreateSession(ctx, &expected))
+
+			devices := Devices{{UserAgent: "Mozilla/5.0", IPAddress: "127.0.0.1", LastSeenAt: time.Now().UTC().Round(time.Second)}}
+			require.NoError(t, p.UpdateSessionDevices(ctx, expected.ID, devices))
+			require.Error(t, p.UpdateSessionDevices(ctx, x.NewUUID(), devices))
+
+			actual, err := p.GetSession(ctx, expected.ID)
+			require.NoError(t, err)
+			require.Len(t, actual.Devices, 1)
+			assert.Equal(t, devices[0].UserAgent, actual.Devices[0].UserAgent)
+			assert.Equal(t, devices[0].IPAddress, actual.Devices[0].IPAddress)
+			assert.Equal(t, devices[0].LastSeenAt.Unix(), actual.Devices[0].LastSeenAt.Unix())
+		})
+
+		t.Run("case=extend session", func(t *testing.T) {
+			var expected Session
+			require.NoError(t, faker.FakeData(&expected))
+			expected.Active = true
+			require.NoError(t, p.CreateIdentity(ctx, expected.Identity))
+			require.NoError(t, p.CreateSession(ctx, &expected))
+
+			expiresAt := time.Now().UTC().Add(time.Hour).Round(time.Second)
+			require.NoError(t, p.ExtendSession(ctx, expected.ID, expiresAt))
+			require.Error(t, p.ExtendSession(ctx, x.NewUUID(), expiresAt))
+
+			actual, err := p.GetSession(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.Equal(t, expiresAt.Unix(), actual.ExpiresAt.Unix())
+
+			require.NoError(t, p.RevokeSessionByID(ctx, expected.ID))
+			require.Error(t, p.ExtendSession(ctx, expected.ID, expiresAt), "revoked sessions can not be extended")
+		})
+
 		t.Run("case=delete session for", func(t *testing.T) {
 			var expected1 Session
 			var expected2 Session
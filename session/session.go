@@ -2,6 +2,11 @@ package session
 
 import (
 	"context"
+	"database/sql/driver"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ory/kratos/corp"
@@ -9,6 +14,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
 
 	"kratos/identity"
 	"kratos/x"
@@ -30,6 +36,17 @@ type Session struct {
 	// required: true
 	IssuedAt time.Time `json:"issued_at" db:"issued_at" faker:"time_type"`
 
+	// Authentication Method References (AMR)
+	//
+	// A list of the authentication methods which were completed to obtain this session.
+	AMR AuthenticationMethods `json:"authentication_methods" db:"authentication_methods" faker:"-"`
+
+	// The Authenticator Assurance Level (AAL) of this session.
+	AuthenticatorAssuranceLevel identity.AuthenticatorAssuranceLevel `json:"aal" db:"aal" faker:"-"`
+
+	// Devices is a list of devices which used this session.
+	Devices Devices `json:"devices" db:"devices" faker:"-"`
+
 	// required: true
 	Identity *identity.Identity `json:"identity" faker:"identity" db:"-" belongs_to:"identities" fk_id:"IdentityID"`
 
@@ -59,19 +76,195 @@ func NewActiveSession(i *identity.Identity, c interface {
 		IdentityID:      i.ID,
 		Token:           randx.MustString(32, randx.AlphaNum),
 		Active:          true,
+
+		AuthenticatorAssuranceLevel: identity.AuthenticatorAssuranceLevel1,
 	}
 }
 
+// AuthenticationMethod is a single authentication method which was completed to obtain a session.
+//
+// swagger:model sessionAuthenticationMethod
+type AuthenticationMethod struct {
+	// The method which was used.
+	Method identity.CredentialsType `json:"method"`
+
+	// The assurance level which was reached by completing this method.
+	AAL identity.AuthenticatorAssuranceLevel `json:"aal"`
+
+	// When the method was completed.
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// AuthenticationMethods is a list of authentication methods.
+//
+// swagger:model sessionAuthenticationMethods
+type AuthenticationMethods []AuthenticationMethod
+
+func (n *AuthenticationMethods) Scan(value interface{}) error {
+	return sqlxx.JSONScan(n, value)
+}
+
+func (n AuthenticationMethods) Value() (driver.Value, error) {
+	return sqlxx.JSONValue(n)
+}
+
+// CompletedLoginFor records that the given method was completed and raises the session's
+// assurance level if the method reached a higher level.
+func (s *Session) CompletedLoginFor(method identity.CredentialsType, aal identity.AuthenticatorAssuranceLevel) {
+	s.AMR = append(s.AMR, AuthenticationMethod{Method: method, AAL: aal, CompletedAt: time.Now().UTC()})
+	if aal.Rank() > s.AuthenticatorAssuranceLevel.Rank() {
+		s.AuthenticatorAssuranceLevel = aal
+	}
+}
+
+// Device is a device which used a session, identified by its user agent and IP address.
+//
+// swagger:model sessionDevice
 type Device struct {
-	UserAgent string      `json:"user_agent"`
-	SeenAt    []time.Time `json:"seen_at" faker:"time_types"`
+	// UserAgent is the User-Agent header sent by the device.
+	UserAgent string `json:"user_agent"`
+
+	// IPAddress is the IP address the device connected from.
+	IPAddress string `json:"ip_address"`
+
+	// Location is a hint where the device is located. It is only set if `session.devices.location_header`
+	// is configured.
+	Location string `json:"location,omitempty"`
+
+	// FirstSeenAt is the first time the device used the session.
+	FirstSeenAt time.Time `json:"first_seen_at"`
+
+	// LastSeenAt is the last time the device used the session.
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// Devices is a list of devices.
+//
+// swagger:model sessionDevices
+type Devices []Device
+
+func (n *Devices) Scan(value interface{}) error {
+	return sqlxx.JSONScan(n, value)
+}
+
+func (n Devices) Value() (driver.Value, error) {
+	return sqlxx.JSONValue(n)
+}
+
+// SeenFrom records that the session was used by the device which sent the request and reports whether the
+// devices changed. To reduce writes, the last use of a known device is only updated once per update interval.
+// Like the forwarding headers, the location header is only read from trusted proxies.
+func (s *Session) SeenFrom(r *http.Request, c interface {
+	SessionDeviceLocationHeader() string
+	SessionDeviceUpdateInterval() time.Duration
+	SessionDeviceHistoryLimit() int
+	SessionDeviceTrustedProxies() []*net.IPNet
+}, at time.Time) bool {
+	d := Device{UserAgent: r.UserAgent(), IPAddress: clientIP(r, c.SessionDeviceTrustedProxies()), FirstSeenAt: at, LastSeenAt: at}
+	if header := c.SessionDeviceLocationHeader(); header != "" && isTrustedProxy(remoteIP(r), c.SessionDeviceTrustedProxies()) {
+		d.Location = r.Header.Get(header)
+	}
+
+	for k := range s.Devices {
+		known := &s.Devices[k]
+		if known.UserAgent != d.UserAgent || known.IPAddress != d.IPAddress {
+			continue
+		}
+
+		if at.Sub(known.LastSeenAt) < c.SessionDeviceUpdateInterval() && known.Location == d.Location {
+			return false
+		}
+
+		known.LastSeenAt = at
+		known.Location = d.Location
+		return true
+	}
+
+	s.Devices = append(s.Devices, d)
+	if limit := c.SessionDeviceHistoryLimit(); limit > 0 && len(s.Devices) > limit {
+		sort.SliceStable(s.Devices, func(i, j int) bool {
+			return s.Devices[i].LastSeenAt.After(s.Devices[j].LastSeenAt)
+		})
+		s.Devices = s.Devices[:limit]
+	}
+	return true
+}
+
+// clientIP returns the remote address of the request. If the request was received from a trusted proxy,
+// the X-Forwarded-For header is walked from right to left and the first address which is not a trusted
+// proxy is returned. Addresses left of it may have been set by the client and are ignored.
+func clientIP(r *http.Request, trusted []*net.IPNet) string {
+	ip := remoteIP(r)
+	if !isTrustedProxy(ip, trusted) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwarded[i])
+		if hop == "" {
+			continue
+		} else if net.ParseIP(hop) == nil {
+			return ip
+		}
+
+		ip = hop
+		if !isTrustedProxy(hop, trusted) {
+			return hop
+		}
+	}
+	return ip
+}
+
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func isTrustedProxy(ip string, trusted []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range trusted {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *Session) Declassify() *Session {
-	s.Identity = s.Identity.CopyWithoutCredentials()
+	s.Identity = s.Identity.Declassify()
 	return s
 }
 
 func (s *Session) IsActive() bool {
 	return s.Active && s.ExpiresAt.After(time.Now())
 }
+
+// CanBeRefreshed reports whether the session expires within the sliding expiry threshold.
+func (s *Session) CanBeRefreshed(c interface {
+	SessionSlidingExpiryThreshold() time.Duration
+}) bool {
+	return s.ExpiresAt.Add(-c.SessionSlidingExpiryThreshold()).Before(time.Now())
+}
+
+// Refresh extends the session so that it expires one session lifespan from now.
+func (s *Session) Refresh(c interface {
+	SessionLifespan() time.Duration
+}) *Session {
+	s.ExpiresAt = time.Now().UTC().Add(c.SessionLifespan())
+	return s
+}
+
+func (s Session) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s Session) GetNID() uuid.UUID {
+	return s.NID
+}
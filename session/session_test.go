@@ -1,11 +1,14 @@
 package session_test
 
 import (
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"kratos/driver/config"
 	"kratos/identity"
 	"kratos/internal"
 	"kratos/session"
@@ -20,4 +23,98 @@ func TestSession(t *testing.T) {
 
 	assert.False(t, (&session.Session{ExpiresAt: time.Now().Add(time.Hour)}).IsActive())
 	assert.False(t, (&session.Session{Active: true}).IsActive())
+
+	t.Run("case=completed login", func(t *testing.T) {
+		s := session.NewActiveSession(new(identity.Identity), conf, authAt)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel1, s.AuthenticatorAssuranceLevel)
+
+		s.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		s.CompletedLoginFor(identity.CredentialsTypeTOTP, identity.AuthenticatorAssuranceLevel2)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, s.AuthenticatorAssuranceLevel)
+		require.Len(t, s.AMR, 2)
+		assert.EqualValues(t, identity.CredentialsTypePassword, s.AMR[0].Method)
+		assert.EqualValues(t, identity.CredentialsTypeTOTP, s.AMR[1].Method)
+
+		s.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, s.AuthenticatorAssuranceLevel, "completing a lower level must not downgrade the session")
+	})
+
+	t.Run("case=seen from", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySessionDeviceUpdateInterval, "5m")
+		conf.MustSet(config.ViperKeySessionDeviceLocationHeader, "CF-IPCountry")
+		conf.MustSet(config.ViperKeySessionDeviceHistoryLimit, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionDeviceLocationHeader, "")
+		})
+
+		s := session.NewActiveSession(new(identity.Identity), conf, authAt)
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "192.168.0.1:1234"
+		r.Header.Set("User-Agent", "Mozilla/5.0")
+		r.Header.Set("CF-IPCountry", "DE")
+
+		first := time.Now().UTC()
+		assert.True(t, s.SeenFrom(r, conf, first))
+		require.Len(t, s.Devices, 1)
+		assert.Equal(t, "Mozilla/5.0", s.Devices[0].UserAgent)
+		assert.Equal(t, "192.168.0.1", s.Devices[0].IPAddress)
+		assert.Empty(t, s.Devices[0].Location, "location headers of untrusted clients are ignored")
+		assert.Equal(t, first, s.Devices[0].FirstSeenAt)
+		assert.Equal(t, first, s.Devices[0].LastSeenAt)
+
+		assert.False(t, s.SeenFrom(r, conf, first.Add(time.Minute)), "known devices are only updated once per interval")
+		assert.Equal(t, first, s.Devices[0].LastSeenAt)
+
+		second := first.Add(10 * time.Minute)
+		assert.True(t, s.SeenFrom(r, conf, second))
+		require.Len(t, s.Devices, 1, "the same device must not be added twice")
+		assert.Equal(t, first, s.Devices[0].FirstSeenAt)
+		assert.Equal(t, second, s.Devices[0].LastSeenAt)
+
+		r.Header.Set("CF-IPCountry", "FR")
+		assert.False(t, s.SeenFrom(r, conf, second.Add(time.Second)), "location headers of untrusted clients are ignored")
+		assert.Empty(t, s.Devices[0].Location)
+
+		r.Header.Set("X-Forwarded-For", "10.0.0.1")
+		assert.False(t, s.SeenFrom(r, conf, second.Add(2*time.Second)), "forwarding headers of untrusted clients are ignored")
+		require.Len(t, s.Devices, 1)
+
+		conf.MustSet(config.ViperKeySessionDeviceTrustedProxies, []string{"192.168.0.0/24", "172.16.0.1"})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionDeviceTrustedProxies, []string{})
+		})
+
+		r.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.1, 172.16.0.1")
+		assert.True(t, s.SeenFrom(r, conf, second.Add(time.Minute)))
+		require.Len(t, s.Devices, 2)
+		assert.Equal(t, "10.0.0.1", s.Devices[1].IPAddress, "addresses left of the first untrusted hop are ignored")
+		assert.Equal(t, "FR", s.Devices[1].Location)
+
+		r.Header.Set("CF-IPCountry", "DE")
+		assert.True(t, s.SeenFrom(r, conf, second.Add(time.Minute+time.Second)), "a changed location is recorded immediately")
+		require.Len(t, s.Devices, 2)
+		assert.Equal(t, "DE", s.Devices[1].Location)
+
+		r.Header.Set("X-Forwarded-For", "10.0.0.2")
+		assert.True(t, s.SeenFrom(r, conf, second.Add(2*time.Minute)))
+		require.Len(t, s.Devices, 2, "the least recently seen device is forgotten")
+		assert.Equal(t, "10.0.0.2", s.Devices[0].IPAddress)
+		assert.Equal(t, "10.0.0.1", s.Devices[1].IPAddress)
+	})
+
+	t.Run("case=refresh", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySessionLifespan, "24h")
+		conf.MustSet(config.ViperKeySessionSlidingExpiryThreshold, "1h")
+
+		s := session.NewActiveSession(new(identity.Identity), conf, time.Now().Add(-time.Hour))
+		assert.False(t, s.CanBeRefreshed(conf))
+
+		s = session.NewActiveSession(new(identity.Identity), conf, time.Now().Add(-23*time.Hour-time.Minute))
+		assert.True(t, s.CanBeRefreshed(conf))
+
+		s.Refresh(conf)
+		assert.False(t, s.CanBeRefreshed(conf))
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), s.ExpiresAt, time.Minute)
+		assert.True(t, s.IsActive())
+	})
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ory/x/randx"
 	"github.com/ory/x/sqlcon"
 	"kratos/driver/config"
+	"kratos/identity"
 	"kratos/internal/testhelpers"
 	"kratos/persistence"
 	"kratos/session"
@@ -76,6 +77,31 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			})
 		})
 
+		t.Run("case=update session", func(t *testing.T) {
+			var expected session.Session
+			require.NoError(t, faker.FakeData(&expected))
+			expected.AuthenticatorAssuranceLevel = identity.AuthenticatorAssuranceLevel1
+			require.NoError(t, p.CreateIdentity(ctx, expected.Identity))
+			require.NoError(t, p.CreateSession(ctx, &expected))
+
+			expected.CompletedLoginFor(identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+			expected.CompletedLoginFor(identity.CredentialsTypeTOTP, identity.AuthenticatorAssuranceLevel2)
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				assert.ErrorIs(t, other.UpdateSession(ctx, &expected), sqlcon.ErrNoRows)
+			})
+
+			require.NoError(t, p.UpdateSession(ctx, &expected))
+
+			actual, err := p.GetSession(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, actual.AuthenticatorAssuranceLevel)
+			require.Len(t, actual.AMR, 2)
+			assert.EqualValues(t, identity.CredentialsTypePassword, actual.AMR[0].Method)
+			assert.EqualValues(t, identity.CredentialsTypeTOTP, actual.AMR[1].Method)
+		})
+
 		t.Run("case=delete session", func(t *testing.T) {
 			var expected session.Session
 			require.NoError(t, faker.FakeData(&expected))
@@ -0,0 +1,194 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-jsonnet"
+	"github.com/pkg/errors"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"kratos/driver/config"
+	"kratos/x"
+)
+
+// ErrTokenizerDisabled is returned when sessions should be tokenized but no JSON Web Key Set was configured.
+var ErrTokenizerDisabled = herodot.ErrForbidden.WithError("session tokenizer is disabled").WithReason("Tokenizing sessions is disabled. Enable it by setting `session.tokenizer.jwks_url`.")
+
+type (
+	tokenizerDependencies interface {
+		config.Provider
+	}
+	TokenizerProvider interface {
+		SessionTokenizer() *Tokenizer
+	}
+	// Tokenizer exchanges sessions for short-lived JSON Web Tokens which are signed with the keys
+	// configured at `session.tokenizer.jwks_url`.
+	Tokenizer struct {
+		r tokenizerDependencies
+		f *fetcher.Fetcher
+
+		sync.RWMutex
+		jwksURL       string
+		jwks          *jose.JSONWebKeySet
+		jwksFetchedAt time.Time
+	}
+)
+
+func NewTokenizer(r tokenizerDependencies) *Tokenizer {
+	return &Tokenizer{
+		r: r,
+		f: fetcher.NewFetcher(),
+	}
+}
+
+// keys returns the configured JSON Web Key Set. The set is cached until the URL changes or the cache TTL passes,
+// so that rotated keys are picked up without a restart.
+func (t *Tokenizer) keys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	source := t.r.Config(ctx).SessionTokenizerJWKSURL()
+	if source == "" {
+		return nil, errors.WithStack(ErrTokenizerDisabled)
+	}
+
+	t.RLock()
+	if t.jwksURL == source && time.Since(t.jwksFetchedAt) < t.r.Config(ctx).SessionTokenizerJWKSCacheTTL() {
+		defer t.RUnlock()
+		return t.jwks, nil
+	}
+	t.RUnlock()
+
+	raw, err := t.f.Fetch(source)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to fetch the JSON Web Key Set for tokenizing sessions: %s", err))
+	}
+
+	var set jose.JSONWebKeySet
+	if err := json.NewDecoder(raw).Decode(&set); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the JSON Web Key Set for tokenizing sessions: %s", err))
+	}
+
+	// Symmetric keys can not be published at /.well-known/jwks.json without disclosing the secret.
+	for _, k := range set.Keys {
+		if _, ok := k.Key.([]byte); ok {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The JSON Web Key %s for tokenizing sessions is a symmetric key, only asymmetric keys are supported.", k.KeyID))
+		}
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	t.jwksURL = source
+	t.jwks = &set
+	t.jwksFetchedAt = time.Now()
+	return t.jwks, nil
+}
+
+// PublicKeys returns the public keys which are used to verify tokens. The set is empty if the tokenizer is
+// disabled.
+func (t *Tokenizer) PublicKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	set, err := t.keys(ctx)
+	if errors.Is(err, ErrTokenizerDisabled) {
+		return &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	public := &jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(set.Keys))}
+	for _, k := range set.Keys {
+		public.Keys = append(public.Keys, k.Public())
+	}
+	return public, nil
+}
+
+// TokenizeSession returns a signed JSON Web Token for the session and the time at which the token expires.
+func (t *Tokenizer) TokenizeSession(ctx context.Context, s *Session) (string, time.Time, error) {
+	set, err := t.keys(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var key *jose.JSONWebKey
+	for k := range set.Keys {
+		if !set.Keys[k].IsPublic() {
+			key = &set.Keys[k]
+			break
+		}
+	}
+	if key == nil {
+		return "", time.Time{}, errors.WithStack(herodot.ErrInternalServerError.WithReason("The JSON Web Key Set for tokenizing sessions does not contain a private key."))
+	} else if key.Algorithm == "" {
+		return "", time.Time{}, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The JSON Web Key %s for tokenizing sessions does not set alg.", key.KeyID))
+	}
+
+	claims, err := t.mapClaims(ctx, s)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(t.r.Config(ctx).SessionTokenizerTTL())
+	if expiresAt.After(s.ExpiresAt) {
+		expiresAt = s.ExpiresAt
+	}
+
+	claims["sub"] = s.IdentityID.String()
+	claims["sid"] = s.ID.String()
+	claims["iss"] = t.r.Config(ctx).SelfPublicURL(nil).String()
+	claims["iat"] = now.Unix()
+	claims["nbf"] = now.Unix()
+	claims["exp"] = expiresAt.Unix()
+	claims["jti"] = x.NewUUID().String()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.SignatureAlgorithm(key.Algorithm), Key: key}, new(jose.SignerOptions).WithType("JWT"))
+	if err != nil {
+		return "", time.Time{}, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to sign the session token: %s", err))
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		return "", time.Time{}, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to sign the session token: %s", err))
+	}
+
+	return token, expiresAt, nil
+}
+
+// mapClaims evaluates the claims mapper. If no mapper is configured, no additional claims are set.
+func (t *Tokenizer) mapClaims(ctx context.Context, s *Session) (map[string]interface{}, error) {
+	claims := map[string]interface{}{}
+
+	mapper := t.r.Config(ctx).SessionTokenizerClaimsMapperURL()
+	if mapper == "" {
+		return claims, nil
+	}
+
+	template, err := t.f.Fetch(mapper)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to fetch the claims mapper for tokenizing sessions: %s", err))
+	}
+
+	sess, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.ExtCode("session", string(sess))
+	evaluated, err := vm.EvaluateSnippet(mapper, template.String())
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to evaluate the claims mapper for tokenizing sessions: %s", strings.TrimSpace(err.Error())))
+	}
+
+	if err := json.Unmarshal([]byte(evaluated), &claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The claims mapper for tokenizing sessions must return an object: %s", err))
+	} else if claims == nil {
+		claims = map[string]interface{}{}
+	}
+
+	return claims, nil
+}
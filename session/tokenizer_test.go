@@ -0,0 +1,179 @@
+package session_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"kratos/driver/config"
+	"kratos/identity"
+	"kratos/internal"
+	"kratos/session"
+	"kratos/x"
+)
+
+// newTokenizerKeys writes a JSON Web Key Set with a single ES256 key and returns its URL and public key.
+func newTokenizerKeys(t *testing.T) (string, jose.JSONWebKey) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	return "file://" + path, writeTokenizerKeys(t, path)
+}
+
+// writeTokenizerKeys writes a JSON Web Key Set with a new ES256 key to path and returns the public key.
+func writeTokenizerKeys(t *testing.T, path string) jose.JSONWebKey {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	key := jose.JSONWebKey{Key: priv, KeyID: x.NewUUID().String(), Algorithm: string(jose.ES256), Use: "sig"}
+	raw, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key}})
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, raw, 0600))
+	return key.Public()
+}
+
+func TestTokenizer(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+
+	newSession := func(t *testing.T) *session.Session {
+		i := &identity.Identity{ID: x.NewUUID(), Traits: identity.Traits(`{"baz":"bar"}`)}
+		return session.NewActiveSession(i, conf, time.Now())
+	}
+
+	verify := func(t *testing.T, token string, key jose.JSONWebKey) (jwt.Claims, map[string]interface{}) {
+		parsed, err := jwt.ParseSigned(token)
+		require.NoError(t, err)
+
+		var claims jwt.Claims
+		var custom map[string]interface{}
+		require.NoError(t, parsed.Claims(key, &claims, &custom))
+		return claims, custom
+	}
+
+	t.Run("case=disabled", func(t *testing.T) {
+		_, _, err := reg.SessionTokenizer().TokenizeSession(context.Background(), newSession(t))
+		assert.ErrorIs(t, err, session.ErrTokenizerDisabled)
+
+		keys, err := reg.SessionTokenizer().PublicKeys(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, keys.Keys)
+	})
+
+	t.Run("case=tokenize", func(t *testing.T) {
+		jwksURL, key := newTokenizerKeys(t)
+		conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, jwksURL)
+		conf.MustSet(config.ViperKeySessionTokenizerTTL, "1m")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, "")
+		})
+
+		s := newSession(t)
+		token, expiresAt, err := reg.SessionTokenizer().TokenizeSession(context.Background(), s)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+		claims, custom := verify(t, token, key)
+		require.NoError(t, claims.Validate(jwt.Expected{Issuer: "https://www.ory.sh/", Subject: s.Identity.ID.String(), Time: time.Now()}))
+		assert.Equal(t, s.ID.String(), custom["sid"])
+		assert.NotEmpty(t, claims.ID)
+		assert.Equal(t, expiresAt.Unix(), claims.Expiry.Time().Unix())
+
+		keys, err := reg.SessionTokenizer().PublicKeys(context.Background())
+		require.NoError(t, err)
+		require.Len(t, keys.Keys, 1)
+		assert.True(t, keys.Keys[0].IsPublic())
+		assert.Equal(t, key.KeyID, keys.Keys[0].KeyID)
+
+		t.Run("case=token does not outlive the session", func(t *testing.T) {
+			s := newSession(t)
+			s.ExpiresAt = time.Now().Add(10 * time.Second).UTC()
+
+			_, expiresAt, err := reg.SessionTokenizer().TokenizeSession(context.Background(), s)
+			require.NoError(t, err)
+			assert.Equal(t, s.ExpiresAt, expiresAt)
+		})
+
+		t.Run("case=claims mapper", func(t *testing.T) {
+			conf.MustSet(config.ViperKeySessionTokenizerClaimsMapperURL, "file://./stub/claims.jsonnet")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeySessionTokenizerClaimsMapperURL, "")
+			})
+
+			s := newSession(t)
+			token, _, err := reg.SessionTokenizer().TokenizeSession(context.Background(), s)
+			require.NoError(t, err)
+
+			claims, custom := verify(t, token, key)
+			assert.Equal(t, s.Identity.ID.String(), claims.Subject, "the mapper must not overwrite reserved claims")
+			assert.Equal(t, "bar", custom["baz"])
+			assert.Equal(t, "aal1", custom["aal"])
+		})
+
+		t.Run("case=claims mapper must return an object", func(t *testing.T) {
+			conf.MustSet(config.ViperKeySessionTokenizerClaimsMapperURL, "base64://WyJub3QiLCAiYW4iLCAib2JqZWN0Il0=")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeySessionTokenizerClaimsMapperURL, "")
+			})
+
+			_, _, err := reg.SessionTokenizer().TokenizeSession(context.Background(), newSession(t))
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("case=reloads rotated keys once the cache expires", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		key := writeTokenizerKeys(t, path)
+		conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, "file://"+path)
+		conf.MustSet(config.ViperKeySessionTokenizerJWKSCacheTTL, "1h")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, "")
+			conf.MustSet(config.ViperKeySessionTokenizerJWKSCacheTTL, "5m")
+		})
+
+		token, _, err := reg.SessionTokenizer().TokenizeSession(context.Background(), newSession(t))
+		require.NoError(t, err)
+		verify(t, token, key)
+
+		rotated := writeTokenizerKeys(t, path)
+		token, _, err = reg.SessionTokenizer().TokenizeSession(context.Background(), newSession(t))
+		require.NoError(t, err)
+		verify(t, token, key)
+
+		conf.MustSet(config.ViperKeySessionTokenizerJWKSCacheTTL, "0s")
+		token, _, err = reg.SessionTokenizer().TokenizeSession(context.Background(), newSession(t))
+		require.NoError(t, err)
+		verify(t, token, rotated)
+	})
+
+	t.Run("case=rejects symmetric keys", func(t *testing.T) {
+		key := jose.JSONWebKey{Key: []byte("a-very-secret-shared-key-of-32b!"), KeyID: x.NewUUID().String(), Algorithm: string(jose.HS256), Use: "sig"}
+		raw, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key}})
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, ioutil.WriteFile(path, raw, 0600))
+		conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, "file://"+path)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionTokenizerJWKSURL, "")
+		})
+
+		_, _, err = reg.SessionTokenizer().TokenizeSession(context.Background(), newSession(t))
+		require.Error(t, err)
+		assert.Contains(t, fmt.Sprintf("%+v", err), "symmetric key")
+
+		_, err = reg.SessionTokenizer().PublicKeys(context.Background())
+		require.Error(t, err)
+	})
+}
@@ -47,7 +47,7 @@ fi
 (cd test/e2e/proxy; npm i)
 
 kratos=./test/e2e/.bin/kratos
-go build -tags sqlite -o $kratos .
+go build -tags sqlite,json1 -o $kratos .
 
 if [ -z ${CI+x} ]; then
   docker rm mailslurper hydra hydra-ui -f || true
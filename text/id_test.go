@@ -8,6 +8,11 @@ import (
 
 func TestIDs(t *testing.T) {
 	assert.Equal(t, 1010000, int(InfoSelfServiceLoginRoot))
+	assert.Equal(t, 1010003, int(InfoSelfServiceLoginTOTP))
+	assert.Equal(t, 1010004, int(InfoSelfServiceLoginWebAuthn))
+	assert.Equal(t, 1010005, int(InfoSelfServiceLoginLookupSecret))
+	assert.Equal(t, 1010007, int(InfoSelfServiceLoginCode))
+	assert.Equal(t, 1010008, int(InfoSelfServiceLoginCodeSent))
 
 	assert.Equal(t, 1020000, int(InfoSelfServiceLogout))
 
@@ -15,19 +20,47 @@ func TestIDs(t *testing.T) {
 
 	assert.Equal(t, 1040000, int(InfoSelfServiceRegistrationRoot))
 	assert.Equal(t, 1040001, int(InfoSelfServiceRegistration))
+	assert.Equal(t, 1040003, int(InfoSelfServiceRegistrationCode))
+	assert.Equal(t, 1040004, int(InfoSelfServiceRegistrationCodeSent))
 
 	assert.Equal(t, 1050000, int(InfoSelfServiceSettings))
 	assert.Equal(t, 1050001, int(InfoSelfServiceSettingsUpdateSuccess))
+	assert.Equal(t, 1050004, int(InfoSelfServiceSettingsUpdateUnlinkTOTP))
+	assert.Equal(t, 1050005, int(InfoSelfServiceSettingsTOTPQRCode))
+	assert.Equal(t, 1050006, int(InfoSelfServiceSettingsTOTPSecret))
+	assert.Equal(t, 1050007, int(InfoSelfServiceSettingsRegisterWebAuthn))
+	assert.Equal(t, 1050008, int(InfoSelfServiceSettingsRegisterWebAuthnDisplayName))
+	assert.Equal(t, 1050009, int(InfoSelfServiceSettingsRemoveWebAuthn))
+	assert.Equal(t, 1050010, int(InfoSelfServiceSettingsRevealLookup))
+	assert.Equal(t, 1050016, int(InfoSelfServiceSettingsDisableLookup))
 
 	assert.Equal(t, 1060000, int(InfoSelfServiceRecovery))
 	assert.Equal(t, 1060001, int(InfoSelfServiceRecoverySuccessful))
 	assert.Equal(t, 1060002, int(InfoSelfServiceRecoveryEmailSent))
+	assert.Equal(t, 1060004, int(InfoSelfServiceRecoveryCodeSent))
 
 	assert.Equal(t, 1070000, int(InfoSelfServiceVerification))
 
 	assert.Equal(t, 4000000, int(ErrorValidation))
 	assert.Equal(t, 4000001, int(ErrorValidationGeneric))
 	assert.Equal(t, 4000002, int(ErrorValidationRequired))
+	assert.Equal(t, 4000009, int(ErrorValidationWebAuthnVerifierWrong))
+	assert.Equal(t, 4000010, int(ErrorValidationLookupAlreadyUsed))
+	assert.Equal(t, 4000011, int(ErrorValidationLookupInvalid))
+	assert.Equal(t, 4000012, int(ErrorValidationCodeInvalid))
+	assert.Equal(t, 4000013, int(ErrorValidationCodeExpired))
+	assert.Equal(t, 4000014, int(ErrorValidationCodeTooManyAttempts))
+	assert.Equal(t, 4000015, int(ErrorValidationIdentityInactive))
+	assert.Equal(t, 4000016, int(ErrorValidationPasswordMinLength))
+	assert.Equal(t, 4000017, int(ErrorValidationPasswordMaxLength))
+	assert.Equal(t, 4000018, int(ErrorValidationPasswordCharacterClass))
+	assert.Equal(t, 4000019, int(ErrorValidationPasswordDenied))
+	assert.Equal(t, 4000020, int(ErrorValidationPasswordIdentifierTooSimilar))
+	assert.Equal(t, 4000021, int(ErrorValidationPasswordTooManyBreaches))
+	assert.Equal(t, 4000022, int(ErrorValidationWebAuthnCloned))
+	assert.Equal(t, 4000023, int(ErrorValidationCodeTooManySent))
+	assert.Equal(t, 4000024, int(ErrorValidationTOTPAlreadyUsed))
+	assert.Equal(t, 4000025, int(ErrorValidationTOTPTooManyAttempts))
 
 	assert.Equal(t, 4010000, int(ErrorValidationLogin))
 	assert.Equal(t, 4010001, int(ErrorValidationLoginFlowExpired))
@@ -41,9 +74,11 @@ func TestIDs(t *testing.T) {
 	assert.Equal(t, 4060000, int(ErrorValidationRecovery))
 	assert.Equal(t, 4060001, int(ErrorValidationRecoveryRetrySuccess))
 	assert.Equal(t, 4060002, int(ErrorValidationRecoveryStateFailure))
+	assert.Equal(t, 4060006, int(ErrorValidationRecoveryCodeTooManyAttempts))
 
 	assert.Equal(t, 4070000, int(ErrorValidationVerification))
 	assert.Equal(t, 4070001, int(ErrorValidationVerificationTokenInvalidOrAlreadyUsed))
+	assert.Equal(t, 4070006, int(ErrorValidationVerificationCodeTooManyAttempts))
 
 	assert.Equal(t, 5000000, int(ErrorSystem))
 }
@@ -6,9 +6,15 @@ import (
 )
 
 const (
-	InfoSelfServiceLoginRoot ID = 1010000 + iota // 1010000
-	InfoSelfServiceLogin                         // 1010001
-	InfoSelfServiceLoginWith                     // 1010002
+	InfoSelfServiceLoginRoot              ID = 1010000 + iota // 1010000
+	InfoSelfServiceLogin                                      // 1010001
+	InfoSelfServiceLoginWith                                  // 1010002
+	InfoSelfServiceLoginTOTP                                  // 1010003
+	InfoSelfServiceLoginWebAuthn                              // 1010004
+	InfoSelfServiceLoginLookupSecret                          // 1010005
+	InfoSelfServiceLoginLookupSecretLabel                     // 1010006
+	InfoSelfServiceLoginCode                                  // 1010007
+	InfoSelfServiceLoginCodeSent                              // 1010008
 )
 
 const (
@@ -41,6 +47,62 @@ func NewInfoLoginWith(provider string) *Message {
 	}
 }
 
+func NewInfoLoginTOTP() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginTOTP,
+		Text: "Use Authenticator",
+		Type: Info,
+	}
+}
+
+func NewInfoLoginWebAuthn() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginWebAuthn,
+		Text: "Use security key",
+		Type: Info,
+	}
+}
+
+func NewInfoLoginLookupSecret() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginLookupSecret,
+		Text: "Use backup recovery code",
+		Type: Info,
+	}
+}
+
+func NewInfoLoginLookupSecretLabel() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginLookupSecretLabel,
+		Text: "Backup recovery code",
+		Type: Info,
+	}
+}
+
+func NewInfoLoginCode() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginCode,
+		Text: "Sign in with code",
+		Type: Info,
+	}
+}
+
+func NewInfoLoginCodeSent() *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginCodeSent,
+		Text: "An email containing a sign in code has been sent to the email address you provided.",
+		Type: Info,
+	}
+}
+
+func NewInfoLoginMFA() *Message {
+	return &Message{
+		ID:   InfoSelfServiceMFA,
+		Text: "Please complete the second authentication challenge.",
+		Type: Info,
+	}
+}
+
 func NewErrorValidationLoginFlowExpired(ago time.Duration) *Message {
 	return &Message{
 		ID:   ErrorValidationLoginFlowExpired,
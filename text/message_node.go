@@ -7,6 +7,7 @@ const (
 	InfoNodeLabelSave                              // 1070003
 	InfoNodeLabelID                                // 1070004
 	InfoNodeLabelSubmit                            // 1070005
+	InfoNodeLabelVerifyOTP                         // 1070006
 )
 
 func NewInfoNodeInputPassword() *Message {
@@ -48,3 +49,11 @@ func NewInfoNodeLabelID() *Message {
 		Type: Info,
 	}
 }
+
+func NewInfoNodeLabelVerifyOTP() *Message {
+	return &Message{
+		ID:   InfoNodeLabelVerifyOTP,
+		Text: "Verify code",
+		Type: Info,
+	}
+}
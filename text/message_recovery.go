@@ -13,6 +13,8 @@ const (
 	InfoSelfServiceRecovery           ID = 1060000 + iota // 1060000
 	InfoSelfServiceRecoverySuccessful                     // 1060001
 	InfoSelfServiceRecoveryEmailSent                      // 1060002
+	InfoSelfServiceRecoverySMSSent                        // 1060003
+	InfoSelfServiceRecoveryCodeSent                       // 1060004
 )
 
 const (
@@ -22,6 +24,8 @@ const (
 	ErrorValidationRecoveryMissingRecoveryToken                          // 4060003
 	ErrorValidationRecoveryTokenInvalidOrAlreadyUsed                     // 4060004
 	ErrorValidationRecoveryFlowExpired                                   // 4060005
+	ErrorValidationRecoveryCodeTooManyAttempts                           // 4060006
+	ErrorValidationRecoveryCodeExpired                                   // 4060007
 )
 
 func NewErrorValidationRecoveryFlowExpired(ago time.Duration) *Message {
@@ -56,6 +60,42 @@ func NewRecoveryEmailSent() *Message {
 	}
 }
 
+func NewRecoverySMSSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceRecoverySMSSent,
+		Type:    Info,
+		Text:    "A text message containing a recovery link has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
+func NewRecoveryCodeSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceRecoveryCodeSent,
+		Type:    Info,
+		Text:    "A recovery code has been sent to the address you provided. Please enter it below.",
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationRecoveryCodeTooManyAttempts() *Message {
+	return &Message{
+		ID:      ErrorValidationRecoveryCodeTooManyAttempts,
+		Text:    "The recovery code was entered incorrectly too many times. Please request a new code.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationRecoveryCodeExpired() *Message {
+	return &Message{
+		ID:      ErrorValidationRecoveryCodeExpired,
+		Text:    "The recovery code has expired. Please request a new code.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationRecoveryMissingRecoveryToken() error {
 	return errors.WithStack(herodot.
 		ErrBadRequest.
@@ -6,9 +6,11 @@ import (
 )
 
 const (
-	InfoSelfServiceRegistrationRoot ID = 1040000 + iota // 1040000
-	InfoSelfServiceRegistration                         // 1040001
-	InfoSelfServiceRegistrationWith                     // 1040002
+	InfoSelfServiceRegistrationRoot     ID = 1040000 + iota // 1040000
+	InfoSelfServiceRegistration                             // 1040001
+	InfoSelfServiceRegistrationWith                         // 1040002
+	InfoSelfServiceRegistrationCode                         // 1040003
+	InfoSelfServiceRegistrationCodeSent                     // 1040004
 )
 
 const (
@@ -36,6 +38,22 @@ func NewInfoRegistrationWith(provider string) *Message {
 	}
 }
 
+func NewInfoRegistrationCode() *Message {
+	return &Message{
+		ID:   InfoSelfServiceRegistrationCode,
+		Text: "Sign up with code",
+		Type: Info,
+	}
+}
+
+func NewInfoRegistrationCodeSent() *Message {
+	return &Message{
+		ID:   InfoSelfServiceRegistrationCodeSent,
+		Text: "An email containing a code has been sent to the email address you provided. Enter it to complete the registration.",
+		Type: Info,
+	}
+}
+
 func NewErrorValidationRegistrationFlowExpired(ago time.Duration) *Message {
 	return &Message{
 		ID:   ErrorValidationRegistrationFlowExpired,
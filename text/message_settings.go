@@ -2,6 +2,7 @@ package text
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -10,6 +11,19 @@ const (
 	InfoSelfServiceSettingsUpdateSuccess
 	InfoSelfServiceSettingsUpdateLinkOidc
 	InfoSelfServiceSettingsUpdateUnlinkOidc
+	InfoSelfServiceSettingsUpdateUnlinkTOTP
+	InfoSelfServiceSettingsTOTPQRCode
+	InfoSelfServiceSettingsTOTPSecret
+	InfoSelfServiceSettingsRegisterWebAuthn
+	InfoSelfServiceSettingsRegisterWebAuthnDisplayName
+	InfoSelfServiceSettingsRemoveWebAuthn
+	InfoSelfServiceSettingsRevealLookup
+	InfoSelfServiceSettingsRegenerateLookup
+	InfoSelfServiceSettingsLookupSecret
+	InfoSelfServiceSettingsLookupSecretUsed
+	InfoSelfServiceSettingsLookupSecretList
+	InfoSelfServiceSettingsLookupConfirm
+	InfoSelfServiceSettingsDisableLookup
 )
 
 const (
@@ -57,3 +71,123 @@ func NewInfoSelfServiceSettingsUpdateUnlinkOIDC(provider string) *Message {
 		}),
 	}
 }
+
+func NewInfoSelfServiceSettingsUpdateUnlinkTOTP() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsUpdateUnlinkTOTP,
+		Text: "Unlink TOTP Authenticator App",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsTOTPQRCode() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsTOTPQRCode,
+		Text: "Authenticator app QR code",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsTOTPSecret(secret string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsTOTPSecret,
+		Text: secret,
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"secret": secret,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsRegisterWebAuthn() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRegisterWebAuthn,
+		Text: "Add security key",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsRegisterWebAuthnDisplayName() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRegisterWebAuthnDisplayName,
+		Text: "Name of the security key",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsRemoveWebAuthn(name string, createdAt time.Time) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRemoveWebAuthn,
+		Text: fmt.Sprintf("Remove security key \"%s\"", name),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"display_name": name,
+			"added_at":     createdAt,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsRevealLookup() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRevealLookup,
+		Text: "Reveal backup recovery codes",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsRegenerateLookup() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsRegenerateLookup,
+		Text: "Generate new backup recovery codes",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsLookupSecret(secret string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsLookupSecret,
+		Text: secret,
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"secret": secret,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsLookupSecretUsed(usedAt time.Time) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsLookupSecretUsed,
+		Text: fmt.Sprintf("Secret was used at %s", usedAt),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"used_at": usedAt,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsLookupSecretList(secrets []string, raw []interface{}) *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsLookupSecretList,
+		Text: strings.Join(secrets, ", "),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"secrets": raw,
+		}),
+	}
+}
+
+func NewInfoSelfServiceSettingsLookupConfirm() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsLookupConfirm,
+		Text: "Confirm backup recovery codes",
+		Type: Info,
+	}
+}
+
+func NewInfoSelfServiceSettingsDisableLookup() *Message {
+	return &Message{
+		ID:   InfoSelfServiceSettingsDisableLookup,
+		Text: "Disable backup recovery codes",
+		Type: Info,
+	}
+}
@@ -14,6 +14,23 @@ const (
 	ErrorValidationInvalidCredentials
 	ErrorValidationDuplicateCredentials
 	ErrorValidationTOTPVerifierWrong
+	ErrorValidationWebAuthnVerifierWrong
+	ErrorValidationLookupAlreadyUsed
+	ErrorValidationLookupInvalid
+	ErrorValidationCodeInvalid
+	ErrorValidationCodeExpired
+	ErrorValidationCodeTooManyAttempts
+	ErrorValidationIdentityInactive
+	ErrorValidationPasswordMinLength
+	ErrorValidationPasswordMaxLength
+	ErrorValidationPasswordCharacterClass
+	ErrorValidationPasswordDenied
+	ErrorValidationPasswordIdentifierTooSimilar
+	ErrorValidationPasswordTooManyBreaches
+	ErrorValidationWebAuthnCloned
+	ErrorValidationCodeTooManySent
+	ErrorValidationTOTPAlreadyUsed
+	ErrorValidationTOTPTooManyAttempts
 )
 
 func NewValidationErrorGeneric(reason string) *Message {
@@ -89,6 +106,24 @@ func NewErrorValidationDuplicateCredentials() *Message {
 	}
 }
 
+func NewErrorValidationWebAuthnVerifierWrong() *Message {
+	return &Message{
+		ID:      ErrorValidationWebAuthnVerifierWrong,
+		Text:    "The security key could not be verified.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationWebAuthnCloned() *Message {
+	return &Message{
+		ID:      ErrorValidationWebAuthnCloned,
+		Text:    "The security key may have been cloned and can no longer be used. Please remove it and sign in using another method.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationTOTPVerifierWrong() *Message {
 	return &Message{
 		ID:      ErrorValidationTOTPVerifierWrong,
@@ -97,3 +132,160 @@ func NewErrorValidationTOTPVerifierWrong() *Message {
 		Context: context(nil),
 	}
 }
+
+func NewErrorValidationTOTPAlreadyUsed() *Message {
+	return &Message{
+		ID:      ErrorValidationTOTPAlreadyUsed,
+		Text:    "This authentication code has already been used. Please wait for the next one.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationTOTPTooManyAttempts() *Message {
+	return &Message{
+		ID:      ErrorValidationTOTPTooManyAttempts,
+		Text:    "The authentication code was entered incorrectly too many times. Please sign in again.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationLookupAlreadyUsed() *Message {
+	return &Message{
+		ID:      ErrorValidationLookupAlreadyUsed,
+		Text:    "This backup recovery code has already been used.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationLookupInvalid() *Message {
+	return &Message{
+		ID:      ErrorValidationLookupInvalid,
+		Text:    "The backup recovery code is not valid.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationCodeInvalid() *Message {
+	return &Message{
+		ID:      ErrorValidationCodeInvalid,
+		Text:    "The code is invalid or has already been used.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationCodeExpired() *Message {
+	return &Message{
+		ID:      ErrorValidationCodeExpired,
+		Text:    "The code has expired. Please request a new one.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationCodeTooManyAttempts() *Message {
+	return &Message{
+		ID:      ErrorValidationCodeTooManyAttempts,
+		Text:    "The code was entered incorrectly too many times. Please request a new one.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationCodeTooManySent() *Message {
+	return &Message{
+		ID:      ErrorValidationCodeTooManySent,
+		Text:    "Too many codes were requested. Please start over.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationIdentityInactive() *Message {
+	return &Message{
+		ID:      ErrorValidationIdentityInactive,
+		Text:    "This account was deactivated. Please contact an administrator.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordMinLength(expected, actual int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMinLength,
+		Text: fmt.Sprintf("The password must be at least %d characters long, but got %d.", expected, actual),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"min_length":    expected,
+			"actual_length": actual,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordMaxLength(expected, actual int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMaxLength,
+		Text: fmt.Sprintf("The password must be at most %d characters long, but got %d.", expected, actual),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"max_length":    expected,
+			"actual_length": actual,
+		}),
+	}
+}
+
+var passwordCharacterClassNames = map[string]string{
+	"lowercase": "lowercase letter",
+	"uppercase": "uppercase letter",
+	"digit":     "digit",
+	"symbol":    "special character",
+}
+
+func NewErrorValidationPasswordCharacterClass(class string) *Message {
+	name, ok := passwordCharacterClassNames[class]
+	if !ok {
+		name = class
+	}
+
+	return &Message{
+		ID:   ErrorValidationPasswordCharacterClass,
+		Text: fmt.Sprintf("The password must contain at least one %s.", name),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"character_class": class,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordDenied() *Message {
+	return &Message{
+		ID:      ErrorValidationPasswordDenied,
+		Text:    "The password can not be used because it is on the list of forbidden passwords.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordIdentifierTooSimilar() *Message {
+	return &Message{
+		ID:      ErrorValidationPasswordIdentifierTooSimilar,
+		Text:    "The password can not be used because it is too similar to the identifier.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordTooManyBreaches(breaches int64) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordTooManyBreaches,
+		Text: "The password has been found in data breaches and must no longer be used.",
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"breaches": breaches,
+		}),
+	}
+}
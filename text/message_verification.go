@@ -7,7 +7,9 @@ import (
 
 const (
 	InfoSelfServiceVerification          ID = 1070000 + iota // 1070000
-	InfoSelfServiceVerificationEmailSent                     // 1070002
+	InfoSelfServiceVerificationEmailSent                     // 1070001
+	InfoSelfServiceVerificationSMSSent                       // 1070002
+	InfoSelfServiceVerificationCodeSent                      // 1070003
 )
 
 const (
@@ -17,6 +19,8 @@ const (
 	ErrorValidationVerificationStateFailure                                  // 4070003
 	ErrorValidationVerificationMissingVerificationToken                      // 4070004
 	ErrorValidationVerificationFlowExpired                                   // 4070005
+	ErrorValidationVerificationCodeTooManyAttempts                           // 4070006
+	ErrorValidationVerificationCodeExpired                                   // 4070007
 )
 
 func NewErrorValidationVerificationFlowExpired(ago time.Duration) *Message {
@@ -39,6 +43,42 @@ func NewVerificationEmailSent() *Message {
 	}
 }
 
+func NewVerificationSMSSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceVerificationSMSSent,
+		Type:    Info,
+		Text:    "A text message containing a verification link has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
+func NewVerificationCodeSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceVerificationCodeSent,
+		Type:    Info,
+		Text:    "A verification code has been sent to the address you provided. Please enter it below.",
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationVerificationCodeTooManyAttempts() *Message {
+	return &Message{
+		ID:      ErrorValidationVerificationCodeTooManyAttempts,
+		Text:    "The verification code was entered incorrectly too many times. Please request a new code.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationVerificationCodeExpired() *Message {
+	return &Message{
+		ID:      ErrorValidationVerificationCodeExpired,
+		Text:    "The verification code has expired. Please request a new code.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationVerificationTokenInvalidOrAlreadyUsed() *Message {
 	return &Message{
 		ID:      ErrorValidationVerificationTokenInvalidOrAlreadyUsed,
@@ -214,6 +214,12 @@ func TestContainer(t *testing.T) {
 				&node.Node{Group: node.DefaultGroup, Type: node.Input, Attributes: &node.InputAttributes{Name: "foo.bar.baz", Type: node.InputAttributeTypeText}, Messages: text.Messages{*text.NewValidationErrorGeneric("test")}, Meta: new(node.Meta)},
 			}}},
 			{err: &jsonschema.ValidationError{Message: "test", InstancePtr: ""}, expect: Container{Nodes: node.Nodes{}, Messages: text.Messages{*text.NewValidationErrorGeneric("test")}}},
+			{err: &schema.ValidationListError{Validations: []*schema.ValidationError{
+				schema.NewHookValidationError("#/", "global", text.Messages{*text.NewValidationErrorGeneric("global")}),
+				schema.NewHookValidationError("#/foo", "field", text.Messages{*text.NewValidationErrorGeneric("field")}),
+			}}, expect: Container{Nodes: node.Nodes{
+				&node.Node{Group: node.DefaultGroup, Type: node.Input, Attributes: &node.InputAttributes{Name: "foo", Type: node.InputAttributeTypeText}, Messages: text.Messages{*text.NewValidationErrorGeneric("field")}, Meta: new(node.Meta)},
+			}, Messages: text.Messages{*text.NewValidationErrorGeneric("global")}}},
 		} {
 			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 				for _, in := range []error{tc.err, errors.WithStack(tc.err)} {
@@ -9,10 +9,12 @@ const (
 	InputAttributeTypeCheckbox      InputAttributeType = "checkbox"
 	InputAttributeTypeHidden        InputAttributeType = "hidden"
 	InputAttributeTypeEmail         InputAttributeType = "email"
+	InputAttributeTypeTel           InputAttributeType = "tel"
 	InputAttributeTypeSubmit        InputAttributeType = "submit"
 	InputAttributeTypeDateTimeLocal InputAttributeType = "datetime-local"
 	InputAttributeTypeDate          InputAttributeType = "date"
 	InputAttributeTypeURI           InputAttributeType = "url"
+	InputAttributeTypeButton        InputAttributeType = "button"
 )
 
 // swagger:model uiNodeInputAttributeType
@@ -61,6 +63,10 @@ type InputAttributes struct {
 	// The input's pattern.
 	Pattern string `json:"pattern,omitempty"`
 
+	// OnClick may contain javascript which should be executed on click. This is primarily
+	// used for WebAuthn.
+	OnClick string `json:"onclick,omitempty"`
+
 	// Sets the input's disabled field to true or false.
 	//
 	// required: true
@@ -71,6 +77,11 @@ type InputAttributes struct {
 //
 // swagger:model uiNodeImageAttributes
 type ImageAttributes struct {
+	// A unique identifier
+	//
+	// required: true
+	Identifier string `json:"id"`
+
 	// The image's source URL.
 	//
 	// format: uri
@@ -99,17 +110,63 @@ type AnchorAttributes struct {
 //
 // swagger:model uiNodeTextAttributes
 type TextAttributes struct {
+	// A unique identifier
+	//
+	// required: true
+	Identifier string `json:"id"`
+
 	// The text of the text node.
 	//
 	// required: true
 	Text *text.Message `json:"text"`
 }
 
+// ScriptAttributes represent script nodes which load javascript.
+//
+// swagger:model uiNodeScriptAttributes
+type ScriptAttributes struct {
+	// The script source
+	//
+	// required: true
+	Source string `json:"src"`
+
+	// The script async type
+	//
+	// required: true
+	Async bool `json:"async"`
+
+	// The script referrer policy
+	//
+	// required: true
+	ReferrerPolicy string `json:"referrerpolicy"`
+
+	// The script cross origin policy
+	//
+	// required: true
+	CrossOrigin string `json:"crossorigin"`
+
+	// The script's integrity hash
+	//
+	// required: true
+	Integrity string `json:"integrity"`
+
+	// The script MIME type
+	//
+	// required: true
+	Type string `json:"type"`
+
+	// A unique identifier
+	//
+	// required: true
+	Identifier string `json:"id"`
+}
+
 var (
 	_ Attributes = new(InputAttributes)
 	_ Attributes = new(ImageAttributes)
 	_ Attributes = new(AnchorAttributes)
 	_ Attributes = new(TextAttributes)
+	_ Attributes = new(ScriptAttributes)
 )
 
 func (a *InputAttributes) ID() string {
@@ -117,7 +174,7 @@ func (a *InputAttributes) ID() string {
 }
 
 func (a *ImageAttributes) ID() string {
-	return ""
+	return a.Identifier
 }
 
 func (a *AnchorAttributes) ID() string {
@@ -125,7 +182,11 @@ func (a *AnchorAttributes) ID() string {
 }
 
 func (a *TextAttributes) ID() string {
-	return ""
+	return a.Identifier
+}
+
+func (a *ScriptAttributes) ID() string {
+	return a.Identifier
 }
 
 func (a *InputAttributes) SetValue(value interface{}) {
@@ -144,6 +205,10 @@ func (a *TextAttributes) SetValue(value interface{}) {
 	a.Text, _ = value.(*text.Message)
 }
 
+func (a *ScriptAttributes) SetValue(value interface{}) {
+	a.Source, _ = value.(string)
+}
+
 func (a *InputAttributes) GetValue() interface{} {
 	return a.FieldValue
 }
@@ -160,6 +225,10 @@ func (a *TextAttributes) GetValue() interface{} {
 	return a.Text
 }
 
+func (a *ScriptAttributes) GetValue() interface{} {
+	return a.Source
+}
+
 func (a *InputAttributes) Reset() {
 	a.FieldValue = nil
 }
@@ -172,3 +241,6 @@ func (a *AnchorAttributes) Reset() {
 
 func (a *TextAttributes) Reset() {
 }
+
+func (a *ScriptAttributes) Reset() {
+}
@@ -64,6 +64,41 @@ func NewInputField(name string, value interface{}, group Group, inputType InputA
 	}
 }
 
+func NewImageField(id string, src string, group Group) *Node {
+	return &Node{
+		Type:       Image,
+		Group:      group,
+		Attributes: &ImageAttributes{Identifier: id, Source: src},
+		Meta:       &Meta{},
+	}
+}
+
+func NewTextField(id string, t *text.Message, group Group) *Node {
+	return &Node{
+		Type:       Text,
+		Group:      group,
+		Attributes: &TextAttributes{Identifier: id, Text: t},
+		Meta:       &Meta{},
+	}
+}
+
+func NewScriptField(name string, src string, group Group, integrity string) *Node {
+	return &Node{
+		Type:  Script,
+		Group: group,
+		Attributes: &ScriptAttributes{
+			Type:           "text/javascript",
+			Identifier:     name,
+			Source:         src,
+			Async:          true,
+			ReferrerPolicy: "no-referrer",
+			CrossOrigin:    "anonymous",
+			Integrity:      integrity,
+		},
+		Meta: &Meta{},
+	}
+}
+
 func NewInputFieldFromSchema(name string, group Group, p jsonschemax.Path, opts ...InputAttributesModifier) *Node {
 	attr := &InputAttributes{
 		Name: name,
@@ -76,6 +111,8 @@ func NewInputFieldFromSchema(name string, group Group, p jsonschemax.Path, opts
 		attr.Type = InputAttributeTypeDateTimeLocal
 	case "email":
 		attr.Type = InputAttributeTypeEmail
+	case "tel":
+		attr.Type = InputAttributeTypeTel
 	case "date":
 		attr.Type = InputAttributeTypeDate
 	case "uri":
@@ -31,13 +31,20 @@ const (
 	PasswordGroup         Group = "password"
 	OpenIDConnectGroup    Group = "oidc"
 	ProfileGroup          Group = "profile"
+	TOTPGroup             Group = "totp"
+	WebAuthnGroup         Group = "webauthn"
+	LookupGroup           Group = "lookup_secret"
 	RecoveryLinkGroup     Group = "link"
 	VerificationLinkGroup Group = "link"
+	RecoveryCodeGroup     Group = "code"
+	VerificationCodeGroup Group = "code"
+	CodeGroup             Group = "code"
 
 	Text   Type = "text"
 	Input  Type = "input"
 	Image  Type = "img"
 	Anchor Type = "a"
+	Script Type = "script"
 )
 
 // swagger:model uiNodes
@@ -52,7 +59,7 @@ type Nodes []*Node
 type Node struct {
 	// The node's type
 	//
-	// Can be one of: text, input, img, a
+	// Can be one of: text, input, img, a, script
 	//
 	// required: true
 	Type Type `json:"type" faker:"-"`
@@ -341,6 +348,8 @@ func (n *Node) UnmarshalJSON(data []byte) error {
 		attr = new(AnchorAttributes)
 	case Image:
 		attr = new(ImageAttributes)
+	case Script:
+		attr = new(ScriptAttributes)
 	default:
 		return fmt.Errorf("unexpected node type: %s", t)
 	}
@@ -372,6 +381,8 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 			t = Anchor
 		case *ImageAttributes:
 			t = Image
+		case *ScriptAttributes:
+			t = Script
 		default:
 			return nil, errors.WithStack(fmt.Errorf("unknown node type: %T", n.Attributes))
 		}
@@ -63,6 +63,9 @@ func NewFakeCSRFHandler(name string) *FakeCSRFHandler {
 func (f *FakeCSRFHandler) ExemptPath(s string) {
 }
 
+func (f *FakeCSRFHandler) ExemptGlob(s string) {
+}
+
 func (f *FakeCSRFHandler) IgnorePath(s string) {
 }
 
@@ -81,6 +84,7 @@ type CSRFHandler interface {
 	http.Handler
 	RegenerateToken(w http.ResponseWriter, r *http.Request) string
 	ExemptPath(string)
+	ExemptGlob(string)
 	IgnorePath(string)
 }
 